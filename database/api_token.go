@@ -0,0 +1,82 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIToken is db table, a long-lived personal token of a user
+type APIToken struct {
+	ID         int32  `gorm:"primaryKey"`
+	UserName   string `gorm:"index;not null"` // foreign key to users.name (migration 0005)
+	Name       string // label given by the user
+	TokenHash  string `gorm:"uniqueIndex;not null"` // the secret itself is never stored
+	Prefix     string // first characters of the secret, shown to identify the token
+	Scopes     string // space separated
+	CreatedAt  time.Time
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+}
+
+// Active reports whether the token can be used at now.
+func (t APIToken) Active(now time.Time) bool {
+	if t.RevokedAt.Valid {
+		return false
+	}
+	return !t.ExpiresAt.Valid || now.Before(t.ExpiresAt.Time)
+}
+
+// save api token and return id
+func SaveAPIToken(db *gorm.DB, token APIToken) (int32, error) {
+	if token.ID != 0 {
+		return 0, errors.New("must not specify api token id")
+	}
+	if token.UserName == "" || token.TokenHash == "" {
+		return 0, errors.New("user name / token hash is empty")
+	}
+	if err := db.Create(&token).Error; err != nil {
+		return 0, err
+	}
+	return token.ID, nil
+}
+
+func FetchAPITokenFromHash(db *gorm.DB, hash string) (APIToken, error) {
+	token := APIToken{}
+	if err := db.Where("token_hash = ?", hash).Take(&token).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return APIToken{}, ErrNotExist
+	} else if err != nil {
+		return APIToken{}, err
+	}
+	return token, nil
+}
+
+// FetchAPITokens returns the tokens of the user that are not revoked, oldest first.
+func FetchAPITokens(db *gorm.DB, userName string) ([]APIToken, error) {
+	tokens := []APIToken{}
+	if err := db.Where("user_name = ? AND revoked_at IS NULL", userName).Order("id asc").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes the token only if it belongs to the user.
+func RevokeAPIToken(db *gorm.DB, userName string, id int32) error {
+	result := db.Model(&APIToken{}).
+		Where("id = ? AND user_name = ? AND revoked_at IS NULL", id, userName).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotExist
+	}
+	return nil
+}
+
+func TouchAPIToken(db *gorm.DB, id int32, now time.Time) error {
+	return db.Model(&APIToken{}).Where("id = ?", id).Update("last_used_at", now).Error
+}
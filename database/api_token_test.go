@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestAPIToken(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if err := RegisterUser(db, "user1", "id1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterUser(db, "user2", "id2"); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := SaveAPIToken(db, APIToken{
+		UserName:  "user1",
+		Name:      "ci",
+		TokenHash: "hash1",
+		Scopes:    "submit",
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := FetchAPITokenFromHash(db, "hash1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.ID != id || token.UserName != "user1" || !token.Active(time.Now()) {
+		t.Fatal("unexpected token:", token)
+	}
+	if _, err := FetchAPITokenFromHash(db, "unknown"); err != ErrNotExist {
+		t.Fatal("expected ErrNotExist:", err)
+	}
+
+	if err := TouchAPIToken(db, id, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// other users cannot revoke the token
+	if err := RevokeAPIToken(db, "user2", id); err != ErrNotExist {
+		t.Fatal("expected ErrNotExist:", err)
+	}
+	tokens, err := FetchAPITokens(db, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || !tokens[0].LastUsedAt.Valid {
+		t.Fatal("unexpected tokens:", tokens)
+	}
+
+	if err := RevokeAPIToken(db, "user1", id); err != nil {
+		t.Fatal(err)
+	}
+	if err := RevokeAPIToken(db, "user1", id); err != ErrNotExist {
+		t.Fatal("revoking twice must fail:", err)
+	}
+	tokens, err = FetchAPITokens(db, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 0 {
+		t.Fatal("revoked tokens must not be listed:", tokens)
+	}
+	token, err = FetchAPITokenFromHash(db, "hash1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Active(time.Now()) {
+		t.Fatal("revoked token is active")
+	}
+}
+
+func TestAPITokenExpiry(t *testing.T) {
+	now := time.Now()
+	token := APIToken{ExpiresAt: sql.NullTime{Time: now.Add(time.Hour), Valid: true}}
+	if !token.Active(now) {
+		t.Fatal("token must be active before expiry")
+	}
+	if token.Active(now.Add(2 * time.Hour)) {
+		t.Fatal("token must not be active after expiry")
+	}
+}
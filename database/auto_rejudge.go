@@ -0,0 +1,120 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AutoRejudgeProgress is db table, the progress of the automatic rejudge of
+// outdated AC submissions of a problem
+type AutoRejudgeProgress struct {
+	ProblemName      string `gorm:"primaryKey"`
+	TestCasesVersion string // the version the rejudge is running for
+	Total            int32  // outdated ACs when the version was noticed
+	Queued           int32
+	LastSubmissionID int32 // submissions up to this id are queued
+	StartedAt        time.Time
+	FinishedAt       sql.NullTime
+}
+
+// FetchAutoRejudgeProgresses returns the progress of every problem, most recently started first.
+func FetchAutoRejudgeProgresses(db *gorm.DB) ([]AutoRejudgeProgress, error) {
+	progresses := []AutoRejudgeProgress{}
+	if err := db.Order("started_at desc, problem_name asc").Find(&progresses).Error; err != nil {
+		return nil, err
+	}
+	return progresses, nil
+}
+
+// QueueAutoRejudge enqueues rejudges of at most limit AC submissions judged
+// with an old TestCasesVersion and returns the number of queued tasks.
+//
+// A problem seen for the first time is only recorded, so that only the
+// test case updates after enabling the automatic rejudge are handled.
+func QueueAutoRejudge(db *gorm.DB, limit int, priority int32) (int, error) {
+	// problems which are new, updated or not finished
+	problems := []Problem{}
+	if err := db.Model(&Problem{}).
+		Select("problems.name, problems.test_cases_version").
+		Joins("LEFT JOIN auto_rejudge_progresses ON auto_rejudge_progresses.problem_name = problems.name").
+		Where("auto_rejudge_progresses.problem_name IS NULL OR auto_rejudge_progresses.test_cases_version <> problems.test_cases_version OR auto_rejudge_progresses.finished_at IS NULL").
+		Order("problems.name asc").
+		Find(&problems).Error; err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, problem := range problems {
+		if queued >= limit {
+			break
+		}
+		n, err := queueAutoRejudge(db, problem, limit-queued, priority)
+		if err != nil {
+			return queued, err
+		}
+		queued += n
+	}
+	return queued, nil
+}
+
+func queueAutoRejudge(db *gorm.DB, problem Problem, limit int, priority int32) (int, error) {
+	ids := []int32{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		progress := AutoRejudgeProgress{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("problem_name = ?", problem.Name).
+			Take(&progress).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(&AutoRejudgeProgress{
+				ProblemName:      problem.Name,
+				TestCasesVersion: problem.TestCasesVersion,
+				StartedAt:        now,
+				FinishedAt:       sql.NullTime{Time: now, Valid: true},
+			}).Error
+		} else if err != nil {
+			return err
+		}
+
+		isLatest := false
+		filter := SubmissionFilter{Problem: problem.Name, Statuses: []string{"AC"}, IsLatest: &isLatest}
+		if progress.TestCasesVersion != problem.TestCasesVersion {
+			total, err := CountRejudgeTargets(tx, filter)
+			if err != nil {
+				return err
+			}
+			progress = AutoRejudgeProgress{
+				ProblemName:      problem.Name,
+				TestCasesVersion: problem.TestCasesVersion,
+				Total:            int32(total),
+				StartedAt:        now,
+			}
+		}
+		if progress.FinishedAt.Valid {
+			return nil
+		}
+
+		if err := filter.query(tx).Where("id > ?", progress.LastSubmissionID).Order("id asc").Limit(limit).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := PushSystemSubmissionTask(tx, SubmissionData{ID: id}, priority); err != nil {
+				return err
+			}
+		}
+		if len(ids) == 0 {
+			progress.FinishedAt = sql.NullTime{Time: now, Valid: true}
+		} else {
+			progress.LastSubmissionID = ids[len(ids)-1]
+			progress.Queued += int32(len(ids))
+		}
+		return tx.Save(&progress).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
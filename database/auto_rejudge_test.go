@@ -0,0 +1,68 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestQueueAutoRejudge(t *testing.T) {
+	db := CreateTestDB(t)
+	createDummyProblem(t, db)
+
+	save := func(status, version string) int32 {
+		id, err := SaveSubmission(db, Submission{ProblemName: "aplusb", Source: "source", Status: status, TestCasesVersion: version})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	save("AC", "old")
+
+	// the first run only records the current version
+	if n, err := QueueAutoRejudge(db, 10, 0); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+
+	ac1 := save("AC", "tversion123")
+	save("WA", "tversion123")
+	save("AC", "tversion123")
+	ac3 := save("AC", "tversion123")
+
+	problem, err := FetchProblem(db, "aplusb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	problem.TestCasesVersion = "new"
+	if err := SaveProblem(db, problem); err != nil {
+		t.Fatal(err)
+	}
+
+	// the old AC recorded at the first run is also outdated
+	if n, err := QueueAutoRejudge(db, 2, 0); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+	progresses, err := FetchAutoRejudgeProgresses(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progresses) != 1 || progresses[0].TestCasesVersion != "new" || progresses[0].Total != 4 || progresses[0].Queued != 2 || progresses[0].LastSubmissionID != ac1 || progresses[0].FinishedAt.Valid {
+		t.Fatal("unexpected progress:", progresses)
+	}
+
+	if n, err := QueueAutoRejudge(db, 10, 0); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+	if n, err := QueueAutoRejudge(db, 10, 0); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+	progresses, err = FetchAutoRejudgeProgresses(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progresses[0].Queued != 4 || progresses[0].LastSubmissionID != ac3 || !progresses[0].FinishedAt.Valid {
+		t.Fatal("unexpected progress:", progresses)
+	}
+
+	if count, err := CountTasks(db); err != nil || count != 4 {
+		t.Fatal(count, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/glebarez/sqlite"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
@@ -23,6 +24,9 @@ const (
 
 var ErrNotExist = errors.New("not exist")
 
+// ErrAlreadyExists is returned when an equivalent row is already active.
+var ErrAlreadyExists = errors.New("already exists")
+
 type DSN struct {
 	Host     string
 	Port     int
@@ -63,27 +67,17 @@ func GetDSNFromEnv() DSN {
 	return dsn
 }
 
-func Connect(dsn DSN, enableLogger bool) *gorm.DB {
-	connStr := fmt.Sprintf(
+func (dsn DSN) connString() string {
+	return fmt.Sprintf(
 		"host=%s port=%d dbname=%s user=%s password=%s sslmode=disable",
 		dsn.Host, dsn.Port, dsn.Database, dsn.User, dsn.Password)
+}
+
+func Connect(dsn DSN, enableLogger bool) *gorm.DB {
+	connStr := dsn.connString()
 	log.Printf("try to connect db, host=%s port=%d dbname=%s user=%s", dsn.Host, dsn.Port, dsn.Database, dsn.User)
 	for i := 0; i < MAX_TRY_TIMES; i++ {
-		newLogger := logger.New(
-			log.New(os.Stdout, "\r\n", log.LstdFlags),
-			logger.Config{
-				SlowThreshold:             200 * time.Millisecond,
-				LogLevel:                  logger.Warn,
-				IgnoreRecordNotFoundError: true,
-				Colorful:                  true,
-			},
-		)
-		config := gorm.Config{
-			Logger: newLogger,
-		}
-		if enableLogger {
-			config.Logger.LogMode(logger.Info)
-		}
+		config := newGormConfig(enableLogger)
 		db, err := gorm.Open(postgres.Open(connStr), &config)
 		if err != nil {
 			log.Printf("cannot connect db %d/%d", i, MAX_TRY_TIMES)
@@ -104,7 +98,66 @@ func Connect(dsn DSN, enableLogger bool) *gorm.DB {
 	return nil
 }
 
+// ConnectSQLite opens an embedded SQLite database, e.g. for local development.
+// path is a file name or a DSN such as "file:name?mode=memory&cache=shared".
+func ConnectSQLite(path string, enableLogger bool) (*gorm.DB, error) {
+	config := newGormConfig(enableLogger)
+	db, err := gorm.Open(sqlite.Open(path), &config)
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite has no row-level locking. A single connection serializes
+	// transactions, so PopTask never hands the same task to two workers.
+	// It also keeps an in-memory database alive until the db is closed.
+	sqlDB.SetMaxOpenConns(1)
+	sqlDB.SetConnMaxLifetime(0)
+
+	return db, nil
+}
+
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
+
+func newGormConfig(enableLogger bool) gorm.Config {
+	newLogger := logger.New(
+		log.New(os.Stdout, "\r\n", log.LstdFlags),
+		logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+			Colorful:                  true,
+		},
+	)
+	config := gorm.Config{
+		Logger: newLogger,
+	}
+	if enableLogger {
+		config.Logger.LogMode(logger.Info)
+	}
+	return config
+}
+
+// CreateTestDB creates a fresh database with all tables. It uses PostgreSQL
+// if PGHOST is set, and an in-memory SQLite database otherwise.
 func CreateTestDB(t *testing.T) *gorm.DB {
+	db := createEmptyTestDB(t)
+	if err := AutoMigrate(db); err != nil {
+		t.Fatal("Migration failed:", err)
+	}
+	return db
+}
+
+func createEmptyTestDB(t *testing.T) *gorm.DB {
+	if os.Getenv("PGHOST") == "" {
+		return createSQLiteTestDB(t)
+	}
+
 	dbName := uuid.New().String()
 	t.Log("create DB: ", dbName)
 
@@ -123,10 +176,6 @@ func CreateTestDB(t *testing.T) *gorm.DB {
 
 	db := Connect(dsn, os.Getenv("API_DB_LOG") != "")
 
-	if err := AutoMigrate(db); err != nil {
-		t.Fatal("Migration failed:", err)
-	}
-
 	t.Cleanup(func() {
 		db2, err := db.DB()
 		if err != nil {
@@ -151,6 +200,28 @@ func CreateTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+func createSQLiteTestDB(t *testing.T) *gorm.DB {
+	dbName := uuid.New().String()
+	t.Log("create SQLite DB: ", dbName)
+
+	db, err := ConnectSQLite(fmt.Sprintf("file:%s?mode=memory&cache=shared", dbName), os.Getenv("API_DB_LOG") != "")
+	if err != nil {
+		t.Fatal("open sqlite failed:", err)
+	}
+	t.Cleanup(func() {
+		db2, err := db.DB()
+		if err != nil {
+			t.Fatal("db.DB() failed:", err)
+		}
+		if err := db2.Close(); err != nil {
+			t.Fatal("db.Close() failed:", err)
+		}
+	})
+	return db
+}
+
+// AutoMigrate creates tables from the models. It is used for test databases;
+// deployed databases are managed by the versioned migrations in migrations/.
 func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(Problem{}); err != nil {
 		return err
@@ -161,6 +232,9 @@ func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(Submission{}); err != nil {
 		return err
 	}
+	if err := db.AutoMigrate(JudgeRun{}); err != nil {
+		return err
+	}
 	if err := db.AutoMigrate(SubmissionTestcaseResult{}); err != nil {
 		return err
 	}
@@ -173,5 +247,26 @@ func AutoMigrate(db *gorm.DB) error {
 	if err := db.AutoMigrate(Metadata{}); err != nil {
 		return err
 	}
+	if err := db.AutoMigrate(LangStatistics{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(APIToken{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(AutoRejudgeProgress{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(RejudgeJob{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(Webhook{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(WebhookDelivery{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(RateLimitCounter{}); err != nil {
+		return err
+	}
 	return nil
 }
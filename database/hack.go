@@ -68,6 +68,7 @@ func UpdateHack(db *gorm.DB, h Hack) error {
 	if err := db.Save(&h).Error; err != nil {
 		return err
 	}
+	notifyUpdate(db, hackUpdateChannel, h.ID)
 	return nil
 }
 
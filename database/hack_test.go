@@ -108,7 +108,7 @@ func TestUpdateHack(t *testing.T) {
 
 	hackID, err := SaveHack(db, Hack{
 		SubmissionID: subID,
-		TestCaseTxt:  []byte{},
+		TestCaseTxt:  []byte("1 2\n"), // not empty: SQLite reads an empty blob back as NULL
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JudgeRun is db table, one row for each time a submission is judged
+type JudgeRun struct {
+	ID               int32 `gorm:"primaryKey"`
+	SubmissionID     int32 `gorm:"index"` // foreign key to submissions.id (migration 0004)
+	TestCasesVersion string
+	Judge            string // name of the judge worker
+	Status           string
+	MaxTime          int32
+	MaxMemory        int64
+	StartTime        time.Time
+	EndTime          sql.NullTime
+}
+
+// save judge run and return id
+func SaveJudgeRun(db *gorm.DB, run JudgeRun) (int32, error) {
+	if run.ID != 0 {
+		return 0, errors.New("must not specify judge run id")
+	}
+	if run.SubmissionID == 0 {
+		return 0, errors.New("must specify submission id")
+	}
+	if err := db.Create(&run).Error; err != nil {
+		return 0, err
+	}
+	return run.ID, nil
+}
+
+func UpdateJudgeRun(db *gorm.DB, run JudgeRun) error {
+	if run.ID == 0 {
+		return errors.New("must specify judge run id")
+	}
+	if err := db.Save(&run).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func FetchJudgeRun(db *gorm.DB, id int32) (JudgeRun, error) {
+	run := JudgeRun{ID: id}
+	if err := db.Take(&run).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return JudgeRun{}, ErrNotExist
+	} else if err != nil {
+		return JudgeRun{}, err
+	}
+	return run, nil
+}
+
+// FetchJudgeRuns returns all judge runs of the submission, oldest first.
+func FetchJudgeRuns(db *gorm.DB, submissionID int32) ([]JudgeRun, error) {
+	runs := []JudgeRun{}
+	if err := db.Where("submission_id = ?", submissionID).Order("id asc").Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// FetchRunTestcaseResults returns the results of a specific judge run.
+func FetchRunTestcaseResults(db *gorm.DB, runID int32) ([]SubmissionTestcaseResult, error) {
+	var cases []SubmissionTestcaseResult
+	if err := db.Where("run_id = ?", runID).Find(&cases).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].DisplayOrder < cases[j].DisplayOrder
+	})
+
+	return cases, nil
+}
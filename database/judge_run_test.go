@@ -0,0 +1,85 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestJudgeRunHistory(t *testing.T) {
+	db := CreateTestDB(t)
+
+	createDummyProblem(t, db)
+
+	id, err := SaveSubmission(db, Submission{
+		ProblemName: "aplusb",
+		Source:      "source",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var runIDs []int32
+	for _, status := range []string{"WA", "AC"} {
+		runID, err := SaveJudgeRun(db, JudgeRun{
+			SubmissionID:     id,
+			TestCasesVersion: "tversion123",
+			Judge:            "judge1",
+			Status:           "-",
+			StartTime:        time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		runIDs = append(runIDs, runID)
+
+		if err := SaveTestcaseResults(db, []SubmissionTestcaseResult{
+			{Submission: id, RunID: runID, Testcase: "example_00", Status: "AC", DisplayOrder: 0},
+			{Submission: id, RunID: runID, Testcase: "random_00", Status: status, DisplayOrder: 1},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		run, err := FetchJudgeRun(db, runID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		run.Status = status
+		run.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+		if err := UpdateJudgeRun(db, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, err := FetchJudgeRuns(db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].ID != runIDs[0] || runs[1].ID != runIDs[1] {
+		t.Fatal("unexpected runs:", runs)
+	}
+	if runs[0].Status != "WA" || runs[1].Status != "AC" || !runs[1].EndTime.Valid {
+		t.Fatal("runs are not updated:", runs)
+	}
+
+	// the first run is kept
+	first, err := FetchRunTestcaseResults(db, runIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || first[1].Status != "WA" {
+		t.Fatal("unexpected results of first run:", first)
+	}
+
+	latest, err := FetchTestcaseResults(db, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 2 || latest[1].RunID != runIDs[1] || latest[1].Status != "AC" {
+		t.Fatal("unexpected latest results:", latest)
+	}
+
+	if _, err := FetchJudgeRun(db, -1); err != ErrNotExist {
+		t.Fatal("expected ErrNotExist:", err)
+	}
+}
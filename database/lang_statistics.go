@@ -0,0 +1,58 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// compileTimeWindow is the number of recent samples that the average compile time roughly follows.
+const compileTimeWindow = 20
+
+// defaultCompileTime is used for languages without any recorded compile.
+const defaultCompileTime = 5 * time.Second
+
+// LangStatistics is db table
+type LangStatistics struct {
+	Lang        string `gorm:"primaryKey"`
+	CompileTime int32  // moving average of compile time in ms
+	Samples     int32
+}
+
+// RecordCompileTime folds a successful compile of lang into its moving average.
+func RecordCompileTime(db *gorm.DB, lang string, compileTime time.Duration) error {
+	if lang == "" {
+		return errors.New("empty lang")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		stats := LangStatistics{Lang: lang}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&stats).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		window := stats.Samples + 1
+		if window > compileTimeWindow {
+			window = compileTimeWindow
+		}
+		ms := int32(compileTime.Milliseconds())
+		stats.CompileTime += (ms - stats.CompileTime) / window
+		stats.Samples++
+		return tx.Save(&stats).Error
+	})
+}
+
+// FetchCompileTime returns the average compile time of lang, or defaultCompileTime if unknown.
+func FetchCompileTime(db *gorm.DB, lang string) (time.Duration, error) {
+	stats := LangStatistics{Lang: lang}
+	if err := db.Take(&stats).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return defaultCompileTime, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if stats.Samples == 0 {
+		return defaultCompileTime, nil
+	}
+	return time.Duration(stats.CompileTime) * time.Millisecond, nil
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompileTime(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if d, err := FetchCompileTime(db, "cpp"); err != nil || d != defaultCompileTime {
+		t.Fatal("unexpected compile time of unknown lang:", d, err)
+	}
+
+	if err := RecordCompileTime(db, "cpp", 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := FetchCompileTime(db, "cpp"); err != nil || d != 2*time.Second {
+		t.Fatal("unexpected compile time after first sample:", d, err)
+	}
+
+	if err := RecordCompileTime(db, "cpp", 4*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := FetchCompileTime(db, "cpp"); err != nil || d != 3*time.Second {
+		t.Fatal("unexpected compile time after second sample:", d, err)
+	}
+
+	if err := RecordCompileTime(db, "", time.Second); err == nil {
+		t.Fatal("empty lang must be rejected")
+	}
+}
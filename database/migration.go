@@ -0,0 +1,232 @@
+package database
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrations/{version}_{name}.{up|down}.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change written in PostgreSQL.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaVersion is db table, one row for each applied migration.
+type SchemaVersion struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (SchemaVersion) TableName() string {
+	return "schema_version"
+}
+
+// MigrationStatus is a migration with the time it was applied, if any.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// LoadMigrations returns all embedded migrations ordered by version.
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := migrationFileRegex.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", e.Name())
+		}
+		version, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, err
+		}
+		body, err := migrationFiles.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s, %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// FetchMigrationStatus returns all migrations with their applied state.
+func FetchMigrationStatus(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[int]SchemaVersion{}
+	if db.Migrator().HasTable(&SchemaVersion{}) {
+		var rows []SchemaVersion
+		if err := db.Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			applied[row.Version] = row
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		row, ok := applied[m.Version]
+		statuses = append(statuses, MigrationStatus{
+			Migration: m,
+			Applied:   ok,
+			AppliedAt: row.AppliedAt,
+		})
+		delete(applied, m.Version)
+	}
+	if len(applied) != 0 {
+		return nil, errors.New("database has applied migrations unknown to this binary")
+	}
+	return statuses, nil
+}
+
+// MigrateUp applies all pending migrations in order, each in its own transaction.
+// With dryRun, the SQL is written to out and nothing is executed.
+func MigrateUp(db *gorm.DB, dryRun bool, out io.Writer) error {
+	statuses, err := FetchMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		if err := db.AutoMigrate(&SchemaVersion{}); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range statuses {
+		if s.Applied {
+			continue
+		}
+		if err := runMigration(db, s.Migration, true, dryRun, out, func(tx *gorm.DB) error {
+			return tx.Create(&SchemaVersion{
+				Version:   s.Version,
+				Name:      s.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrateDown reverts the last steps applied migrations in reverse order.
+// With dryRun, the SQL is written to out and nothing is executed.
+func MigrateDown(db *gorm.DB, steps int, dryRun bool, out io.Writer) error {
+	if steps <= 0 {
+		return errors.New("steps must be positive")
+	}
+	statuses, err := FetchMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(statuses) - 1; i >= 0 && steps > 0; i-- {
+		s := statuses[i]
+		if !s.Applied {
+			continue
+		}
+		if err := runMigration(db, s.Migration, false, dryRun, out, func(tx *gorm.DB) error {
+			return tx.Delete(&SchemaVersion{Version: s.Version}).Error
+		}); err != nil {
+			return err
+		}
+		steps--
+	}
+	return nil
+}
+
+func runMigration(db *gorm.DB, m Migration, up bool, dryRun bool, out io.Writer, record func(tx *gorm.DB) error) error {
+	sql, direction := m.Up, "up"
+	if !up {
+		sql, direction = m.Down, "down"
+	}
+	if _, err := fmt.Fprintf(out, "-- %04d_%s (%s)\n", m.Version, m.Name, direction); err != nil {
+		return err
+	}
+	if dryRun {
+		_, err := fmt.Fprintln(out, strings.TrimSpace(sql))
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range splitStatements(sql) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+		return record(tx)
+	})
+}
+
+// splitStatements splits a migration into statements terminated by ";" at the end of a line.
+func splitStatements(sql string) []string {
+	var stmts []string
+	var current []string
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current = append(current, line)
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	if len(current) != 0 {
+		stmts = append(stmts, strings.Join(current, "\n"))
+	}
+	return stmts
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Fatal("migration versions must be sequential from 1:", m.Version, m.Name)
+		}
+		if len(splitStatements(m.Up)) == 0 || len(splitStatements(m.Down)) == 0 {
+			t.Fatal("empty migration:", m.Version, m.Name)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- comment
+CREATE TABLE a (
+    id integer
+);
+
+ALTER TABLE a ADD COLUMN b text;
+`
+	expect := []string{
+		"CREATE TABLE a (\n    id integer\n);",
+		"ALTER TABLE a ADD COLUMN b text;",
+	}
+	if got := splitStatements(sql); !reflect.DeepEqual(got, expect) {
+		t.Fatalf("splitStatements = %q", got)
+	}
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db := createEmptyTestDB(t)
+	if !isPostgres(db) {
+		t.Skip("migrations are written in PostgreSQL")
+	}
+
+	dryRun := bytes.NewBuffer(nil)
+	if err := MigrateUp(db, true, dryRun); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(dryRun.String(), "CREATE TABLE IF NOT EXISTS submissions") {
+		t.Fatal("dry run does not print SQL:", dryRun.String())
+	}
+	if db.Migrator().HasTable(&Submission{}) {
+		t.Fatal("dry run must not create tables")
+	}
+
+	if err := MigrateUp(db, false, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := FetchMigrationStatus(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if !s.Applied {
+			t.Fatal("migration is not applied:", s.Version, s.Name)
+		}
+	}
+
+	// migrations must produce every column of the models
+	for _, model := range []interface{}{&Problem{}, &User{}, &Submission{}, &JudgeRun{}, &SubmissionTestcaseResult{}, &Hack{}, &Task{}, &Metadata{}, &LangStatistics{}, &APIToken{}, &AutoRejudgeProgress{}, &RejudgeJob{}, &Webhook{}, &WebhookDelivery{}, &RateLimitCounter{}} {
+		stmt := db.Model(model).Statement
+		if err := stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !db.Migrator().HasColumn(model, field.DBName) {
+				t.Fatalf("column %s.%s is not created by migrations", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+
+	// applying again is a no-op
+	if err := MigrateUp(db, false, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MigrateDown(db, len(statuses), false, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasTable(&Submission{}) {
+		t.Fatal("tables remain after all migrations are reverted")
+	}
+}
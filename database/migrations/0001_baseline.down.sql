@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS metadata;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS hacks;
+DROP TABLE IF EXISTS submission_testcase_results;
+DROP TABLE IF EXISTS submissions;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS problems;
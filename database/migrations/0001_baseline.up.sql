@@ -0,0 +1,88 @@
+-- Schema created by gorm AutoMigrate before versioned migrations were introduced.
+-- Every statement is idempotent so that existing databases only record this version.
+
+CREATE TABLE IF NOT EXISTS problems (
+    name text,
+    title text,
+    source_url text,
+    timelimit integer,
+    test_cases_version text,
+    version text,
+    overall_version text,
+    PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    name text,
+    uid text NOT NULL,
+    library_url text,
+    is_developer boolean,
+    PRIMARY KEY (name),
+    CONSTRAINT uni_users_uid UNIQUE (uid)
+);
+
+CREATE TABLE IF NOT EXISTS submissions (
+    id serial,
+    submission_time timestamptz,
+    problem_name text,
+    lang text,
+    status text,
+    prev_status text,
+    hacked boolean,
+    source text,
+    test_cases_version text,
+    max_time integer,
+    max_memory bigint,
+    compile_error bytea,
+    user_name text,
+    judged_time timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_submissions_problem FOREIGN KEY (problem_name) REFERENCES problems(name),
+    CONSTRAINT fk_submissions_user FOREIGN KEY (user_name) REFERENCES users(name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_submissions_user_name ON submissions (user_name);
+
+CREATE TABLE IF NOT EXISTS submission_testcase_results (
+    submission integer,
+    testcase text,
+    status text,
+    time integer,
+    memory bigint,
+    stderr bytea,
+    checker_out bytea,
+    display_order integer,
+    PRIMARY KEY (submission, testcase)
+);
+
+CREATE TABLE IF NOT EXISTS hacks (
+    id serial,
+    hack_time timestamptz NOT NULL,
+    submission_id integer,
+    user_name text,
+    test_case_txt bytea,
+    test_case_cpp bytea,
+    status text,
+    time integer,
+    memory bigint,
+    stderr bytea,
+    judge_output bytea,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_hacks_submission FOREIGN KEY (submission_id) REFERENCES submissions(id),
+    CONSTRAINT fk_hacks_user FOREIGN KEY (user_name) REFERENCES users(name)
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id serial,
+    priority integer,
+    available timestamptz,
+    enqueue timestamptz,
+    task_data bytea,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS metadata (
+    key text,
+    value text,
+    PRIMARY KEY (key)
+);
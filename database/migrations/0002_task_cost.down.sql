@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS lang_statistics;
+
+ALTER TABLE problems DROP COLUMN IF EXISTS test_case_count;
+
+DROP INDEX IF EXISTS idx_tasks_user_name;
+ALTER TABLE tasks DROP COLUMN IF EXISTS virtual_finish;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimated_cost;
+ALTER TABLE tasks DROP COLUMN IF EXISTS fair_time;
+ALTER TABLE tasks DROP COLUMN IF EXISTS user_name;
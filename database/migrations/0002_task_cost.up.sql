@@ -0,0 +1,16 @@
+-- Fair-share scheduling and judge cost estimation of tasks.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS user_name text;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS fair_time timestamptz;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimated_cost integer;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS virtual_finish timestamptz;
+CREATE INDEX IF NOT EXISTS idx_tasks_user_name ON tasks (user_name);
+
+ALTER TABLE problems ADD COLUMN IF NOT EXISTS test_case_count integer;
+
+CREATE TABLE IF NOT EXISTS lang_statistics (
+    lang text,
+    compile_time integer,
+    samples integer,
+    PRIMARY KEY (lang)
+);
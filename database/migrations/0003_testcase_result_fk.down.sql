@@ -0,0 +1,2 @@
+ALTER TABLE submission_testcase_results
+    DROP CONSTRAINT IF EXISTS fk_submission_testcase_results_submission;
@@ -0,0 +1,7 @@
+-- Results of deleted submissions can never be shown; drop them before adding the constraint.
+DELETE FROM submission_testcase_results r
+WHERE NOT EXISTS (SELECT 1 FROM submissions s WHERE s.id = r.submission);
+
+ALTER TABLE submission_testcase_results
+    ADD CONSTRAINT fk_submission_testcase_results_submission
+    FOREIGN KEY (submission) REFERENCES submissions(id) ON DELETE CASCADE;
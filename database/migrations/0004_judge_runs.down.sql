@@ -0,0 +1,14 @@
+ALTER TABLE submission_testcase_results
+    DROP CONSTRAINT IF EXISTS fk_submission_testcase_results_run;
+
+-- Only the latest run of each submission fits the old primary key.
+DELETE FROM submission_testcase_results r
+WHERE r.run_id <> (SELECT MAX(x.run_id) FROM submission_testcase_results x WHERE x.submission = r.submission);
+
+ALTER TABLE submission_testcase_results DROP CONSTRAINT submission_testcase_results_pkey;
+
+ALTER TABLE submission_testcase_results DROP COLUMN run_id;
+
+ALTER TABLE submission_testcase_results ADD PRIMARY KEY (submission, testcase);
+
+DROP TABLE IF EXISTS judge_runs;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS judge_runs (
+    id serial,
+    submission_id integer,
+    test_cases_version text,
+    judge text,
+    status text,
+    max_time integer,
+    max_memory bigint,
+    start_time timestamptz,
+    end_time timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_judge_runs_submission FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_judge_runs_submission_id ON judge_runs (submission_id);
+
+-- Existing results become one legacy run per submission.
+INSERT INTO judge_runs (submission_id, test_cases_version, judge, status, max_time, max_memory, start_time, end_time)
+SELECT s.id, s.test_cases_version, '', s.status, s.max_time, s.max_memory, s.judged_time, s.judged_time
+FROM submissions s
+WHERE EXISTS (SELECT 1 FROM submission_testcase_results r WHERE r.submission = s.id);
+
+ALTER TABLE submission_testcase_results ADD COLUMN IF NOT EXISTS run_id integer;
+
+UPDATE submission_testcase_results r SET run_id = j.id
+FROM judge_runs j
+WHERE j.submission_id = r.submission;
+
+ALTER TABLE submission_testcase_results ALTER COLUMN run_id SET NOT NULL;
+
+ALTER TABLE submission_testcase_results DROP CONSTRAINT submission_testcase_results_pkey;
+
+ALTER TABLE submission_testcase_results ADD PRIMARY KEY (submission, run_id, testcase);
+
+ALTER TABLE submission_testcase_results
+    ADD CONSTRAINT fk_submission_testcase_results_run
+    FOREIGN KEY (run_id) REFERENCES judge_runs(id) ON DELETE CASCADE;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id serial,
+    user_name text NOT NULL,
+    name text,
+    token_hash text NOT NULL,
+    prefix text,
+    scopes text,
+    created_at timestamptz,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_name) REFERENCES users(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_name ON api_tokens (user_name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_developer boolean;
+UPDATE users SET is_developer = (role <> 'user');
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,3 @@
+-- is_developer was editable by the user itself; privileges are now given by role
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role text NOT NULL DEFAULT 'user';
+ALTER TABLE users DROP COLUMN IF EXISTS is_developer;
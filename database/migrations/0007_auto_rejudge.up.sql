@@ -0,0 +1,13 @@
+-- Progress of the automatic rejudge of outdated AC submissions.
+
+CREATE TABLE IF NOT EXISTS auto_rejudge_progresses (
+    problem_name text,
+    test_cases_version text,
+    total integer,
+    queued integer,
+    last_submission_id integer,
+    started_at timestamptz,
+    finished_at timestamptz,
+    PRIMARY KEY (problem_name),
+    CONSTRAINT fk_auto_rejudge_progresses_problem FOREIGN KEY (problem_name) REFERENCES problems(name) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_submissions_submission_time ON submissions (submission_time);
@@ -0,0 +1,34 @@
+-- Outgoing webhooks notified when submissions and hacks finish, and their delivery log.
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id serial,
+    user_name text NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text,
+    all_users boolean NOT NULL DEFAULT false,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_webhooks_user FOREIGN KEY (user_name) REFERENCES users(name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_name ON webhooks (user_name);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id serial,
+    webhook_id integer NOT NULL,
+    event text,
+    payload text,
+    status text,
+    attempts integer,
+    next_attempt_at timestamptz,
+    last_attempt_at timestamptz,
+    response_code integer,
+    last_error text,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
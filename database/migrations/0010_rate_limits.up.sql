@@ -0,0 +1,11 @@
+-- Fixed window counters of the REST API rate limiter, shared by API instances.
+
+CREATE TABLE IF NOT EXISTS rate_limit_counters (
+    bucket text NOT NULL,
+    window_start timestamptz NOT NULL,
+    count integer NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (bucket, window_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_counters_expires_at ON rate_limit_counters (expires_at);
@@ -0,0 +1,4 @@
+-- Rejudges queued by an admin or the auto rejudger are not counted in the
+-- pending task limit of the submitter.
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS system_queued boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS rejudge_jobs;
@@ -0,0 +1,20 @@
+-- Bulk rejudges started by admins, queued by the API servers.
+
+CREATE TABLE IF NOT EXISTS rejudge_jobs (
+    id serial,
+    filter text,
+    priority integer,
+    rate integer,
+    created_by text,
+    total integer,
+    queued integer,
+    max_submission_id integer,
+    last_submission_id integer,
+    last_queued_at timestamptz,
+    started_at timestamptz,
+    finished_at timestamptz,
+    canceled boolean,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rejudge_jobs_finished_at ON rejudge_jobs (finished_at);
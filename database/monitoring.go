@@ -1,6 +1,8 @@
 package database
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -47,16 +49,17 @@ func fetchTaskQueueInfo(db *gorm.DB) (*TaskQueueInfo, error) {
 	var pendingTasks int64
 	var runningTasks int64
 	var totalTasks int64
+	now := time.Now()
 
 	// Count pending tasks (available <= now, which means they are waiting to be processed)
 	// Tasks that are available for processing are considered "pending"
-	if err := db.Model(&Task{}).Where("available <= ?", "NOW()").Count(&pendingTasks).Error; err != nil {
+	if err := db.Model(&Task{}).Where("available <= ?", now).Count(&pendingTasks).Error; err != nil {
 		return nil, err
 	}
 
 	// Count running tasks (available > now, which means they are currently being processed)
 	// Tasks with future availability are currently being processed (running)
-	if err := db.Model(&Task{}).Where("available > ?", "NOW()").Count(&runningTasks).Error; err != nil {
+	if err := db.Model(&Task{}).Where("available > ?", now).Count(&runningTasks).Error; err != nil {
 		return nil, err
 	}
 
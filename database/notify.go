@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Postgres NOTIFY channels, the payload is the id of the updated row
+const (
+	submissionUpdateChannel = "submission_update"
+	hackUpdateChannel       = "hack_update"
+)
+
+type UpdateKind int
+
+const (
+	SubmissionUpdate UpdateKind = iota
+	HackUpdate
+)
+
+// Update tells that the status or results of a submission or hack changed.
+type Update struct {
+	Kind UpdateKind
+	ID   int32
+}
+
+// notifyUpdate sends NOTIFY on PostgreSQL. Other backends have no
+// notifications and listeners fall back to polling. It is best-effort: the
+// update is already written and the listeners poll anyway, so an error is
+// only logged.
+func notifyUpdate(db *gorm.DB, channel string, id int32) {
+	if !isPostgres(db) {
+		return
+	}
+	if err := db.Exec("SELECT pg_notify(?, ?)", channel, strconv.Itoa(int(id))).Error; err != nil {
+		log.Println("notify update failed:", channel, id, err)
+	}
+}
+
+// ListenUpdates calls fn for each update of submissions and hacks until ctx is done.
+// Notifications sent while the connection is lost are dropped; listening is
+// called with false when the connection is lost and with true when updates
+// are delivered again.
+func ListenUpdates(ctx context.Context, dsn DSN, fn func(Update), listening func(bool)) error {
+	listener := pq.NewListener(dsn.connString(), 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("listener event:", ev, err)
+		}
+		switch ev {
+		case pq.ListenerEventDisconnected:
+			listening(false)
+		case pq.ListenerEventReconnected:
+			listening(true)
+		}
+	})
+	defer func() {
+		listening(false)
+		_ = listener.Close()
+	}()
+
+	channels := map[string]UpdateKind{
+		submissionUpdateChannel: SubmissionUpdate,
+		hackUpdateChannel:       HackUpdate,
+	}
+	for channel := range channels {
+		if err := listener.Listen(channel); err != nil {
+			return err
+		}
+	}
+	listening(true)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// nil is sent after reconnection
+			if n == nil {
+				continue
+			}
+			id, err := strconv.Atoi(n.Extra)
+			if err != nil {
+				log.Println("invalid notification payload:", n.Channel, n.Extra)
+				continue
+			}
+			fn(Update{Kind: channels[n.Channel], ID: int32(id)})
+		case <-time.After(90 * time.Second):
+			go func() { _ = listener.Ping() }()
+		}
+	}
+}
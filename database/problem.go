@@ -16,6 +16,7 @@ type Problem struct {
 	TestCasesVersion string
 	Version          string
 	OverallVersion   string
+	TestCaseCount    int32
 }
 
 func FetchProblem(db *gorm.DB, name string) (Problem, error) {
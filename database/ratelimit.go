@@ -0,0 +1,33 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RateLimitCounter is db table, the number of requests of a bucket in a fixed window
+type RateLimitCounter struct {
+	Bucket      string    `gorm:"primaryKey"`
+	WindowStart time.Time `gorm:"primaryKey"`
+	Count       int32     `gorm:"not null;default:0"`
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
+
+// IncrementRateLimitCounter counts a request of bucket in the window starting
+// at windowStart and returns the number of requests in the window, including
+// this one. The counter can be deleted after expiresAt.
+func IncrementRateLimitCounter(db *gorm.DB, bucket string, windowStart, expiresAt time.Time) (int32, error) {
+	var count int32
+	err := db.Raw(`INSERT INTO rate_limit_counters (bucket, window_start, count, expires_at) VALUES (?, ?, 1, ?)
+ON CONFLICT (bucket, window_start) DO UPDATE SET count = rate_limit_counters.count + 1
+RETURNING count`, bucket, windowStart, expiresAt).Scan(&count).Error
+	return count, err
+}
+
+// DeleteExpiredRateLimitCounters deletes the counters expired at now and
+// returns the number of deleted rows.
+func DeleteExpiredRateLimitCounters(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("expires_at < ?", now).Delete(&RateLimitCounter{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitCounter(t *testing.T) {
+	db := CreateTestDB(t)
+
+	window := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := window.Add(2 * time.Minute)
+	for i := int32(1); i <= 3; i++ {
+		count, err := IncrementRateLimitCounter(db, "submit:ip:192.0.2.1", window, expires)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != i {
+			t.Fatal("unexpected count:", count, "expected:", i)
+		}
+	}
+	// buckets and windows are counted separately
+	if count, err := IncrementRateLimitCounter(db, "submit:ip:192.0.2.2", window, expires); err != nil || count != 1 {
+		t.Fatal("other bucket:", count, err)
+	}
+	if count, err := IncrementRateLimitCounter(db, "submit:ip:192.0.2.1", window.Add(time.Minute), expires.Add(time.Minute)); err != nil || count != 1 {
+		t.Fatal("next window:", count, err)
+	}
+
+	deleted, err := DeleteExpiredRateLimitCounters(db, expires.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Fatal("unexpected number of deleted counters:", deleted)
+	}
+}
@@ -0,0 +1,199 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// rejudgeJobMaxBurst caps the tasks a job queues at once after a pause, e.g.
+// while no API server runs, to this many seconds of its rate.
+const rejudgeJobMaxBurst = 10
+
+func CountRejudgeTargets(db *gorm.DB, f SubmissionFilter) (int64, error) {
+	count := int64(0)
+	if err := f.query(db).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FetchRejudgeTargets returns the ids of the matched submissions, oldest first.
+func FetchRejudgeTargets(db *gorm.DB, f SubmissionFilter) ([]int32, error) {
+	ids := []int32{}
+	if err := f.query(db).Order("id asc").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// RejudgeJob is db table, a bulk rejudge started by an admin. The API servers
+// queue its submissions in id order at Rate tasks per second, so that the job
+// survives restarts and several servers share it.
+type RejudgeJob struct {
+	ID        int32  `gorm:"primaryKey;autoIncrement"`
+	Filter    string // SubmissionFilter in JSON
+	Priority  int32
+	Rate      int32 // tasks queued per second
+	CreatedBy string
+	Total     int32 // matched submissions when the job was created
+	Queued    int32
+	// MaxSubmissionID is the last matched submission when the job was
+	// created; later submissions are not rejudged
+	MaxSubmissionID  int32
+	LastSubmissionID int32 // submissions up to this id are queued
+	LastQueuedAt     time.Time
+	StartedAt        time.Time
+	FinishedAt       sql.NullTime `gorm:"index"`
+	Canceled         bool
+}
+
+// CreateRejudgeJob creates a job rejudging the submissions matched by f. It
+// returns ErrAlreadyExists if an unfinished job has the same filter.
+func CreateRejudgeJob(db *gorm.DB, f SubmissionFilter, priority, rate int32, createdBy string) (RejudgeJob, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return RejudgeJob{}, err
+	}
+	job := RejudgeJob{}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		count := int64(0)
+		if err := tx.Model(&RejudgeJob{}).Where("filter = ? AND finished_at IS NULL", string(data)).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrAlreadyExists
+		}
+
+		matched := struct {
+			Total int32
+			MaxID sql.NullInt32
+		}{}
+		if err := f.query(tx).Select("COUNT(*) AS total, MAX(id) AS max_id").Scan(&matched).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		job = RejudgeJob{
+			Filter:          string(data),
+			Priority:        priority,
+			Rate:            rate,
+			CreatedBy:       createdBy,
+			Total:           matched.Total,
+			MaxSubmissionID: matched.MaxID.Int32,
+			LastQueuedAt:    now,
+			StartedAt:       now,
+		}
+		if matched.Total == 0 {
+			job.FinishedAt = sql.NullTime{Time: now, Valid: true}
+		}
+		return tx.Create(&job).Error
+	})
+	if err != nil {
+		return RejudgeJob{}, err
+	}
+	return job, nil
+}
+
+// FetchRejudgeJobs returns the latest limit jobs, newest first.
+func FetchRejudgeJobs(db *gorm.DB, limit int) ([]RejudgeJob, error) {
+	jobs := []RejudgeJob{}
+	if err := db.Order("id desc").Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CancelRejudgeJob stops queueing the job. The tasks already queued are kept.
+func CancelRejudgeJob(db *gorm.DB, id int32) (RejudgeJob, error) {
+	job := RejudgeJob{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&job).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotExist
+		} else if err != nil {
+			return err
+		}
+		if job.FinishedAt.Valid {
+			return nil
+		}
+		job.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		job.Canceled = true
+		return tx.Save(&job).Error
+	})
+	if err != nil {
+		return RejudgeJob{}, err
+	}
+	return job, nil
+}
+
+// QueueRejudgeJobs enqueues the next submissions of the unfinished jobs, as
+// many as their rates allow since they last queued, and returns the number of
+// queued tasks.
+func QueueRejudgeJobs(db *gorm.DB, now time.Time) (int, error) {
+	ids := []int32{}
+	if err := db.Model(&RejudgeJob{}).Where("finished_at IS NULL").Order("id asc").Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, id := range ids {
+		n, err := queueRejudgeJob(db, id, now)
+		if err != nil {
+			return queued, err
+		}
+		queued += n
+	}
+	return queued, nil
+}
+
+func queueRejudgeJob(db *gorm.DB, id int32, now time.Time) (int, error) {
+	ids := []int32{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		job := RejudgeJob{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&job).Error; err != nil {
+			return err
+		}
+		if job.FinishedAt.Valid {
+			return nil
+		}
+		limit := min(int(now.Sub(job.LastQueuedAt).Seconds()*float64(job.Rate)), int(job.Rate)*rejudgeJobMaxBurst)
+		if limit <= 0 {
+			return nil
+		}
+		f := SubmissionFilter{}
+		if err := json.Unmarshal([]byte(job.Filter), &f); err != nil {
+			return err
+		}
+
+		if err := f.query(tx).
+			Where("id > ? AND id <= ?", job.LastSubmissionID, job.MaxSubmissionID).
+			Order("id asc").Limit(limit).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := PushSystemSubmissionTask(tx, SubmissionData{ID: id}, job.Priority); err != nil {
+				return err
+			}
+		}
+		if len(ids) > 0 {
+			job.LastSubmissionID = ids[len(ids)-1]
+			job.Queued += int32(len(ids))
+		}
+		if len(ids) < limit {
+			job.FinishedAt = sql.NullTime{Time: now, Valid: true}
+		}
+		if limit == int(job.Rate)*rejudgeJobMaxBurst {
+			job.LastQueuedAt = now
+		} else {
+			// keep the fraction of a task for the next call
+			job.LastQueuedAt = job.LastQueuedAt.Add(time.Duration(limit) * time.Second / time.Duration(job.Rate))
+		}
+		return tx.Save(&job).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
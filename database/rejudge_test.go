@@ -0,0 +1,135 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRejudgeTargets(t *testing.T) {
+	db := CreateTestDB(t)
+	createDummyProblem(t, db)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := []int32{}
+	for i, sub := range []Submission{
+		{Status: "AC", Lang: "cpp", TestCasesVersion: "old"},
+		{Status: "AC", Lang: "cpp", TestCasesVersion: "tversion123"},
+		{Status: "WA", Lang: "cpp", TestCasesVersion: "old"},
+		{Status: "AC", Lang: "rust", TestCasesVersion: "old"},
+	} {
+		sub.ProblemName = "aplusb"
+		sub.Source = "source"
+		sub.SubmissionTime = base.Add(time.Duration(i) * time.Hour)
+		id, err := SaveSubmission(db, sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	no := false
+	for _, tc := range []struct {
+		filter   SubmissionFilter
+		expected []int32
+	}{
+		{SubmissionFilter{Problem: "aplusb", Statuses: []string{"AC"}, IsLatest: &no}, []int32{ids[0], ids[3]}},
+		{SubmissionFilter{Langs: []string{"cpp"}, Since: base.Add(time.Hour)}, []int32{ids[1], ids[2]}},
+		{SubmissionFilter{Statuses: []string{"AC", "WA"}, Langs: []string{"cpp"}, IsLatest: &no}, []int32{ids[0], ids[2]}},
+		{SubmissionFilter{Until: base.Add(time.Hour)}, []int32{ids[0]}},
+		{SubmissionFilter{Problem: "unknown"}, []int32{}},
+	} {
+		actual, err := FetchRejudgeTargets(db, tc.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("FetchRejudgeTargets(%+v) = %v, want %v", tc.filter, actual, tc.expected)
+		}
+		count, err := CountRejudgeTargets(db, tc.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != int64(len(tc.expected)) {
+			t.Errorf("CountRejudgeTargets(%+v) = %d, want %d", tc.filter, count, len(tc.expected))
+		}
+	}
+
+	if !(SubmissionFilter{}).Empty() || (SubmissionFilter{IsLatest: &no}).Empty() {
+		t.Fatal("unexpected Empty")
+	}
+}
+
+func TestRejudgeJob(t *testing.T) {
+	db := CreateTestDB(t)
+	createDummyProblem(t, db)
+
+	ids := []int32{}
+	for i := 0; i < 5; i++ {
+		id, err := SaveSubmission(db, Submission{ProblemName: "aplusb", Source: "source", Status: "AC"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	filter := SubmissionFilter{Problem: "aplusb"}
+	job, err := CreateRejudgeJob(db, filter, 5, 2, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Total != 5 || job.MaxSubmissionID != ids[4] || job.FinishedAt.Valid {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	if _, err := CreateRejudgeJob(db, filter, 5, 2, "admin"); err != ErrAlreadyExists {
+		t.Fatal("duplicated job must fail:", err)
+	}
+
+	// submitted after the job is created
+	if _, err := SaveSubmission(db, Submission{ProblemName: "aplusb", Source: "source", Status: "AC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 tasks per second
+	if n, err := QueueRejudgeJobs(db, job.LastQueuedAt.Add(time.Second)); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+	if n, err := QueueRejudgeJobs(db, job.LastQueuedAt.Add(time.Second)); err != nil || n != 0 {
+		t.Fatal("the rate must be kept:", n, err)
+	}
+	if n, err := QueueRejudgeJobs(db, job.LastQueuedAt.Add(time.Hour)); err != nil || n != 3 {
+		t.Fatal(n, err)
+	}
+	for _, id := range ids {
+		_, task, err := PopTask(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Data.(SubmissionData).ID != id {
+			t.Fatal("unexpected task:", task)
+		}
+	}
+
+	jobs, err := FetchRejudgeJobs(db, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Queued != 5 || jobs[0].LastSubmissionID != ids[4] || !jobs[0].FinishedAt.Valid || jobs[0].Canceled {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+
+	// the same filter can run again after the job finished, and be canceled
+	job, err = CreateRejudgeJob(db, filter, 5, 2, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err = CancelRejudgeJob(db, job.ID); err != nil || !job.Canceled || !job.FinishedAt.Valid {
+		t.Fatal(job, err)
+	}
+	if n, err := QueueRejudgeJobs(db, job.LastQueuedAt.Add(time.Hour)); err != nil || n != 0 {
+		t.Fatal("canceled job must not be queued:", n, err)
+	}
+	if _, err := CancelRejudgeJob(db, -1); err != ErrNotExist {
+		t.Fatal(err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"sort"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,8 +12,8 @@ import (
 
 // Submission is db table
 type Submission struct {
-	ID               int32 `gorm:"primaryKey"`
-	SubmissionTime   time.Time
+	ID               int32     `gorm:"primaryKey"`
+	SubmissionTime   time.Time `gorm:"index"`
 	ProblemName      string
 	Problem          Problem `gorm:"foreignKey:ProblemName"`
 	Lang             string
@@ -69,7 +70,8 @@ const (
 
 // SubmissionTestcaseResult is db table
 type SubmissionTestcaseResult struct {
-	Submission   int32  `gorm:"primaryKey"` // TODO: should be foreign key
+	Submission   int32  `gorm:"primaryKey"` // foreign key to submissions.id (migration 0003)
+	RunID        int32  `gorm:"primaryKey"` // foreign key to judge_runs.id (migration 0004)
 	Testcase     string `gorm:"primaryKey"`
 	Status       string
 	Time         int32
@@ -121,6 +123,7 @@ func UpdateSubmission(db *gorm.DB, submission Submission) error {
 		return err
 	}
 
+	notifyUpdate(db, submissionUpdateChannel, submission.ID)
 	return nil
 }
 
@@ -131,13 +134,7 @@ func UpdateSubmissionStatus(db *gorm.DB, id int32, status string) error {
 	}).Error; err != nil {
 		return err
 	}
-	return nil
-}
-
-func ClearTestcaseResult(db *gorm.DB, subID int32) error {
-	if err := db.Where("submission = ?", subID).Delete(&SubmissionTestcaseResult{}).Error; err != nil {
-		return err
-	}
+	notifyUpdate(db, submissionUpdateChannel, id)
 	return nil
 }
 
@@ -146,12 +143,42 @@ func SaveTestcaseResults(db *gorm.DB, results []SubmissionTestcaseResult) error
 		return err
 	}
 
+	notified := map[int32]bool{}
+	for _, r := range results {
+		if notified[r.Submission] {
+			continue
+		}
+		notified[r.Submission] = true
+		notifyUpdate(db, submissionUpdateChannel, r.Submission)
+	}
 	return nil
 }
 
+// ProblemTestCasesVersion is a test case version of a problem.
+type ProblemTestCasesVersion struct {
+	ProblemName      string
+	TestCasesVersion string
+}
+
+// FetchJudgedTestCasesVersions returns the distinct test case versions with
+// which the submissions judged at or after since were judged.
+func FetchJudgedTestCasesVersions(db *gorm.DB, since time.Time) ([]ProblemTestCasesVersion, error) {
+	versions := []ProblemTestCasesVersion{}
+	if err := db.Model(&Submission{}).
+		Distinct("problem_name", "test_cases_version").
+		Where("judged_time >= ? AND test_cases_version <> ''", since).
+		Order("problem_name, test_cases_version").
+		Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// FetchTestcaseResults returns the results of the latest judge run of the submission.
 func FetchTestcaseResults(db *gorm.DB, id int32) ([]SubmissionTestcaseResult, error) {
+	latest := db.Model(&SubmissionTestcaseResult{}).Select("MAX(run_id)").Where("submission = ?", id)
 	var cases []SubmissionTestcaseResult
-	if err := db.Where("submission = ?", id).Find(&cases).Error; err != nil {
+	if err := db.Where("submission = ? AND run_id = (?)", id, latest).Find(&cases).Error; err != nil {
 		return nil, err
 	}
 
@@ -175,28 +202,155 @@ func applyOrder(query *gorm.DB, order []SubmissionOrder) *gorm.DB {
 	return query
 }
 
+func orderSQL(order []SubmissionOrder) string {
+	terms := []string{}
+	for _, o := range order {
+		switch o {
+		case ID_DESC:
+			terms = append(terms, "id desc")
+		case MAX_TIME_ASC:
+			terms = append(terms, "max_time asc")
+		}
+	}
+	if len(terms) == 0 {
+		return "id desc"
+	}
+	return strings.Join(terms, ", ")
+}
+
+// SubmissionFilter selects submissions of FetchSubmissionPage and of bulk
+// rejudges. Empty fields match everything.
+type SubmissionFilter struct {
+	Problem  string
+	Statuses []string
+	Langs    []string
+	User     string
+	// DedupUser keeps only the first submission of each user in the order; only
+	// FetchSubmissionPage uses it
+	DedupUser bool
+	// submissions in [Since, Until), zero means unbounded
+	Since time.Time
+	Until time.Time
+	// IsLatest matches submissions judged (or not) with the current test cases of the problem
+	IsLatest *bool
+	Hacked   *bool
+}
+
+// Empty reports whether f matches every submission.
+func (f SubmissionFilter) Empty() bool {
+	return f.Problem == "" && len(f.Statuses) == 0 && len(f.Langs) == 0 && f.User == "" &&
+		f.Since.IsZero() && f.Until.IsZero() && f.IsLatest == nil && f.Hacked == nil
+}
+
+// SubmissionCursor is the position of the last submission of a page. The next
+// page starts right after it.
+type SubmissionCursor struct {
+	ID      int32
+	MaxTime int32
+}
+
+// CursorOf returns the cursor pointing to s.
+func CursorOf(s SubmissionOverView) SubmissionCursor {
+	return SubmissionCursor{ID: s.ID, MaxTime: s.MaxTime}
+}
+
+// isLatestSQL matches submissions judged with the current test cases of the problem
+const isLatestSQL = "submissions.test_cases_version = (SELECT problems.test_cases_version FROM problems WHERE problems.name = submissions.problem_name)"
+
+func (f SubmissionFilter) query(db *gorm.DB) *gorm.DB {
+	query := db.Model(&Submission{}).Where(&Submission{
+		ProblemName: f.Problem,
+		UserName:    sql.NullString{String: f.User, Valid: (f.User != "")},
+	})
+	if len(f.Statuses) == 1 {
+		query = query.Where("status = ?", f.Statuses[0])
+	} else if len(f.Statuses) > 1 {
+		query = query.Where("status IN ?", f.Statuses)
+	}
+	if len(f.Langs) == 1 {
+		query = query.Where("lang = ?", f.Langs[0])
+	} else if len(f.Langs) > 1 {
+		query = query.Where("lang IN ?", f.Langs)
+	}
+	if !f.Since.IsZero() {
+		query = query.Where("submission_time >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		query = query.Where("submission_time < ?", f.Until)
+	}
+	if f.IsLatest != nil {
+		if *f.IsLatest {
+			query = query.Where(isLatestSQL)
+		} else {
+			query = query.Where("NOT (" + isLatestSQL + ")")
+		}
+	}
+	if f.Hacked != nil {
+		query = query.Where("hacked = ?", *f.Hacked)
+	}
+	return query
+}
+
+// cursorCondition returns the condition of the rows after c in order, which must end with ID_DESC.
+func cursorCondition(order []SubmissionOrder, c SubmissionCursor) (string, []interface{}, error) {
+	switch {
+	case len(order) == 1 && order[0] == ID_DESC:
+		return "id < ?", []interface{}{c.ID}, nil
+	case len(order) == 2 && order[0] == MAX_TIME_ASC && order[1] == ID_DESC:
+		return "(max_time > ? OR (max_time = ? AND id < ?))", []interface{}{c.MaxTime, c.MaxTime, c.ID}, nil
+	}
+	return "", nil, errors.New("cursor is not supported for this order")
+}
+
 func FetchSubmissionList(db *gorm.DB, problem, status, lang, user string, dedupUser bool, order []SubmissionOrder, offset, limit int) ([]SubmissionOverView, int64, error) {
-	filter := &Submission{
-		ProblemName: problem,
-		Status:      status,
-		Lang:        lang,
-		UserName:    sql.NullString{String: user, Valid: (user != "")},
+	filter := SubmissionFilter{
+		Problem:   problem,
+		User:      user,
+		DedupUser: dedupUser,
+	}
+	if status != "" {
+		filter.Statuses = []string{status}
 	}
+	if lang != "" {
+		filter.Langs = []string{lang}
+	}
+	return FetchSubmissionPage(db, filter, order, nil, offset, limit, true)
+}
 
-	query := db.Model(&Submission{}).Where(filter)
+// FetchSubmissionPage returns the submissions matched by filter in order. If
+// after is not nil, the page starts right after it (keyset pagination) instead
+// of skipping offset rows. The total count is -1 unless withCount is true.
+func FetchSubmissionPage(db *gorm.DB, filter SubmissionFilter, order []SubmissionOrder, after *SubmissionCursor, offset, limit int, withCount bool) ([]SubmissionOverView, int64, error) {
+	query := filter.query(db)
 	query.Session(&gorm.Session{})
 
-	if dedupUser {
-		query.Order("user_name desc")
-		query = applyOrder(query, order)
-		query = db.Model(&Submission{}).Where("id IN (?)", query.Select("DISTINCT ON (user_name) id"))
+	if filter.DedupUser {
+		if isPostgres(db) {
+			query.Order("user_name desc")
+			query = applyOrder(query, order)
+			query = db.Model(&Submission{}).Where("id IN (?)", query.Select("DISTINCT ON (user_name) id"))
+		} else {
+			// portable equivalent of DISTINCT ON: the first row of each user by window function
+			ranked := query.Select("id, ROW_NUMBER() OVER (PARTITION BY user_name ORDER BY " + orderSQL(order) + ") AS rn")
+			query = db.Model(&Submission{}).Where("id IN (?)", db.Table("(?) AS ranked", ranked).Select("id").Where("rn = 1"))
+		}
 	}
 
-	count := int64(0)
-	if err := query.Count(&count).Error; err != nil {
-		return nil, 0, errors.New("count query failed")
+	count := int64(-1)
+	if withCount {
+		if err := query.Count(&count).Error; err != nil {
+			return nil, 0, errors.New("count query failed")
+		}
 	}
 
+	if after != nil {
+		cond, args, err := cursorCondition(order, *after)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where(cond, args...)
+		offset = 0
+	}
 	query = applyOrder(query, order)
 
 	var submissions = make([]SubmissionOverView, 0)
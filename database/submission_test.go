@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSubmission(t *testing.T) {
@@ -357,3 +358,116 @@ func TestDedupSubmissionList(t *testing.T) {
 		}
 	}
 }
+
+func TestSubmissionPage(t *testing.T) {
+	db := CreateTestDB(t)
+
+	createDummyProblem(t, db)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := []int32{}
+	for i, sub := range []Submission{
+		{Status: "AC", Lang: "cpp", MaxTime: 30, TestCasesVersion: "tversion123"},
+		{Status: "WA", Lang: "rust", MaxTime: 10, TestCasesVersion: "tversion123"},
+		{Status: "TLE", Lang: "cpp", MaxTime: 20, TestCasesVersion: "old", Hacked: true},
+		{Status: "AC", Lang: "python3", MaxTime: 10, TestCasesVersion: "tversion123"},
+		{Status: "AC", Lang: "cpp", MaxTime: 20, TestCasesVersion: "tversion123"},
+	} {
+		sub.ProblemName = "aplusb"
+		sub.Source = "source"
+		sub.SubmissionTime = base.Add(time.Duration(i) * time.Hour)
+		id, err := SaveSubmission(db, sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	// pages through every submission with a cursor
+	fetchAll := func(filter SubmissionFilter, order []SubmissionOrder) []int32 {
+		result := []int32{}
+		var after *SubmissionCursor
+		for {
+			subs, count, err := FetchSubmissionPage(db, filter, order, after, 0, 2, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != -1 {
+				t.Fatal("count must not be fetched:", count)
+			}
+			for _, s := range subs {
+				result = append(result, s.ID)
+			}
+			if len(subs) < 2 {
+				return result
+			}
+			c := CursorOf(subs[len(subs)-1])
+			after = &c
+		}
+	}
+
+	if actual := fetchAll(SubmissionFilter{}, []SubmissionOrder{ID_DESC}); !reflect.DeepEqual(actual, []int32{ids[4], ids[3], ids[2], ids[1], ids[0]}) {
+		t.Fatal("unexpected order:", actual)
+	}
+	if actual := fetchAll(SubmissionFilter{}, []SubmissionOrder{MAX_TIME_ASC, ID_DESC}); !reflect.DeepEqual(actual, []int32{ids[3], ids[1], ids[4], ids[2], ids[0]}) {
+		t.Fatal("unexpected order:", actual)
+	}
+
+	yes, no := true, false
+	for _, tc := range []struct {
+		filter   SubmissionFilter
+		expected []int32
+	}{
+		{SubmissionFilter{Statuses: []string{"WA", "TLE"}}, []int32{ids[2], ids[1]}},
+		{SubmissionFilter{Langs: []string{"rust", "python3"}}, []int32{ids[3], ids[1]}},
+		{SubmissionFilter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)}, []int32{ids[2], ids[1]}},
+		{SubmissionFilter{IsLatest: &no}, []int32{ids[2]}},
+		{SubmissionFilter{IsLatest: &yes, Statuses: []string{"AC"}, Langs: []string{"cpp"}}, []int32{ids[4], ids[0]}},
+		{SubmissionFilter{Hacked: &yes}, []int32{ids[2]}},
+	} {
+		if actual := fetchAll(tc.filter, []SubmissionOrder{ID_DESC}); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("filter %+v: got %v, want %v", tc.filter, actual, tc.expected)
+		}
+		_, count, err := FetchSubmissionPage(db, tc.filter, []SubmissionOrder{ID_DESC}, nil, 0, 1, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != int64(len(tc.expected)) {
+			t.Errorf("filter %+v: count %d, want %d", tc.filter, count, len(tc.expected))
+		}
+	}
+
+	if _, _, err := FetchSubmissionPage(db, SubmissionFilter{}, []SubmissionOrder{MAX_TIME_ASC}, &SubmissionCursor{}, 0, 1, false); err == nil {
+		t.Fatal("cursor must require a total order")
+	}
+}
+
+func TestFetchJudgedTestCasesVersions(t *testing.T) {
+	db := CreateTestDB(t)
+	createDummyProblem(t, db)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, sub := range []Submission{
+		{TestCasesVersion: "old", JudgedTime: base.Add(-time.Hour)},
+		{TestCasesVersion: "v1", JudgedTime: base},
+		{TestCasesVersion: "v1", JudgedTime: base.Add(time.Hour)},
+		{TestCasesVersion: "v2", JudgedTime: base.Add(time.Hour)},
+		// waiting for judge
+		{JudgedTime: base.Add(time.Hour)},
+	} {
+		sub.ProblemName = "aplusb"
+		sub.Source = "source"
+		if _, err := SaveSubmission(db, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := FetchJudgedTestCasesVersions(db, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ProblemTestCasesVersion{{"aplusb", "v1"}, {"aplusb", "v2"}}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("versions = %v, want %v", versions, expected)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/gob"
 	"errors"
 	"time"
@@ -12,6 +13,24 @@ import (
 
 const taskRetryPeriod = 2 * time.Minute
 
+// fairShareInterval is the virtual gap between consecutive tasks of the same user.
+// Tasks with equal priority are popped in FairTime order, so a user who queues
+// many tasks at once is interleaved with other users instead of blocking them.
+const fairShareInterval = 10 * time.Second
+
+// defaultTestCaseCount is assumed for problems whose test case count is not known yet.
+const defaultTestCaseCount = 10
+
+type PopPolicy int
+
+const (
+	// PopFairShare pops the task with the highest priority, interleaving users.
+	PopFairShare PopPolicy = iota
+	// PopShortestJobFirst pops the task with the highest priority and the earliest virtual finish time.
+	// Cheap tasks overtake expensive ones queued a little earlier, but not those that have waited long enough.
+	PopShortestJobFirst
+)
+
 type TaskType = int
 
 const (
@@ -56,6 +75,15 @@ type Task struct {
 	Available time.Time
 	Enqueue   time.Time
 	TaskData  []byte
+	UserName  sql.NullString `gorm:"index"`
+	FairTime  time.Time
+	// EstimatedCost is the expected judge time in ms
+	EstimatedCost int32
+	// VirtualFinish is FairTime + EstimatedCost, the sort key of PopShortestJobFirst
+	VirtualFinish time.Time
+	// SystemQueued is set for the tasks queued by an admin or the auto
+	// rejudger, which are charged to the user but not started by them
+	SystemQueued bool
 }
 
 func init() {
@@ -80,38 +108,201 @@ func PushSubmissionTask(db *gorm.DB, submissionData SubmissionData, priority int
 	return pushTask(db, TaskData{
 		TaskType: JudgeSubmission,
 		Data:     submissionData,
-	}, priority)
+	}, priority, false)
+}
+
+// PushSystemSubmissionTask pushes a rejudge queued by an admin or the auto
+// rejudger. It is not counted by CountUserTasks.
+func PushSystemSubmissionTask(db *gorm.DB, submissionData SubmissionData, priority int32) error {
+	return pushTask(db, TaskData{
+		TaskType: JudgeSubmission,
+		Data:     submissionData,
+	}, priority, true)
 }
 
 func PushHackTask(db *gorm.DB, hackData HackData, priority int32) error {
 	return pushTask(db, TaskData{
 		TaskType: JudgeHack,
 		Data:     hackData,
-	}, priority)
+	}, priority, false)
 }
 
-func pushTask(db *gorm.DB, taskData TaskData, priority int32) error {
+func pushTask(db *gorm.DB, taskData TaskData, priority int32, systemQueued bool) error {
 	now := time.Now()
 	binTaskData, err := encode(taskData)
 	if err != nil {
 		return err
 	}
+	info, err := fetchTaskInfo(db, taskData)
+	if err != nil {
+		return err
+	}
+	fairTime, err := nextFairTime(db, info.userName, priority, now)
+	if err != nil {
+		return err
+	}
 	if err := db.Save(&Task{
-		Priority:  priority,
-		Available: now,
-		Enqueue:   now,
-		TaskData:  binTaskData,
+		Priority:      priority,
+		Available:     now,
+		Enqueue:       now,
+		TaskData:      binTaskData,
+		UserName:      info.userName,
+		FairTime:      fairTime,
+		EstimatedCost: int32(info.cost.Milliseconds()),
+		VirtualFinish: fairTime.Add(info.cost),
+		SystemQueued:  systemQueued,
 	}).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+// taskInfo is the queueing metadata derived from a task payload.
+type taskInfo struct {
+	// userName is the user charged for the task: the submitter for submissions and the hacker for hacks.
+	userName sql.NullString
+	// cost is the estimated judge time, compile time plus time limit of every case.
+	cost time.Duration
+}
+
+func fetchTaskInfo(db *gorm.DB, taskData TaskData) (taskInfo, error) {
+	type row struct {
+		UserName      sql.NullString
+		Lang          string
+		Timelimit     int32
+		TestCaseCount int32
+	}
+	var rows []row
+	caseCount := int32(0)
+	switch data := taskData.Data.(type) {
+	case SubmissionData:
+		if err := db.Model(&Submission{}).
+			Joins("LEFT JOIN problems ON submissions.problem_name = problems.name").
+			Select("submissions.user_name AS user_name, submissions.lang AS lang, problems.timelimit AS timelimit, problems.test_case_count AS test_case_count").
+			Where("submissions.id = ?", data.ID).
+			Scan(&rows).Error; err != nil {
+			return taskInfo{}, err
+		}
+	case HackData:
+		if err := db.Model(&Hack{}).
+			Joins("LEFT JOIN submissions ON hacks.submission_id = submissions.id").
+			Joins("LEFT JOIN problems ON submissions.problem_name = problems.name").
+			Select("hacks.user_name AS user_name, submissions.lang AS lang, problems.timelimit AS timelimit").
+			Where("hacks.id = ?", data.ID).
+			Scan(&rows).Error; err != nil {
+			return taskInfo{}, err
+		}
+		// a hack runs the submission on its single case
+		caseCount = 1
+	}
+	if len(rows) == 0 {
+		return taskInfo{}, nil
+	}
+
+	r := rows[0]
+	if caseCount == 0 {
+		caseCount = r.TestCaseCount
+	}
+	if caseCount == 0 {
+		caseCount = defaultTestCaseCount
+	}
+	compileTime, err := FetchCompileTime(db, r.Lang)
+	if err != nil {
+		return taskInfo{}, err
+	}
+	return taskInfo{
+		userName: r.UserName,
+		cost:     compileTime + time.Duration(caseCount)*time.Duration(r.Timelimit)*time.Millisecond,
+	}, nil
+}
+
+// nextFairTime returns the FairTime of a new task of the user at priority.
+// FairTime only orders tasks of the same priority, so the tasks of the other
+// priorities, e.g. bulk rejudges, do not delay the new one. Anonymous tasks
+// share a single slot.
+func nextFairTime(db *gorm.DB, userName sql.NullString, priority int32, now time.Time) (time.Time, error) {
+	last := Task{}
+	if err := whereTaskOwner(db.Model(&Task{}), userName).
+		Where("priority = ?", priority).
+		Select("fair_time").
+		Order("fair_time desc").
+		Take(&last).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return now, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	if next := last.FairTime.Add(fairShareInterval); next.After(now) {
+		return next, nil
+	}
+	return now, nil
+}
+
+func whereTaskOwner(query *gorm.DB, userName sql.NullString) *gorm.DB {
+	if !userName.Valid {
+		return query.Where("user_name IS NULL")
+	}
+	return query.Where("user_name = ?", userName.String)
+}
+
+// CountUserTasks returns the number of queued or running tasks started by the
+// user. Rejudges queued by an admin or the auto rejudger are not counted.
+func CountUserTasks(db *gorm.DB, userName string) (int64, error) {
+	if userName == "" {
+		return 0, errors.New("user name is empty")
+	}
+	count := int64(0)
+	if err := db.Model(&Task{}).Where("user_name = ? AND NOT system_queued", userName).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountTasks returns the number of queued or running tasks.
+func CountTasks(db *gorm.DB) (int64, error) {
+	count := int64(0)
+	if err := db.Model(&Task{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func PopTask(db *gorm.DB) (int32, TaskData, error) {
+	return PopTaskWithPolicy(db, PopFairShare)
+}
+
+func popOrder(policy PopPolicy) string {
+	if policy == PopShortestJobFirst {
+		return "priority desc, virtual_finish asc, id asc"
+	}
+	return "priority desc, fair_time asc, id asc"
+}
+
+// PeekTasksWithPolicy returns up to limit tasks that PopTaskWithPolicy would
+// pop next, in order. The tasks are neither locked nor popped.
+func PeekTasksWithPolicy(db *gorm.DB, policy PopPolicy, limit int) ([]TaskData, error) {
+	tasks := []Task{}
+	if err := db.Where("available <= ?", time.Now()).Order(popOrder(policy)).Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	result := []TaskData{}
+	for _, task := range tasks {
+		taskData, err := decode(task.TaskData)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, taskData)
+	}
+	return result, nil
+}
+
+func PopTaskWithPolicy(db *gorm.DB, policy PopPolicy) (int32, TaskData, error) {
+	order := popOrder(policy)
+
 	task := Task{}
 	found := false
 	if err := db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("available <= ?", time.Now()).Order("priority desc, id asc").Clauses(clause.Locking{Strength: "UPDATE"}).Take(&task).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Where("available <= ?", time.Now()).Order(order).Clauses(clause.Locking{Strength: "UPDATE"}).Take(&task).Error; errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil
 		} else if err != nil {
 			return err
@@ -1,7 +1,11 @@
 package database
 
 import (
+	"database/sql"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestTask(t *testing.T) {
@@ -50,6 +54,47 @@ func TestTask(t *testing.T) {
 	}
 }
 
+func TestPeekTasks(t *testing.T) {
+	db := CreateTestDB(t)
+
+	for _, task := range []struct {
+		id       int32
+		priority int32
+	}{{1, 1}, {2, 10}, {3, 5}} {
+		if err := PushSubmissionTask(db, SubmissionData{ID: task.id}, task.priority); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	peekIDs := func(limit int) []int32 {
+		tasks, err := PeekTasksWithPolicy(db, PopFairShare, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []int32{}
+		for _, task := range tasks {
+			ids = append(ids, task.Data.(SubmissionData).ID)
+		}
+		return ids
+	}
+	if ids := peekIDs(2); !reflect.DeepEqual(ids, []int32{2, 3}) {
+		t.Fatal("unexpected peek:", ids)
+	}
+	// peek does not pop
+	if ids := peekIDs(10); !reflect.DeepEqual(ids, []int32{2, 3, 1}) {
+		t.Fatal("unexpected peek:", ids)
+	}
+
+	// popped tasks are not available until they are retried
+	id, data, err := PopTask(db)
+	if err != nil || data.Data.(SubmissionData).ID != 2 {
+		t.Fatal(id, data, err)
+	}
+	if ids := peekIDs(10); !reflect.DeepEqual(ids, []int32{3, 1}) {
+		t.Fatal("unexpected peek:", ids)
+	}
+}
+
 func TestTaskSamePriority(t *testing.T) {
 	db := CreateTestDB(t)
 
@@ -92,6 +137,50 @@ func TestTaskSamePriority(t *testing.T) {
 	}
 }
 
+func TestTaskConcurrentPop(t *testing.T) {
+	db := CreateTestDB(t)
+
+	const taskCount = 20
+	for i := 0; i < taskCount; i++ {
+		if err := PushSubmissionTask(db, SubmissionData{ID: int32(i)}, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	popped := map[int32]int{}
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				id, _, err := PopTask(db)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if id == -1 {
+					return
+				}
+				mu.Lock()
+				popped[id]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(popped) != taskCount {
+		t.Fatal("not all tasks are popped:", popped)
+	}
+	for id, n := range popped {
+		if n != 1 {
+			t.Fatal("task is popped more than once:", id, n)
+		}
+	}
+}
+
 func TestTaskDataSerialize(t *testing.T) {
 	submissionData := SubmissionData{
 		ID: 123,
@@ -201,3 +290,159 @@ func TestTaskWithRealDatabaseRecords(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTaskFairShare(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if err := SaveProblem(db, Problem{Name: "aplusb", Title: "A + B", TestCasesVersion: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"alice", "bob"} {
+		if err := RegisterUser(db, name, "uid-"+name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	submit := func(user string) int32 {
+		id, err := SaveSubmission(db, Submission{
+			ProblemName: "aplusb",
+			Lang:        "cpp",
+			Status:      "WJ",
+			Source:      "int main(){}",
+			UserName:    sql.NullString{String: user, Valid: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+
+	// alice floods the queue before bob submits once
+	alice1, alice2, alice3 := submit("alice"), submit("alice"), submit("alice")
+	bob1 := submit("bob")
+	for _, id := range []int32{alice1, alice2, alice3, bob1} {
+		if err := PushSubmissionTask(db, SubmissionData{ID: id}, 45); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count, err := CountUserTasks(db, "alice"); err != nil || count != 3 {
+		t.Fatal("unexpected alice task count:", count, err)
+	}
+
+	for _, expect := range []int32{alice1, bob1, alice2, alice3} {
+		id, data, err := PopTask(db)
+		if id == -1 || err != nil {
+			t.Fatal(id, data, err)
+		}
+		if submissionData, ok := data.Data.(SubmissionData); !ok || submissionData.ID != expect {
+			t.Fatal("Expected SubmissionData with ID", expect, "got:", data.Data)
+		}
+		if err := FinishTask(db, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count, err := CountUserTasks(db, "alice"); err != nil || count != 0 {
+		t.Fatal("unexpected alice task count:", count, err)
+	}
+}
+
+func TestTaskSystemQueued(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if err := SaveProblem(db, Problem{Name: "aplusb", Title: "A + B", TestCasesVersion: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterUser(db, "alice", "uid-alice"); err != nil {
+		t.Fatal(err)
+	}
+	ids := []int32{}
+	for i := 0; i < 4; i++ {
+		id, err := SaveSubmission(db, Submission{
+			ProblemName: "aplusb",
+			Lang:        "cpp",
+			Status:      "WJ",
+			Source:      "int main(){}",
+			UserName:    sql.NullString{String: "alice", Valid: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	// a bulk rejudge of alice's submissions
+	for _, id := range ids[:3] {
+		if err := PushSystemSubmissionTask(db, SubmissionData{ID: id}, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count, err := CountUserTasks(db, "alice"); err != nil || count != 0 {
+		t.Fatal("rejudges are counted as alice's tasks:", count, err)
+	}
+
+	// her own submission is not delayed by the rejudges
+	before := time.Now()
+	if err := PushSubmissionTask(db, SubmissionData{ID: ids[3]}, 45); err != nil {
+		t.Fatal(err)
+	}
+	task := Task{}
+	if err := db.Where("priority = ?", 45).Take(&task).Error; err != nil {
+		t.Fatal(err)
+	}
+	if task.FairTime.After(before.Add(time.Second)) {
+		t.Fatal("fair time is pushed by the rejudges:", task.FairTime, before)
+	}
+	if count, err := CountUserTasks(db, "alice"); err != nil || count != 1 {
+		t.Fatal("unexpected alice task count:", count, err)
+	}
+}
+
+func TestTaskShortestJobFirst(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if err := SaveProblem(db, Problem{Name: "aplusb", Title: "A + B", Timelimit: 2000, TestCasesVersion: "v1", TestCaseCount: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveProblem(db, Problem{Name: "heavy", Title: "Heavy", Timelimit: 10000, TestCasesVersion: "v1", TestCaseCount: 200}); err != nil {
+		t.Fatal(err)
+	}
+	submit := func(problem string) int32 {
+		id, err := SaveSubmission(db, Submission{
+			ProblemName: problem,
+			Lang:        "cpp",
+			Status:      "WJ",
+			Source:      "int main(){}",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+
+	heavy := submit("heavy")
+	light := submit("aplusb")
+	for _, id := range []int32{heavy, light} {
+		if err := PushSubmissionTask(db, SubmissionData{ID: id}, 40); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	task := Task{}
+	if err := db.Order("id desc").Take(&task).Error; err != nil {
+		t.Fatal(err)
+	}
+	if expect := int32((defaultCompileTime + 5*2*time.Second).Milliseconds()); task.EstimatedCost != expect {
+		t.Fatal("unexpected estimated cost:", task.EstimatedCost, "!=", expect)
+	}
+
+	for _, expect := range []int32{light, heavy} {
+		id, data, err := PopTaskWithPolicy(db, PopShortestJobFirst)
+		if id == -1 || err != nil {
+			t.Fatal(id, data, err)
+		}
+		if submissionData, ok := data.Data.(SubmissionData); !ok || submissionData.ID != expect {
+			t.Fatal("Expected SubmissionData with ID", expect, "got:", data.Data)
+		}
+	}
+}
@@ -2,16 +2,34 @@ package database
 
 import (
 	"errors"
+	"fmt"
 
 	"gorm.io/gorm"
 )
 
+// UserRole decides what a user is allowed to do
+type UserRole string
+
+const (
+	RoleUser      UserRole = "user"
+	RoleModerator UserRole = "moderator"
+	RoleAdmin     UserRole = "admin"
+)
+
+func (r UserRole) Valid() bool {
+	switch r {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 // User is db table
 type User struct {
-	Name        string `gorm:"primaryKey" validate:"username"`
-	UID         string `gorm:"not null;unique"`
-	LibraryURL  string `validate:"libraryURL"`
-	IsDeveloper bool
+	Name       string   `gorm:"primaryKey" validate:"username"`
+	UID        string   `gorm:"not null;unique"`
+	LibraryURL string   `validate:"libraryURL"`
+	Role       UserRole `gorm:"not null;default:'user'"` // changed only by UpdateUserRole
 }
 
 func RegisterUser(db *gorm.DB, name string, uid string) error {
@@ -22,6 +40,7 @@ func RegisterUser(db *gorm.DB, name string, uid string) error {
 	user := User{
 		Name: name,
 		UID:  uid,
+		Role: RoleUser,
 	}
 	if err := validate.Struct(user); err != nil {
 		return err
@@ -39,7 +58,8 @@ func UpdateUser(db *gorm.DB, user User) error {
 	}
 
 	return db.Transaction(func(tx *gorm.DB) error {
-		if user2, err := FetchUserFromUID(tx, user.UID); err != nil || user2 == nil || user2.Name != user.Name {
+		user2, err := FetchUserFromUID(tx, user.UID)
+		if err != nil || user2 == nil || user2.Name != user.Name {
 			if err != nil {
 				return err
 			} else if user2 == nil {
@@ -56,8 +76,9 @@ func UpdateUser(db *gorm.DB, user User) error {
 			return err
 		}
 		user.Name = name
+		user.Role = user2.Role
 
-		if err := db.Save(&user).Error; err != nil {
+		if err := tx.Save(&user).Error; err != nil {
 			return err
 		}
 
@@ -94,3 +115,27 @@ func FetchUserFromName(db *gorm.DB, name string) (*User, error) {
 
 	return &user, nil
 }
+
+// UpdateUserRole changes the role of the user
+func UpdateUserRole(db *gorm.DB, name string, role UserRole) error {
+	if !role.Valid() {
+		return fmt.Errorf("unknown role: %s", role)
+	}
+	result := db.Model(&User{}).Where("name = ?", name).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotExist
+	}
+	return nil
+}
+
+// FetchStaffUsers returns the users whose role is not RoleUser
+func FetchStaffUsers(db *gorm.DB) ([]User, error) {
+	users := []User{}
+	if err := db.Where("role <> ?", RoleUser).Order("name asc").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
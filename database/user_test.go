@@ -136,3 +136,40 @@ func TestUpdateUserWithInvalidURL(t *testing.T) {
 		t.Log(err)
 	}
 }
+
+func TestUserRole(t *testing.T) {
+	db := CreateTestDB(t)
+
+	if err := RegisterUser(db, "name1", "id1"); err != nil {
+		t.Fatal(err)
+	}
+	if user, err := FetchUserFromName(db, "name1"); err != nil || user.Role != RoleUser {
+		t.Fatal(err, user)
+	}
+
+	if err := UpdateUserRole(db, "name1", RoleModerator); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateUserRole(db, "name1", "owner"); err == nil {
+		t.Fatal("unknown role is accepted")
+	}
+	if err := UpdateUserRole(db, "unknown", RoleAdmin); err != ErrNotExist {
+		t.Fatal("expected ErrNotExist:", err)
+	}
+
+	// UpdateUser must not change the role
+	if err := UpdateUser(db, User{Name: "name1", UID: "id1", Role: RoleAdmin}); err != nil {
+		t.Fatal(err)
+	}
+	if user, err := FetchUserFromName(db, "name1"); err != nil || user.Role != RoleModerator {
+		t.Fatal(err, user)
+	}
+
+	staff, err := FetchStaffUsers(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(staff) != 1 || staff[0].Name != "name1" {
+		t.Fatal("unexpected staff:", staff)
+	}
+}
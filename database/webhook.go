@@ -0,0 +1,298 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookEvent is the kind of the event sent to webhooks.
+type WebhookEvent string
+
+const (
+	// WebhookSubmissionFinished is sent when a submission gets its final status.
+	WebhookSubmissionFinished WebhookEvent = "submission.finished"
+	// WebhookHackFinished is sent when a hack gets its final status.
+	WebhookHackFinished WebhookEvent = "hack.finished"
+)
+
+func (e WebhookEvent) Valid() bool {
+	return e == WebhookSubmissionFinished || e == WebhookHackFinished
+}
+
+// Webhook is db table, a URL notified when submissions or hacks finish
+type Webhook struct {
+	ID       int32  `gorm:"primaryKey"`
+	UserName string `gorm:"index;not null"` // foreign key to users.name (migration 0009)
+	URL      string `gorm:"not null"`
+	Secret   string `gorm:"not null"` // HMAC key of the signature
+	Events   string // space separated WebhookEvent
+	// AllUsers webhooks receive the events of every user, others only those of the owner
+	AllUsers  bool `gorm:"not null;default:false"`
+	CreatedAt time.Time
+}
+
+// Subscribes reports whether the webhook receives event.
+func (w Webhook) Subscribes(event WebhookEvent) bool {
+	return slices.Contains(strings.Fields(w.Events), string(event))
+}
+
+type WebhookDeliveryStatus = string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed" // gave up retrying
+)
+
+// WebhookDelivery is db table, a payload sent (or to be sent) to a webhook
+type WebhookDelivery struct {
+	ID            int32   `gorm:"primaryKey"`
+	WebhookID     int32   `gorm:"index;not null"`
+	Webhook       Webhook `gorm:"foreignKey:WebhookID"`
+	Event         string
+	Payload       string // JSON
+	Status        WebhookDeliveryStatus
+	Attempts      int32
+	NextAttemptAt time.Time `gorm:"index"` // meaningful only while pending
+	LastAttemptAt sql.NullTime
+	ResponseCode  int32  // status code of the last attempt, 0 if no response
+	LastError     string // empty if the last attempt succeeded
+	CreatedAt     time.Time
+}
+
+// WebhookPayload is the JSON body of a delivery.
+type WebhookPayload struct {
+	Event      WebhookEvent              `json:"event"`
+	Time       time.Time                 `json:"time"`
+	Submission *WebhookSubmissionPayload `json:"submission,omitempty"`
+	Hack       *WebhookHackPayload       `json:"hack,omitempty"`
+}
+
+type WebhookSubmissionPayload struct {
+	ID      int32  `json:"id"`
+	Problem string `json:"problem"`
+	User    string `json:"user,omitempty"`
+	Lang    string `json:"lang"`
+	Status  string `json:"status"`
+	Time    int32  `json:"time_ms"`
+	Memory  int64  `json:"memory_bytes"`
+}
+
+type WebhookHackPayload struct {
+	ID           int32  `json:"id"`
+	SubmissionID int32  `json:"submission_id"`
+	Problem      string `json:"problem"`
+	User         string `json:"user,omitempty"`
+	Status       string `json:"status"`
+}
+
+// save webhook and return id
+func SaveWebhook(db *gorm.DB, w Webhook) (int32, error) {
+	if w.ID != 0 {
+		return 0, errors.New("must not specify webhook id")
+	}
+	if w.UserName == "" || w.URL == "" || w.Secret == "" {
+		return 0, errors.New("user name / url / secret is empty")
+	}
+	if err := db.Create(&w).Error; err != nil {
+		return 0, err
+	}
+	return w.ID, nil
+}
+
+func FetchWebhook(db *gorm.DB, id int32) (Webhook, error) {
+	w := Webhook{}
+	if err := db.Where("id = ?", id).Take(&w).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return Webhook{}, ErrNotExist
+	} else if err != nil {
+		return Webhook{}, err
+	}
+	return w, nil
+}
+
+// FetchWebhooks returns the webhooks of the user, oldest first.
+func FetchWebhooks(db *gorm.DB, userName string) ([]Webhook, error) {
+	webhooks := []Webhook{}
+	if err := db.Where("user_name = ?", userName).Order("id asc").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes the webhook and its deliveries only if it belongs to the user.
+func DeleteWebhook(db *gorm.DB, userName string, id int32) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_name = ?", id, userName).Delete(&Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotExist
+		}
+		return tx.Where("webhook_id = ?", id).Delete(&WebhookDelivery{}).Error
+	})
+}
+
+// EnqueueSubmissionWebhooks creates the deliveries of the final status of s
+// to the webhooks of its submitter and the all-users webhooks.
+func EnqueueSubmissionWebhooks(db *gorm.DB, s Submission) error {
+	return enqueueWebhooks(db, WebhookPayload{
+		Event: WebhookSubmissionFinished,
+		Time:  time.Now(),
+		Submission: &WebhookSubmissionPayload{
+			ID:      s.ID,
+			Problem: s.ProblemName,
+			User:    s.UserName.String,
+			Lang:    s.Lang,
+			Status:  s.Status,
+			Time:    s.MaxTime,
+			Memory:  s.MaxMemory,
+		},
+	}, s.UserName)
+}
+
+// EnqueueHackWebhooks creates the deliveries of the final status of h to the
+// webhooks of the hacker, the author of the hacked submission and the all-users
+// webhooks. h.Submission must be loaded.
+func EnqueueHackWebhooks(db *gorm.DB, h Hack) error {
+	return enqueueWebhooks(db, WebhookPayload{
+		Event: WebhookHackFinished,
+		Time:  time.Now(),
+		Hack: &WebhookHackPayload{
+			ID:           h.ID,
+			SubmissionID: h.SubmissionID,
+			Problem:      h.Submission.ProblemName,
+			User:         h.UserName.String,
+			Status:       h.Status,
+		},
+	}, h.UserName, h.Submission.UserName)
+}
+
+func enqueueWebhooks(db *gorm.DB, payload WebhookPayload, owners ...sql.NullString) error {
+	names := []string{}
+	for _, owner := range owners {
+		if owner.Valid && owner.String != "" {
+			names = append(names, owner.String)
+		}
+	}
+	query := db.Where("all_users")
+	if len(names) > 0 {
+		query = db.Where("all_users OR user_name IN ?", names)
+	}
+	webhooks := []Webhook{}
+	if err := query.Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	deliveries := []WebhookDelivery{}
+	for _, w := range webhooks {
+		if !w.Subscribes(payload.Event) {
+			continue
+		}
+		deliveries = append(deliveries, WebhookDelivery{
+			WebhookID:     w.ID,
+			Event:         string(payload.Event),
+			Payload:       string(body),
+			Status:        DeliveryPending,
+			NextAttemptAt: payload.Time,
+			CreatedAt:     payload.Time,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.Omit("Webhook").Create(&deliveries).Error
+}
+
+// ClaimWebhookDeliveries returns at most limit pending deliveries that are due,
+// with their webhooks. They are not claimed again until lease passes, so that
+// a crashed sender does not lose them.
+func ClaimWebhookDeliveries(db *gorm.DB, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", DeliveryPending, now).
+			Order("next_attempt_at asc, id asc").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		ids := []int32{}
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return tx.Model(&WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+
+	webhookIDs := []int32{}
+	for _, d := range deliveries {
+		webhookIDs = append(webhookIDs, d.WebhookID)
+	}
+	webhooks := []Webhook{}
+	if err := db.Where("id IN ?", webhookIDs).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	byID := map[int32]Webhook{}
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+	for i := range deliveries {
+		deliveries[i].Webhook = byID[deliveries[i].WebhookID]
+	}
+	return deliveries, nil
+}
+
+// RecordWebhookAttempt records an attempt of the delivery. A failed attempt
+// (attemptErr is not empty) is retried at retryAt, or given up if retryAt is zero.
+func RecordWebhookAttempt(db *gorm.DB, id int32, at time.Time, responseCode int32, attemptErr string, retryAt time.Time) error {
+	updates := map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_attempt_at": at,
+		"response_code":   responseCode,
+		"last_error":      attemptErr,
+	}
+	switch {
+	case attemptErr == "":
+		updates["status"] = DeliverySucceeded
+	case retryAt.IsZero():
+		updates["status"] = DeliveryFailed
+	default:
+		updates["status"] = DeliveryPending
+		updates["next_attempt_at"] = retryAt
+	}
+	return db.Model(&WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// FetchWebhookDeliveries returns the latest deliveries of the webhook, newest first.
+func FetchWebhookDeliveries(db *gorm.DB, webhookID int32, limit int) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	if err := db.Where("webhook_id = ?", webhookID).Order("id desc").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// DeleteWebhookDeliveriesBefore deletes finished deliveries created before t
+// and returns the number of deleted rows.
+func DeleteWebhookDeliveriesBefore(db *gorm.DB, t time.Time) (int64, error) {
+	result := db.Where("status <> ? AND created_at < ?", DeliveryPending, t).Delete(&WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	db := CreateTestDB(t)
+	createDummyProblem(t, db)
+	for _, name := range []string{"alice", "bob", "admin"} {
+		if err := RegisterUser(db, name, "id-"+name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	save := func(w Webhook) int32 {
+		t.Helper()
+		w.Secret = "secret"
+		w.CreatedAt = time.Now()
+		id, err := SaveWebhook(db, w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	aliceID := save(Webhook{UserName: "alice", URL: "https://alice.example.com", Events: "submission.finished hack.finished"})
+	bobID := save(Webhook{UserName: "bob", URL: "https://bob.example.com", Events: "hack.finished"})
+	adminID := save(Webhook{UserName: "admin", URL: "https://admin.example.com", Events: "submission.finished", AllUsers: true})
+
+	submission := Submission{
+		ID:          1,
+		ProblemName: "aplusb",
+		UserName:    sql.NullString{String: "alice", Valid: true},
+		Lang:        "cpp",
+		Status:      "AC",
+		MaxTime:     12,
+		MaxMemory:   1024,
+	}
+	if err := EnqueueSubmissionWebhooks(db, submission); err != nil {
+		t.Fatal(err)
+	}
+	hack := Hack{
+		ID:           2,
+		SubmissionID: 1,
+		Submission:   submission,
+		UserName:     sql.NullString{String: "bob", Valid: true},
+		Status:       "WA",
+	}
+	if err := EnqueueHackWebhooks(db, hack); err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := ClaimWebhookDeliveries(db, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[int32][]string{}
+	for _, d := range deliveries {
+		if d.Webhook.ID != d.WebhookID || d.Webhook.URL == "" {
+			t.Fatal("webhook is not loaded:", d)
+		}
+		got[d.WebhookID] = append(got[d.WebhookID], d.Event)
+	}
+	// alice: her submission and the hack to it, bob: his hack, admin: every submission
+	if len(got[aliceID]) != 2 || len(got[bobID]) != 1 || len(got[adminID]) != 1 || got[adminID][0] != "submission.finished" {
+		t.Fatal("unexpected deliveries:", got)
+	}
+	payload := WebhookPayload{}
+	if err := json.Unmarshal([]byte(deliveries[0].Payload), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != WebhookSubmissionFinished || payload.Submission == nil || payload.Submission.Status != "AC" || payload.Submission.User != "alice" {
+		t.Fatal("unexpected payload:", deliveries[0].Payload)
+	}
+
+	// claimed deliveries are leased
+	if again, err := ClaimWebhookDeliveries(db, 10, time.Minute); err != nil || len(again) != 0 {
+		t.Fatal("claimed deliveries must not be claimed again:", again, err)
+	}
+
+	now := time.Now()
+	if err := RecordWebhookAttempt(db, deliveries[0].ID, now, 200, "", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordWebhookAttempt(db, deliveries[1].ID, now, 500, "status 500", now.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordWebhookAttempt(db, deliveries[2].ID, now, 0, "timeout", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	retry, err := ClaimWebhookDeliveries(db, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retry) != 1 || retry[0].ID != deliveries[1].ID || retry[0].Attempts != 1 {
+		t.Fatal("only the failed delivery must be retried:", retry)
+	}
+
+	log, err := FetchWebhookDeliveries(db, deliveries[0].WebhookID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := map[int32]string{}
+	for _, d := range log {
+		statuses[d.ID] = d.Status
+	}
+	if statuses[deliveries[0].ID] != DeliverySucceeded {
+		t.Fatal("unexpected delivery log:", log)
+	}
+
+	deleted, err := DeleteWebhookDeliveriesBefore(db, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 2 {
+		t.Fatal("only finished deliveries must be deleted:", deleted)
+	}
+
+	// other users cannot delete the webhook
+	if err := DeleteWebhook(db, "bob", aliceID); err != ErrNotExist {
+		t.Fatal("expected ErrNotExist:", err)
+	}
+	if err := DeleteWebhook(db, "alice", aliceID); err != nil {
+		t.Fatal(err)
+	}
+	if webhooks, err := FetchWebhooks(db, "alice"); err != nil || len(webhooks) != 0 {
+		t.Fatal("webhook is not deleted:", webhooks, err)
+	}
+}
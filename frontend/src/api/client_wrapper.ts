@@ -172,7 +172,7 @@ export const useUserInfo = (
       ]);
       const solvedMap = toSolvedStatusMap(stats.solved_map ?? {});
       return {
-        isAdmin: false,
+        isAdmin: userInfo.user.role === "admin",
         user: {
           name: userInfo.user.name,
           libraryUrl: userInfo.user.library_url,
@@ -225,7 +225,7 @@ export const useSubmissionList = (
       });
       return {
         submissions: res.submissions.map(toSubmissionOverviewProto),
-        count: res.count,
+        count: res.count ?? 0,
       } satisfies SubmissionListResponse;
     },
     structuralSharing: false,
@@ -439,7 +439,7 @@ export const useHackList = (
       const res = await fetchHackList({ user, status, order, skip, limit });
       return {
         hacks: res.hacks.map(toHackOverviewProto),
-        count: res.count,
+        count: res.count ?? 0,
       } satisfies HackListResponse;
     },
     structuralSharing: false,
@@ -16,8 +16,38 @@ const getRestBaseUrl = (): string => {
 const REST_BASE = getRestBaseUrl();
 const client = createClient<paths>({ baseUrl: REST_BASE });
 
+type ProblemDetails = components["schemas"]["ProblemDetails"];
+
+// RestError carries the problem details of a failed request, so that callers
+// can branch on the stable error code instead of the message.
+export class RestError extends Error {
+  readonly status: number;
+  readonly problem?: ProblemDetails;
+
+  constructor(url: string, status: number, problem?: ProblemDetails) {
+    super(
+      `REST ${url} failed: ${status} ${problem?.detail ?? problem?.title ?? ""}`,
+    );
+    this.name = "RestError";
+    this.status = status;
+    this.problem = problem;
+  }
+
+  get code(): components["schemas"]["ErrorCode"] | undefined {
+    return this.problem?.code;
+  }
+}
+
+const isProblemDetails = (value: unknown): value is ProblemDetails =>
+  typeof value === "object" &&
+  value !== null &&
+  typeof (value as { code?: unknown }).code === "string";
+
 function unwrap<T>(r: { data?: T; error?: unknown; response: Response }) {
   if (r.error) {
+    if (isProblemDetails(r.error)) {
+      throw new RestError(r.response.url, r.response.status, r.error);
+    }
     const status = `${r.response.status} ${r.response.statusText}`;
     const msg = typeof r.error === "string" ? r.error : JSON.stringify(r.error);
     throw new Error(`REST ${r.response.url} failed: ${status} ${msg}`);
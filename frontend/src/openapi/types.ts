@@ -72,6 +72,91 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
+  "/problems/{name}/statement": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get the problem statement (task.md) of the current version */
+    get: operations["getProblemStatement"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/problems/{name}/examples": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get the examples of the current test cases */
+    get: operations["getProblemExamples"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/problems/{name}/files": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List the public files (grader, headers, ...) of the current version */
+    get: operations["getProblemFiles"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/problems/{name}/testcases": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List the test cases of the current version with their sizes */
+    get: operations["getProblemTestCases"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/problems/{name}/file": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Download a public file of the current version */
+    get: operations["getProblemFile"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
   "/langs": {
     parameters: {
       query?: never;
@@ -157,6 +242,46 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
+  "/submissions/{id}/runs/diff": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Compare the case results of two judge runs of a submission */
+    get: operations["getJudgeRunDiff"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/submissions/{id}/events": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /**
+     * Stream status changes of a submission
+     * @description Server-Sent Events stream. Each `submission` event carries a
+     *     SubmissionStatusEvent as JSON. The first event is the current state and
+     *     later events contain only the case results changed since the previous
+     *     event. The stream ends after the submission reaches a final status.
+     */
+    get: operations["getSubmissionEvents"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
   "/submissions/{id}/rejudge": {
     parameters: {
       query?: never;
@@ -209,6 +334,47 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
+  "/hacks/{id}/events": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /**
+     * Stream status changes of a hack
+     * @description Server-Sent Events stream. Each `hack` event carries a HackStatusEvent
+     *     as JSON. The stream ends after the hack reaches a final status.
+     */
+    get: operations["getHackEvents"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/hacks/{id}/rejudge": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /**
+     * Judge a hack again
+     * @description Requires the moderator or admin role.
+     */
+    post: operations["postHackRejudge"];
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
   "/auth/register": {
     parameters: {
       query?: never;
@@ -244,104 +410,344 @@ export interface paths {
     patch: operations["patchCurrentUserInfo"];
     trace?: never;
   };
-  "/users/{name}": {
+  "/auth/tokens": {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    /** Get user info */
-    get: operations["getUserInfo"];
+    /** List personal API tokens of the current user */
+    get: operations["getAPITokens"];
     put?: never;
-    post?: never;
+    /**
+     * Create a personal API token
+     * @description The secret is returned only once. Tokens cannot be used to manage tokens.
+     */
+    post: operations["postAPIToken"];
     delete?: never;
     options?: never;
     head?: never;
     patch?: never;
     trace?: never;
   };
-  "/users/{name}/statistics": {
+  "/auth/tokens/{id}": {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    /** Get user solved statistics */
-    get: operations["getUserStatistics"];
+    get?: never;
     put?: never;
     post?: never;
-    delete?: never;
+    /** Revoke a personal API token */
+    delete: operations["deleteAPIToken"];
     options?: never;
     head?: never;
     patch?: never;
     trace?: never;
   };
-}
-export type webhooks = Record<string, never>;
-export interface components {
-  schemas: {
-    HackOverview: {
-      /** Format: int32 */
-      id: number;
-      /** Format: int32 */
-      submission_id: number;
-      status: string;
-      user_name?: string;
-      /** Format: float */
-      time?: number;
-      /** Format: int64 */
-      memory?: number;
-      /** Format: date-time */
-      hack_time: string;
-    };
-    HackListResponse: {
-      hacks: components["schemas"]["HackOverview"][];
-      /** Format: int32 */
-      count: number;
+  "/webhooks": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
-    HackInfoResponse: {
-      overview: components["schemas"]["HackOverview"];
-      /** Format: byte */
-      test_case_txt?: string;
-      /** Format: byte */
-      test_case_cpp?: string;
-      /** Format: byte */
-      stderr?: string;
-      /** Format: byte */
-      judge_output?: string;
+    /** List webhooks of the current user */
+    get: operations["getWebhooks"];
+    put?: never;
+    /** Register a webhook */
+    post: operations["postWebhook"];
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/webhooks/{id}": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
-    CreateHackRequest: {
-      /** Format: int32 */
-      submission: number;
-      /** Format: byte */
-      test_case_txt?: string;
-      /** Format: byte */
-      test_case_cpp?: string;
+    get?: never;
+    put?: never;
+    post?: never;
+    /** Delete a webhook and its delivery log */
+    delete: operations["deleteWebhook"];
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/webhooks/{id}/deliveries": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
-    HackResponse: {
-      /** Format: int32 */
-      id: number;
+    /** Get the delivery log of a webhook, newest first */
+    get: operations["getWebhookDeliveries"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/admin/users": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
-    /** @description Optional URL for the user's library profile. Use an empty string to keep it unset. */
-    LibraryUrl: string;
-    /** @description Problem identifier consisting of lowercase letters, digits, or underscores. */
-    ProblemName: string;
     /**
-     * @description Sorting order for submissions.
-     * @default -id
-     * @enum {string}
+     * List users with a role other than user
+     * @description Requires the admin role.
      */
-    SubmissionOrder: "-id" | "+time";
-    /** @description Unique user identifier consisting of letters, digits, hyphen, or underscore. */
-    Username: string;
-    UserStatistics: {
-      name: components["schemas"]["Username"];
-      /** Format: int32 */
-      count: number;
+    get: operations["getAdminUsers"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/admin/users/{name}/role": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
-    RankingResponse: {
+    get?: never;
+    /**
+     * Change the role of a user
+     * @description Requires the admin role. Admins cannot change their own role.
+     */
+    put: operations["putUserRole"];
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/admin/rejudge": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /**
+     * List bulk rejudge jobs, newest first
+     * @description Requires the admin role.
+     */
+    get: operations["getBulkRejudgeJobs"];
+    put?: never;
+    /**
+     * Rejudge submissions matched by filters
+     * @description Requires the admin role. With dry_run, only counts the matched
+     *     submissions. Otherwise creates a job, which the API servers drain at
+     *     the given low priority, at most `rate` tasks per second. A job with
+     *     the same filters that is still running is a conflict.
+     */
+    post: operations["postBulkRejudge"];
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/admin/rejudge/{id}": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    post?: never;
+    /**
+     * Cancel a bulk rejudge job
+     * @description Requires the admin role. Tasks already enqueued by the job are kept.
+     */
+    delete: operations["cancelBulkRejudgeJob"];
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/admin/auto_rejudge": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /**
+     * Progress of the automatic rejudge of outdated AC submissions
+     * @description Requires the admin role.
+     */
+    get: operations["getAutoRejudgeProgress"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/users/{name}": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get user info */
+    get: operations["getUserInfo"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/users/{name}/statistics": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get user solved statistics */
+    get: operations["getUserStatistics"];
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+}
+export type webhooks = Record<string, never>;
+export interface components {
+  schemas: {
+    /**
+     * @description Stable machine-readable error code. Clients should branch on this
+     *     instead of the message; new codes may be added.
+     * @enum {string}
+     */
+    ErrorCode:
+      | "invalid_request"
+      | "invalid_parameter"
+      | "unauthorized"
+      | "invalid_api_token"
+      | "forbidden"
+      | "user_not_registered"
+      | "not_found"
+      | "problem_not_found"
+      | "unknown_problem"
+      | "unknown_language"
+      | "method_not_allowed"
+      | "limit_exceeded"
+      | "too_many_pending_tasks"
+      | "too_many_requests"
+      | "conflict"
+      | "internal_error"
+      | "service_unavailable";
+    FieldError: {
+      /** @description Name of the request body field or parameter. */
+      field: string;
+      reason: string;
+    };
+    /** @description Error body (RFC 7807, application/problem+json). */
+    ProblemDetails: {
+      /** @description Always about:blank, use code to identify the error. */
+      type: string;
+      /** @description Reason phrase of the status. */
+      title: string;
+      /** Format: int32 */
+      status: number;
+      /** @description Human-readable message, may change. */
+      detail?: string;
+      /** @description Path of the request. */
+      instance?: string;
+      code: components["schemas"]["ErrorCode"];
+      errors?: components["schemas"]["FieldError"][];
+    };
+    HackOverview: {
+      /** Format: int32 */
+      id: number;
+      /** Format: int32 */
+      submission_id: number;
+      status: string;
+      user_name?: string;
+      /** Format: float */
+      time?: number;
+      /** Format: int64 */
+      memory?: number;
+      /** Format: date-time */
+      hack_time: string;
+    };
+    HackListResponse: {
+      hacks: components["schemas"]["HackOverview"][];
+      /** Format: int32 */
+      count: number;
+    };
+    HackInfoResponse: {
+      overview: components["schemas"]["HackOverview"];
+      /** Format: byte */
+      test_case_txt?: string;
+      /** Format: byte */
+      test_case_cpp?: string;
+      /** Format: byte */
+      stderr?: string;
+      /** Format: byte */
+      judge_output?: string;
+    };
+    CreateHackRequest: {
+      /** Format: int32 */
+      submission: number;
+      /** Format: byte */
+      test_case_txt?: string;
+      /** Format: byte */
+      test_case_cpp?: string;
+    };
+    HackResponse: {
+      /** Format: int32 */
+      id: number;
+    };
+    /** @description Optional URL for the user's library profile. Use an empty string to keep it unset. */
+    LibraryUrl: string;
+    /** @description Problem identifier consisting of lowercase letters, digits, or underscores. */
+    ProblemName: string;
+    /**
+     * @description Sorting order for submissions.
+     * @default -id
+     * @enum {string}
+     */
+    SubmissionOrder: "-id" | "+time";
+    /** @description Unique user identifier consisting of letters, digits, hyphen, or underscore. */
+    Username: string;
+    UserStatistics: {
+      name: components["schemas"]["Username"];
+      /** Format: int32 */
+      count: number;
+    };
+    RankingResponse: {
       statistics: components["schemas"]["UserStatistics"][];
       /** Format: int32 */
       count: number;
@@ -363,6 +769,50 @@ export interface components {
       testcases_version: string;
       overall_version: string;
     };
+    ProblemStatementResponse: {
+      overall_version: string;
+      /** @description task.md in Markdown. */
+      statement: string;
+    };
+    ProblemExample: {
+      /** @example example_00 */
+      name: string;
+      in: string;
+      out: string;
+    };
+    ProblemExamplesResponse: {
+      testcases_version: string;
+      examples: components["schemas"]["ProblemExample"][];
+    };
+    ProblemFile: {
+      /** @description Path relative to the problem dir, or under common/ for shared headers. */
+      path: string;
+      /** Format: int64 */
+      size: number;
+    };
+    ProblemFilesResponse: {
+      overall_version: string;
+      files: components["schemas"]["ProblemFile"][];
+    };
+    ProblemTestCase: {
+      /** @description Name of the case, e.g. random_00. */
+      name: string;
+      /** @description Generator in info.toml, e.g. random.cpp. Empty if unknown. */
+      generator: string;
+      /** Format: int64 */
+      in_size: number;
+      /** Format: int64 */
+      out_size: number;
+    };
+    ProblemTestCasesResponse: {
+      testcases_version: string;
+      /**
+       * Format: int64
+       * @description Total size of the input and output files.
+       */
+      total_size: number;
+      testcases: components["schemas"]["ProblemTestCase"][];
+    };
     Lang: {
       id: string;
       name: string;
@@ -381,8 +831,15 @@ export interface components {
     User: {
       name: components["schemas"]["Username"];
       library_url: components["schemas"]["LibraryUrl"];
+      /** @description True for moderators and admins. Ignored on update. */
       is_developer: boolean;
+      role?: components["schemas"]["UserRole"];
     };
+    /**
+     * @description Set only by admins through PUT /admin/users/{name}/role.
+     * @enum {string}
+     */
+    UserRole: "user" | "moderator" | "admin";
     RegisterRequest: {
       name: components["schemas"]["Username"];
     };
@@ -394,6 +851,204 @@ export interface components {
       user: components["schemas"]["User"];
     };
     ChangeCurrentUserInfoResponse: Record<string, never>;
+    /**
+     * @description submit allows submitting and rejudging, hack allows creating hacks.
+     * @enum {string}
+     */
+    APITokenScope: "submit" | "hack";
+    APIToken: {
+      /** Format: int32 */
+      id: number;
+      name: string;
+      /** @description First characters of the secret to identify the token. */
+      prefix: string;
+      scopes: components["schemas"]["APITokenScope"][];
+      /** Format: date-time */
+      created_at: string;
+      /** Format: date-time */
+      expires_at?: string;
+      /** Format: date-time */
+      last_used_at?: string;
+    };
+    APITokenListResponse: {
+      tokens: components["schemas"]["APIToken"][];
+    };
+    CreateAPITokenRequest: {
+      name: string;
+      scopes: components["schemas"]["APITokenScope"][];
+      /**
+       * Format: int32
+       * @description Days until the token expires. The token never expires if omitted.
+       */
+      expires_in_days?: number;
+    };
+    CreateAPITokenResponse: {
+      token: components["schemas"]["APIToken"];
+      /** @description Bearer token. It cannot be retrieved again. */
+      secret: string;
+    };
+    RevokeAPITokenResponse: Record<string, never>;
+    /** @enum {string} */
+    WebhookEvent: "submission.finished" | "hack.finished";
+    Webhook: {
+      /** Format: int32 */
+      id: number;
+      url: string;
+      events: components["schemas"]["WebhookEvent"][];
+      /** @description Whether the webhook receives the events of every user. */
+      all_users: boolean;
+      /** Format: date-time */
+      created_at: string;
+    };
+    WebhookListResponse: {
+      webhooks: components["schemas"]["Webhook"][];
+    };
+    CreateWebhookRequest: {
+      /** @description HTTPS URL receiving POST requests. */
+      url: string;
+      events: components["schemas"]["WebhookEvent"][];
+      /** @default false */
+      all_users?: boolean;
+    };
+    CreateWebhookResponse: {
+      webhook: components["schemas"]["Webhook"];
+      /** @description HMAC-SHA256 key. Each request has X-LibraryChecker-Signature:
+       *     sha256=hex(HMAC(secret, X-LibraryChecker-Timestamp + "." + body)).
+       *     It cannot be retrieved again.
+       *      */
+      secret: string;
+    };
+    DeleteWebhookResponse: Record<string, never>;
+    /**
+     * @description pending deliveries are retried with backoff, failed ones were given up.
+     * @enum {string}
+     */
+    WebhookDeliveryStatus: "pending" | "succeeded" | "failed";
+    WebhookDelivery: {
+      /** Format: int32 */
+      id: number;
+      event: components["schemas"]["WebhookEvent"];
+      /** @description JSON body sent to the webhook. */
+      payload: string;
+      status: components["schemas"]["WebhookDeliveryStatus"];
+      /** Format: int32 */
+      attempts: number;
+      /**
+       * Format: int32
+       * @description HTTP status of the last attempt, omitted if there was no response.
+       */
+      response_code?: number;
+      /** @description Error of the last attempt. */
+      error?: string;
+      /** Format: date-time */
+      created_at: string;
+      /** Format: date-time */
+      last_attempt_at?: string;
+      /**
+       * Format: date-time
+       * @description Set while pending.
+       */
+      next_attempt_at?: string;
+    };
+    WebhookDeliveryListResponse: {
+      deliveries: components["schemas"]["WebhookDelivery"][];
+    };
+    HackRejudgeResponse: Record<string, never>;
+    AdminUserListResponse: {
+      users: components["schemas"]["User"][];
+    };
+    UpdateUserRoleRequest: {
+      role: components["schemas"]["UserRole"];
+    };
+    UpdateUserRoleResponse: Record<string, never>;
+    /** @description At least one filter must be given. Filters are combined with AND, and
+     *     mean the same as the query parameters of GET /submissions.
+     *      */
+    BulkRejudgeRequest: {
+      problem?: string;
+      /** @description Status, or comma separated statuses (e.g. WA,TLE). */
+      status?: string;
+      /** @description Language, or comma separated languages (e.g. cpp,rust). */
+      lang?: string;
+      user?: string;
+      /** @description Only submissions judged (or not judged) with the current test cases. */
+      is_latest?: boolean;
+      hacked?: boolean;
+      /** @description Same as is_latest=false. */
+      outdated_only?: boolean;
+      /** Format: date-time */
+      since?: string;
+      /** Format: date-time */
+      until?: string;
+      dry_run?: boolean;
+      /**
+       * Format: int32
+       * @description Task priority (default 5). Lower than rejudges by users (40).
+       */
+      priority?: number;
+      /**
+       * Format: int32
+       * @description Tasks enqueued per second (default 10).
+       */
+      rate?: number;
+    };
+    BulkRejudgeResponse: {
+      /**
+       * Format: int64
+       * @description Number of matched submissions.
+       */
+      count: number;
+      dry_run: boolean;
+      /**
+       * Format: int32
+       * @description ID of the created job. Not set with dry_run.
+       */
+      job_id?: number;
+    };
+    BulkRejudgeJob: {
+      /** Format: int32 */
+      id: number;
+      /** @description Filters of the job in JSON. */
+      filter: string;
+      /** Format: int32 */
+      priority: number;
+      /** Format: int32 */
+      rate: number;
+      created_by: string;
+      /**
+       * Format: int32
+       * @description Matched submissions when the job was created.
+       */
+      total: number;
+      /** Format: int32 */
+      queued: number;
+      /** Format: date-time */
+      started_at: string;
+      /** Format: date-time */
+      finished_at?: string;
+      canceled: boolean;
+    };
+    BulkRejudgeJobListResponse: {
+      jobs: components["schemas"]["BulkRejudgeJob"][];
+    };
+    AutoRejudgeProgress: {
+      problem: string;
+      test_cases_version: string;
+      /**
+       * Format: int32
+       * @description Outdated AC submissions when the test case update was noticed.
+       */
+      total: number;
+      /** Format: int32 */
+      queued: number;
+      /** Format: date-time */
+      started_at: string;
+      /** Format: date-time */
+      finished_at?: string;
+    };
+    AutoRejudgeProgressListResponse: {
+      progresses: components["schemas"]["AutoRejudgeProgress"][];
+    };
     UserInfoResponse: {
       user: components["schemas"]["User"];
     };
@@ -437,8 +1092,13 @@ export interface components {
     };
     SubmissionListResponse: {
       submissions: components["schemas"]["SubmissionOverview"][];
-      /** Format: int32 */
-      count: number;
+      /**
+       * Format: int32
+       * @description Number of matched submissions, omitted if with_count is false.
+       */
+      count?: number;
+      /** @description Cursor of the next page, omitted on the last page. */
+      next_cursor?: string;
     };
     SubmissionCaseResult: {
       case: string;
@@ -459,6 +1119,59 @@ export interface components {
       compile_error?: string;
       can_rejudge: boolean;
       case_results?: components["schemas"]["SubmissionCaseResult"][];
+      /** @description Judge runs of the submission, oldest first. */
+      runs?: components["schemas"]["JudgeRun"][];
+    };
+    SubmissionStatusEvent: {
+      /** Format: int32 */
+      id: number;
+      status: string;
+      /** Format: float */
+      time: number;
+      /** Format: int64 */
+      memory: number;
+      /** @description Case results changed since the previous event. */
+      case_results?: components["schemas"]["SubmissionCaseResult"][];
+      /** @description True if judging finished and no more events follow. */
+      final: boolean;
+    };
+    HackStatusEvent: {
+      /** Format: int32 */
+      id: number;
+      status: string;
+      /** Format: float */
+      time?: number;
+      /** Format: int64 */
+      memory?: number;
+      /** @description True if judging finished and no more events follow. */
+      final: boolean;
+    };
+    JudgeRun: {
+      /** Format: int32 */
+      id: number;
+      status: string;
+      testcases_version: string;
+      judge: string;
+      /** Format: float */
+      time: number;
+      /** Format: int64 */
+      memory: number;
+      /** Format: date-time */
+      start_time: string;
+      /** Format: date-time */
+      end_time?: string;
+    };
+    JudgeRunCaseDiff: {
+      case: string;
+      base?: components["schemas"]["SubmissionCaseResult"];
+      target?: components["schemas"]["SubmissionCaseResult"];
+      /** @description True if the status differs or the case exists in only one run. */
+      changed: boolean;
+    };
+    JudgeRunDiffResponse: {
+      base: components["schemas"]["JudgeRun"];
+      target: components["schemas"]["JudgeRun"];
+      cases: components["schemas"]["JudgeRunCaseDiff"][];
     };
     TaskQueueInfo: {
       /** Format: int32 */
@@ -476,42 +1189,481 @@ export interface components {
       task_queue: components["schemas"]["TaskQueueInfo"];
     };
   };
-  responses: never;
-  parameters: {
-    /** @description Number of ranking records to skip before collecting results. */
-    RankingSkip: number;
-    /** @description Maximum number of ranking records to return (1-1000). */
-    RankingLimit: number;
-    /** @description Number of submissions to skip before collecting results. */
-    SubmissionSkip: number;
-    /** @description Maximum number of submissions to return (1-1000). */
-    SubmissionLimit: number;
-    /** @description Number of hacks to skip before collecting results. */
-    HackSkip: number;
-    /** @description Maximum number of hacks to return (1-1000). */
-    HackLimit: number;
-    /** @description Submission identifier. */
-    SubmissionId: number;
-    /** @description Hack identifier. */
-    HackId: number;
-    /** @description Problem identifier. */
-    ProblemName: components["schemas"]["ProblemName"];
-    /** @description User identifier. */
-    UserNamePath: components["schemas"]["Username"];
-  };
-  requestBodies: never;
-  headers: never;
-  pathItems: never;
-}
-export type $defs = Record<string, never>;
-export interface operations {
-  getRanking: {
+  responses: {
+    /** @description Error */
+    Error: {
+      headers: {
+        [name: string]: unknown;
+      };
+      content: {
+        "application/problem+json": components["schemas"]["ProblemDetails"];
+      };
+    };
+  };
+  parameters: {
+    /** @description Number of ranking records to skip before collecting results. */
+    RankingSkip: number;
+    /** @description Maximum number of ranking records to return (1-1000). */
+    RankingLimit: number;
+    /** @description Number of submissions to skip before collecting results. */
+    SubmissionSkip: number;
+    /** @description Maximum number of submissions to return (1-1000). */
+    SubmissionLimit: number;
+    /** @description Number of hacks to skip before collecting results. */
+    HackSkip: number;
+    /** @description Maximum number of hacks to return (1-1000). */
+    HackLimit: number;
+    /** @description Submission identifier. */
+    SubmissionId: number;
+    /** @description Hack identifier. */
+    HackId: number;
+    /** @description Problem identifier. */
+    ProblemName: components["schemas"]["ProblemName"];
+    /** @description User identifier. */
+    UserNamePath: components["schemas"]["Username"];
+  };
+  requestBodies: never;
+  headers: never;
+  pathItems: never;
+}
+export type $defs = Record<string, never>;
+export interface operations {
+  getRanking: {
+    parameters: {
+      query?: {
+        /** @description Number of ranking records to skip before collecting results. */
+        skip?: components["parameters"]["RankingSkip"];
+        /** @description Maximum number of ranking records to return (1-1000). */
+        limit?: components["parameters"]["RankingLimit"];
+      };
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["RankingResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getMonitoring: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["MonitoringResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblems: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemListResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemInfo: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemInfoResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemStatement: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemStatementResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemExamples: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemExamplesResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemFiles: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemFilesResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemTestCases: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemTestCasesResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemFile: {
+    parameters: {
+      query: {
+        /** @description Path of the file as listed by /problems/{name}/files (e.g. grader/solve.hpp, common/fastio.h). */
+        path: string;
+      };
+      header?: never;
+      path: {
+        /** @description Problem identifier. */
+        name: components["parameters"]["ProblemName"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/octet-stream": string;
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getLangList: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["LangListResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getProblemCategories: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["ProblemCategoriesResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  postSubmit: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody: {
+      content: {
+        "application/json": components["schemas"]["SubmitRequest"];
+      };
+    };
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["SubmitResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getSubmissionList: {
+    parameters: {
+      query?: {
+        /** @description Number of submissions to skip before collecting results. */
+        skip?: components["parameters"]["SubmissionSkip"];
+        /** @description Maximum number of submissions to return (1-1000). */
+        limit?: components["parameters"]["SubmissionLimit"];
+        problem?: components["schemas"]["ProblemName"];
+        status?: string;
+        hacked?: boolean;
+        user?: components["schemas"]["Username"];
+        dedupUser?: boolean;
+        lang?: string;
+        order?: components["schemas"]["SubmissionOrder"];
+        /** @description Only submissions at or after this time. */
+        since?: string;
+        /** @description Only submissions before this time. */
+        until?: string;
+        /** @description Only submissions judged (or not judged) with the current test cases. */
+        is_latest?: boolean;
+        /** @description next_cursor of the previous page. Pages are fetched by keyset instead
+         *     of skip, which stays fast on deep pages. Cannot be used with skip.
+         *      */
+        cursor?: string;
+        /** @description Whether to count the matched submissions (default true). Counting is slow on large results. */
+        with_count?: boolean;
+      };
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["SubmissionListResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getSubmissionInfo: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Submission identifier. */
+        id: components["parameters"]["SubmissionId"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["SubmissionInfoResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getJudgeRunDiff: {
+    parameters: {
+      query: {
+        base: number;
+        target: number;
+      };
+      header?: never;
+      path: {
+        /** @description Submission identifier. */
+        id: components["parameters"]["SubmissionId"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["JudgeRunDiffResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getSubmissionEvents: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Submission identifier. */
+        id: components["parameters"]["SubmissionId"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "text/event-stream": string;
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  postRejudge: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Submission identifier. */
+        id: components["parameters"]["SubmissionId"];
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["RejudgeResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getHackList: {
     parameters: {
       query?: {
-        /** @description Number of ranking records to skip before collecting results. */
-        skip?: components["parameters"]["RankingSkip"];
-        /** @description Maximum number of ranking records to return (1-1000). */
-        limit?: components["parameters"]["RankingLimit"];
+        /** @description Number of hacks to skip before collecting results. */
+        skip?: components["parameters"]["HackSkip"];
+        /** @description Maximum number of hacks to return (1-1000). */
+        limit?: components["parameters"]["HackLimit"];
+        user?: string;
+        status?: string;
+        order?: string;
       };
       header?: never;
       path?: never;
@@ -525,18 +1677,47 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["RankingResponse"];
+          "application/json": components["schemas"]["HackListResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getMonitoring: {
+  postHack: {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
+    requestBody: {
+      content: {
+        "application/json": components["schemas"]["CreateHackRequest"];
+      };
+    };
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["HackResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getHackInfo: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description Hack identifier. */
+        id: components["parameters"]["HackId"];
+      };
+      cookie?: never;
+    };
     requestBody?: never;
     responses: {
       /** @description OK */
@@ -545,16 +1726,20 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["MonitoringResponse"];
+          "application/json": components["schemas"]["HackInfoResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getProblems: {
+  getHackEvents: {
     parameters: {
       query?: never;
       header?: never;
-      path?: never;
+      path: {
+        /** @description Hack identifier. */
+        id: components["parameters"]["HackId"];
+      };
       cookie?: never;
     };
     requestBody?: never;
@@ -565,18 +1750,19 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["ProblemListResponse"];
+          "text/event-stream": string;
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getProblemInfo: {
+  postHackRejudge: {
     parameters: {
       query?: never;
       header?: never;
       path: {
-        /** @description Problem identifier. */
-        name: components["parameters"]["ProblemName"];
+        /** @description Hack identifier. */
+        id: components["parameters"]["HackId"];
       };
       cookie?: never;
     };
@@ -588,19 +1774,24 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["ProblemInfoResponse"];
+          "application/json": components["schemas"]["HackRejudgeResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getLangList: {
+  postRegister: {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    requestBody?: never;
+    requestBody: {
+      content: {
+        "application/json": components["schemas"]["RegisterRequest"];
+      };
+    };
     responses: {
       /** @description OK */
       200: {
@@ -608,12 +1799,13 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["LangListResponse"];
+          "application/json": components["schemas"]["RegisterResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getProblemCategories: {
+  getCurrentUserInfo: {
     parameters: {
       query?: never;
       header?: never;
@@ -628,12 +1820,13 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["ProblemCategoriesResponse"];
+          "application/json": components["schemas"]["CurrentUserInfoResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  postSubmit: {
+  patchCurrentUserInfo: {
     parameters: {
       query?: never;
       header?: never;
@@ -642,7 +1835,7 @@ export interface operations {
     };
     requestBody: {
       content: {
-        "application/json": components["schemas"]["SubmitRequest"];
+        "application/json": components["schemas"]["ChangeCurrentUserInfoRequest"];
       };
     };
     responses: {
@@ -652,26 +1845,15 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["SubmitResponse"];
+          "application/json": components["schemas"]["ChangeCurrentUserInfoResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getSubmissionList: {
+  getAPITokens: {
     parameters: {
-      query?: {
-        /** @description Number of submissions to skip before collecting results. */
-        skip?: components["parameters"]["SubmissionSkip"];
-        /** @description Maximum number of submissions to return (1-1000). */
-        limit?: components["parameters"]["SubmissionLimit"];
-        problem?: components["schemas"]["ProblemName"];
-        status?: string;
-        hacked?: boolean;
-        user?: components["schemas"]["Username"];
-        dedupUser?: boolean;
-        lang?: string;
-        order?: components["schemas"]["SubmissionOrder"];
-      };
+      query?: never;
       header?: never;
       path?: never;
       cookie?: never;
@@ -684,22 +1866,24 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["SubmissionListResponse"];
+          "application/json": components["schemas"]["APITokenListResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getSubmissionInfo: {
+  postAPIToken: {
     parameters: {
       query?: never;
       header?: never;
-      path: {
-        /** @description Submission identifier. */
-        id: components["parameters"]["SubmissionId"];
-      };
+      path?: never;
       cookie?: never;
     };
-    requestBody?: never;
+    requestBody: {
+      content: {
+        "application/json": components["schemas"]["CreateAPITokenRequest"];
+      };
+    };
     responses: {
       /** @description OK */
       200: {
@@ -707,18 +1891,18 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["SubmissionInfoResponse"];
+          "application/json": components["schemas"]["CreateAPITokenResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  postRejudge: {
+  deleteAPIToken: {
     parameters: {
       query?: never;
       header?: never;
       path: {
-        /** @description Submission identifier. */
-        id: components["parameters"]["SubmissionId"];
+        id: number;
       };
       cookie?: never;
     };
@@ -730,22 +1914,15 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["RejudgeResponse"];
+          "application/json": components["schemas"]["RevokeAPITokenResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getHackList: {
+  getWebhooks: {
     parameters: {
-      query?: {
-        /** @description Number of hacks to skip before collecting results. */
-        skip?: components["parameters"]["HackSkip"];
-        /** @description Maximum number of hacks to return (1-1000). */
-        limit?: components["parameters"]["HackLimit"];
-        user?: string;
-        status?: string;
-        order?: string;
-      };
+      query?: never;
       header?: never;
       path?: never;
       cookie?: never;
@@ -758,12 +1935,13 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["HackListResponse"];
+          "application/json": components["schemas"]["WebhookListResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  postHack: {
+  postWebhook: {
     parameters: {
       query?: never;
       header?: never;
@@ -772,7 +1950,7 @@ export interface operations {
     };
     requestBody: {
       content: {
-        "application/json": components["schemas"]["CreateHackRequest"];
+        "application/json": components["schemas"]["CreateWebhookRequest"];
       };
     };
     responses: {
@@ -782,18 +1960,18 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["HackResponse"];
+          "application/json": components["schemas"]["CreateWebhookResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getHackInfo: {
+  deleteWebhook: {
     parameters: {
       query?: never;
       header?: never;
       path: {
-        /** @description Hack identifier. */
-        id: components["parameters"]["HackId"];
+        id: number;
       };
       cookie?: never;
     };
@@ -805,12 +1983,88 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["HackInfoResponse"];
+          "application/json": components["schemas"]["DeleteWebhookResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  postRegister: {
+  getWebhookDeliveries: {
+    parameters: {
+      query?: {
+        /** @description Maximum number of deliveries to return (1-100). */
+        limit?: number;
+      };
+      header?: never;
+      path: {
+        id: number;
+      };
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["WebhookDeliveryListResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  getAdminUsers: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["AdminUserListResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  putUserRole: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path: {
+        /** @description User identifier. */
+        name: components["parameters"]["UserNamePath"];
+      };
+      cookie?: never;
+    };
+    requestBody: {
+      content: {
+        "application/json": components["schemas"]["UpdateUserRoleRequest"];
+      };
+    };
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["UpdateUserRoleResponse"];
+        };
+      };
+      default: components["responses"]["Error"];
+    };
+  };
+  postBulkRejudge: {
     parameters: {
       query?: never;
       header?: never;
@@ -819,7 +2073,7 @@ export interface operations {
     };
     requestBody: {
       content: {
-        "application/json": components["schemas"]["RegisterRequest"];
+        "application/json": components["schemas"]["BulkRejudgeRequest"];
       };
     };
     responses: {
@@ -829,12 +2083,13 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["RegisterResponse"];
+          "application/json": components["schemas"]["BulkRejudgeResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  getCurrentUserInfo: {
+  getBulkRejudgeJobs: {
     parameters: {
       query?: never;
       header?: never;
@@ -849,23 +2104,43 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["CurrentUserInfoResponse"];
+          "application/json": components["schemas"]["BulkRejudgeJobListResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
-  patchCurrentUserInfo: {
+  cancelBulkRejudgeJob: {
     parameters: {
       query?: never;
       header?: never;
-      path?: never;
+      path: {
+        id: number;
+      };
       cookie?: never;
     };
-    requestBody: {
-      content: {
-        "application/json": components["schemas"]["ChangeCurrentUserInfoRequest"];
+    requestBody?: never;
+    responses: {
+      /** @description OK */
+      200: {
+        headers: {
+          [name: string]: unknown;
+        };
+        content: {
+          "application/json": components["schemas"]["BulkRejudgeJob"];
+        };
       };
+      default: components["responses"]["Error"];
+    };
+  };
+  getAutoRejudgeProgress: {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
     };
+    requestBody?: never;
     responses: {
       /** @description OK */
       200: {
@@ -873,9 +2148,10 @@ export interface operations {
           [name: string]: unknown;
         };
         content: {
-          "application/json": components["schemas"]["ChangeCurrentUserInfoResponse"];
+          "application/json": components["schemas"]["AutoRejudgeProgressListResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
   getUserInfo: {
@@ -899,6 +2175,7 @@ export interface operations {
           "application/json": components["schemas"]["UserInfoResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
   getUserStatistics: {
@@ -922,6 +2199,7 @@ export interface operations {
           "application/json": components["schemas"]["UserSolvedStatisticsResponse"];
         };
       };
+      default: components["responses"]["Error"];
     };
   };
 }
@@ -14,8 +14,7 @@ import {
   useCurrentUser,
 } from "../api/client_wrapper";
 import { LibraryBooks } from "@mui/icons-material";
-import { Alert, Container, Divider, FormLabel, Switch } from "@mui/material";
-import BuildIcon from "@mui/icons-material/Build";
+import { Alert, Container, Divider } from "@mui/material";
 import { useCurrentAuthUser, useUpdateEmailMutation } from "../auth/auth";
 import { User as AuthUser } from "firebase/auth";
 import EmailIcon from "@mui/icons-material/Email";
@@ -133,7 +132,6 @@ const GeneralSetting: React.FC<{ user: User }> = (props) => {
   const { user } = props;
 
   const [libraryURL, setLibraryURL] = useState(user.libraryUrl);
-  const [isDeveloper, setIsDeveloper] = useState(user.isDeveloper);
 
   const mutation = useChangeCurrentUserInfoMutation();
 
@@ -143,7 +141,7 @@ const GeneralSetting: React.FC<{ user: User }> = (props) => {
       user: {
         name: user.name,
         libraryUrl: libraryURL,
-        isDeveloper: isDeveloper,
+        isDeveloper: user.isDeveloper,
       },
     });
   };
@@ -174,21 +172,6 @@ const GeneralSetting: React.FC<{ user: User }> = (props) => {
               }
             />
           </ListItem>
-          <ListItem>
-            <ListItemAvatar>
-              <Avatar>
-                <BuildIcon />
-              </Avatar>
-            </ListItemAvatar>
-            <FormLabel id="is-developer-mode-switch">Developer Mode</FormLabel>
-            <Switch
-              aria-labelledby="is-developer-mode-switch"
-              checked={isDeveloper}
-              onChange={(e) => {
-                setIsDeveloper(e.target.checked);
-              }}
-            />
-          </ListItem>
         </List>
         <Button color="primary" type="submit">
           Update
@@ -31,16 +31,12 @@ func execHackTask(db *gorm.DB, downloader storage.TestCaseDownloader, taskID int
 	if !ok {
 		return fmt.Errorf("unknown language: %v", lang)
 	}
-	p := storage.Problem{
-		Name:            s.Problem.Name,
-		Version:         s.Problem.Version,
-		OverallVersion:  s.Problem.OverallVersion,
-		TestCaseVersion: s.Problem.TestCasesVersion,
-	}
+	p := toStorageProblem(s.Problem)
 	files, err := downloader.Fetch(p)
 	if err != nil {
 		return err
 	}
+	defer files.Release()
 
 	info, err := storage.ParseInfo(files.InfoTomlPath())
 	if err != nil {
@@ -59,12 +55,22 @@ func execHackTask(db *gorm.DB, downloader storage.TestCaseDownloader, taskID int
 		if err := data.updateHack(); err != nil {
 			slog.Error("Deep error", "taskID", taskID, "err", err)
 		}
+		// the task is popped again, so IE is not final
 		return err
 	}
 
+	data.enqueueWebhooks()
 	return nil
 }
 
+// enqueueWebhooks queues the notifications of the final status. Failures are
+// only logged because the result is already saved.
+func (data *HackTaskData) enqueueWebhooks() {
+	if err := database.EnqueueHackWebhooks(data.task.db, data.h); err != nil {
+		slog.Error("Failed to enqueue webhooks", "hackID", data.h.ID, "err", err)
+	}
+}
+
 type HackTaskData struct {
 	task  TaskData
 	files storage.ProblemFiles
@@ -13,9 +13,28 @@ import (
 
 const POOLING_PERIOD = 3 * time.Second
 
+// judgeName identifies this worker in judge run history
+var judgeName string
+
 func main() {
+	policy := flag.String("policy", "fair", "task pop policy (fair or sjf)")
+	hostname, _ := os.Hostname()
+	flag.StringVar(&judgeName, "name", hostname, "name of this judge recorded in judge runs")
+	prefetchLookahead := flag.Int("prefetch", 4, "number of queued tasks whose test cases are prefetched (0 to disable)")
+	prefetchConcurrency := flag.Int("prefetch-concurrency", 2, "max concurrent prefetch downloads")
+	prefetchBandwidth := flag.Int("prefetch-bandwidth", 50<<20, "max total bandwidth of prefetch downloads in bytes/sec (0 for unlimited)")
 	flag.Parse()
 
+	popPolicy := database.PopFairShare
+	switch *policy {
+	case "fair":
+	case "sjf":
+		popPolicy = database.PopShortestJobFirst
+	default:
+		slog.Error("Unknown policy", "policy", *policy)
+		os.Exit(1)
+	}
+
 	// connect db
 	db := database.Connect(database.GetDSNFromEnv(), false)
 
@@ -24,16 +43,26 @@ func main() {
 		slog.Error("Failed to connect to storage", "err", err)
 		os.Exit(1)
 	}
-	downloader, err := storage.NewTestCaseDownloader(storageClient)
+	downloaderConfig, err := storage.GetDownloaderConfigFromEnv()
+	if err != nil {
+		slog.Error("Failed to read test case cache config", "err", err)
+		os.Exit(1)
+	}
+	downloader, err := storage.NewTestCaseDownloaderWithConfig(storageClient, downloaderConfig)
 	if err != nil {
 		slog.Error("Failed to create TestCaseDownloader", "err", err)
 		os.Exit(1)
 	}
 	defer func() { _ = downloader.Close() }()
 
+	if *prefetchLookahead > 0 {
+		p := newPrefetcher(db, downloader, popPolicy, *prefetchLookahead, *prefetchConcurrency, *prefetchBandwidth)
+		go p.run(context.Background(), POOLING_PERIOD)
+	}
+
 	slog.Info("Start pooling")
 	for {
-		taskID, taskData, err := database.PopTask(db)
+		taskID, taskData, err := database.PopTaskWithPolicy(db, popPolicy)
 		if err != nil {
 			slog.Error("PopJudgeTask failed", "err", err)
 			time.Sleep(POOLING_PERIOD)
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"github.com/yosupo06/library-checker-judge/storage"
+	"gorm.io/gorm"
+)
+
+// prefetcher downloads the files of the tasks at the head of the queue in the
+// background, so that the judge finds them in the cache when it pops them.
+type prefetcher struct {
+	db         *gorm.DB
+	downloader storage.TestCaseDownloader
+	policy     database.PopPolicy
+	// lookahead is the number of queued tasks to peek
+	lookahead int
+	// slots limits the concurrent downloads
+	slots chan struct{}
+
+	mu       sync.Mutex
+	inFlight map[storage.Problem]bool
+	wg       sync.WaitGroup
+}
+
+// newPrefetcher returns a prefetcher running at most concurrency downloads,
+// limited to bandwidth bytes/sec in total (0 means unlimited).
+func newPrefetcher(db *gorm.DB, downloader storage.TestCaseDownloader, policy database.PopPolicy, lookahead, concurrency, bandwidth int) *prefetcher {
+	return &prefetcher{
+		db:         db,
+		downloader: downloader.WithBandwidthLimit(bandwidth),
+		policy:     policy,
+		lookahead:  lookahead,
+		slots:      make(chan struct{}, max(concurrency, 1)),
+		inFlight:   map[storage.Problem]bool{},
+	}
+}
+
+func (p *prefetcher) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.prefetch()
+		select {
+		case <-ctx.Done():
+			p.wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prefetch starts downloading the problems of the next tasks, as long as
+// there are free slots.
+func (p *prefetcher) prefetch() {
+	tasks, err := database.PeekTasksWithPolicy(p.db, p.policy, p.lookahead)
+	if err != nil {
+		slog.Warn("Failed to peek tasks", "err", err)
+		return
+	}
+	for _, task := range tasks {
+		problem, err := taskProblem(p.db, task)
+		if err != nil {
+			slog.Warn("Failed to fetch problem of task", "err", err)
+			continue
+		}
+		if !p.start(problem) {
+			continue
+		}
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			p.finish(problem)
+			return
+		}
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			defer func() { <-p.slots }()
+			defer p.finish(problem)
+
+			start := time.Now()
+			files, err := p.downloader.Fetch(problem)
+			if err != nil {
+				slog.Warn("Failed to prefetch", "name", problem.Name, "err", err)
+				return
+			}
+			files.Release()
+			slog.Info("Prefetched", "name", problem.Name, "hash", problem.TestCaseVersion, "elapsed", time.Since(start))
+		}()
+	}
+}
+
+// start marks problem as in flight, unless it already is.
+func (p *prefetcher) start(problem storage.Problem) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.inFlight[problem] {
+		return false
+	}
+	p.inFlight[problem] = true
+	return true
+}
+
+func (p *prefetcher) finish(problem storage.Problem) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.inFlight, problem)
+}
+
+// taskProblem returns the problem which the task is judged on.
+func taskProblem(db *gorm.DB, task database.TaskData) (storage.Problem, error) {
+	var submissionID int32
+	switch data := task.Data.(type) {
+	case database.SubmissionData:
+		submissionID = data.ID
+	case database.HackData:
+		hack, err := database.FetchHack(db, data.ID)
+		if err != nil {
+			return storage.Problem{}, err
+		}
+		submissionID = hack.SubmissionID
+	default:
+		return storage.Problem{}, fmt.Errorf("unknown task type: %v", task.TaskType)
+	}
+	s, err := database.FetchSubmission(db, submissionID)
+	if err != nil {
+		return storage.Problem{}, err
+	}
+	return toStorageProblem(s.Problem), nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"github.com/yosupo06/library-checker-judge/storage"
+)
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+	db := database.CreateTestDB(t)
+	dir := t.TempDir()
+
+	problem := database.Problem{Name: "aplusb", Title: "A + B", Version: "v", OverallVersion: "ov", TestCasesVersion: "tv"}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatal(err)
+	}
+	id, err := database.SaveSubmission(db, database.Submission{ProblemName: "aplusb", Lang: "cpp", Status: "WJ", Source: "int main(){}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.PushSubmissionTask(db, database.SubmissionData{ID: id}, 50); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: "in/example_00.in", Mode: 0o644, Size: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("1 2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	client := storage.NewClient(storage.NewLocalBucket(path.Join(dir, "private")), storage.NewLocalBucket(path.Join(dir, "public")))
+	if err := client.Bucket().Put(ctx, "v4/testcase/aplusb/tv.tar.gz", bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PublicBucket().Put(ctx, "v4/files/aplusb/ov/aplusb/checker.cpp", strings.NewReader("checker")); err != nil {
+		t.Fatal(err)
+	}
+
+	config := storage.DownloaderConfig{CacheDir: path.Join(dir, "cache")}
+	downloader, err := storage.NewTestCaseDownloaderWithConfig(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+
+	p := newPrefetcher(db, downloader, database.PopFairShare, 4, 2, 1<<20)
+	p.prefetch()
+	p.wg.Wait()
+
+	if count, err := database.CountTasks(db); err != nil || count != 1 {
+		t.Fatal("task is popped:", count, err)
+	}
+
+	// the judge finds the files in the cache without the buckets
+	empty := storage.NewClient(storage.NewLocalBucket(path.Join(dir, "none")), storage.NewLocalBucket(path.Join(dir, "none")))
+	cached, err := storage.NewTestCaseDownloaderWithConfig(empty, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cached.Close() }()
+	files, err := cached.Fetch(toStorageProblem(problem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"log/slog"
@@ -29,16 +30,12 @@ func execSubmissionTask(db *gorm.DB, downloader storage.TestCaseDownloader, task
 		return fmt.Errorf("unknown language: %v", s.Lang)
 	}
 
-	problem := storage.Problem{
-		Name:            s.Problem.Name,
-		Version:         s.Problem.Version,
-		OverallVersion:  s.Problem.OverallVersion,
-		TestCaseVersion: s.Problem.TestCasesVersion,
-	}
+	problem := toStorageProblem(s.Problem)
 	files, err := downloader.Fetch(problem)
 	if err != nil {
 		return err
 	}
+	defer files.Release()
 	data := SubmissionTaskData{
 		task:           NewTaskData(db, taskID),
 		files:          files,
@@ -55,9 +52,17 @@ func execSubmissionTask(db *gorm.DB, downloader storage.TestCaseDownloader, task
 		if err := data.updateSubmission(); err != nil {
 			slog.Error("Deep error", "taskID", taskID, "err", err)
 		}
+		if err := data.finishRun(); err != nil {
+			slog.Error("Deep error", "taskID", taskID, "err", err)
+		}
+		// the task is popped again, so IE is not final
 		return err
 	}
 
+	if err := data.finishRun(); err != nil {
+		return err
+	}
+	data.enqueueWebhooks()
 	return nil
 }
 
@@ -67,6 +72,7 @@ type SubmissionTaskData struct {
 	s              database.Submission
 	submissionData database.SubmissionData
 	lang           langs.Lang
+	run            database.JudgeRun
 	results        []database.SubmissionTestcaseResult
 	resultsToSave  []database.SubmissionTestcaseResult
 	lastUpdate     time.Time
@@ -80,10 +86,24 @@ func (data *SubmissionTaskData) init() error {
 	}
 	data.info = info
 
+	data.run = database.JudgeRun{
+		SubmissionID:     data.s.ID,
+		TestCasesVersion: data.s.Problem.TestCasesVersion,
+		Judge:            judgeName,
+		Status:           "-",
+		StartTime:        time.Now(),
+	}
+	runID, err := database.SaveJudgeRun(data.task.db, data.run)
+	if err != nil {
+		return err
+	}
+	data.run.ID = runID
+
 	testCases := data.info.TestCaseNames()
 	data.results = make([]database.SubmissionTestcaseResult, len(testCases))
 	for i := range data.results {
 		data.results[i].Submission = data.s.ID
+		data.results[i].RunID = runID
 		data.results[i].Testcase = testCases[i]
 		data.results[i].Status = "-"
 		data.results[i].DisplayOrder = int32(i)
@@ -99,9 +119,6 @@ func (data *SubmissionTaskData) init() error {
 	if err := data.updateSubmission(); err != nil {
 		return err
 	}
-	if err := database.ClearTestcaseResult(data.task.db, data.s.ID); err != nil {
-		return err
-	}
 	if err := database.SaveTestcaseResults(data.task.db, data.results); err != nil {
 		return err
 	}
@@ -150,6 +167,9 @@ func (data *SubmissionTaskData) judge() error {
 		data.s.CompileError = taskResult.Stderr
 		return data.updateSubmission()
 	}
+	if err := database.RecordCompileTime(data.task.db, data.lang.ID, taskResult.Time); err != nil {
+		slog.Warn("Failed to record compile time", "lang", data.lang.ID, "err", err)
+	}
 
 	slog.Info("Start executing")
 	testCaseNum := len(data.results)
@@ -243,6 +263,23 @@ func (data *SubmissionTaskData) updateSubmission() error {
 	return nil
 }
 
+// enqueueWebhooks queues the notifications of the final status. Failures are
+// only logged because the verdict is already saved.
+func (data *SubmissionTaskData) enqueueWebhooks() {
+	if err := database.EnqueueSubmissionWebhooks(data.task.db, data.s); err != nil {
+		slog.Error("Failed to enqueue webhooks", "submissionID", data.s.ID, "err", err)
+	}
+}
+
+// finishRun records the final state of the submission to its judge run.
+func (data *SubmissionTaskData) finishRun() error {
+	data.run.Status = data.s.Status
+	data.run.MaxTime = data.s.MaxTime
+	data.run.MaxMemory = data.s.MaxMemory
+	data.run.EndTime = sql.NullTime{Time: time.Now(), Valid: true}
+	return database.UpdateJudgeRun(data.task.db, data.run)
+}
+
 func (data *SubmissionTaskData) compileSource() (executor.Volume, executor.TaskResult, error) {
 	// write source to tempfile
 	sourceDir, err := os.MkdirTemp("", "source")
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/yosupo06/library-checker-judge/database"
+	"github.com/yosupo06/library-checker-judge/storage"
 	"gorm.io/gorm"
 )
 
@@ -32,3 +33,12 @@ func (t *TaskData) TouchIfNeeded() error {
 	}
 	return nil
 }
+
+func toStorageProblem(p database.Problem) storage.Problem {
+	return storage.Problem{
+		Name:            p.Name,
+		Version:         p.Version,
+		OverallVersion:  p.OverallVersion,
+		TestCaseVersion: p.TestCasesVersion,
+	}
+}
@@ -1,15 +1,61 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log/slog"
+	"os"
+	"time"
 
 	"github.com/yosupo06/library-checker-judge/database"
+	"gorm.io/gorm"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "print SQL of migrations instead of applying them")
+	steps := flag.Int("steps", 1, "number of migrations reverted by down")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [up|down|status]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cmd := "up"
+	if flag.NArg() > 0 {
+		cmd = flag.Arg(0)
+	}
+
 	db := database.Connect(database.GetDSNFromEnv(), false)
 
-	if err := database.AutoMigrate(db); err != nil {
-		slog.Error("Migration failed:", slog.Any("err", err))
+	var err error
+	switch cmd {
+	case "up":
+		err = database.MigrateUp(db, *dryRun, os.Stdout)
+	case "down":
+		err = database.MigrateDown(db, *steps, *dryRun, os.Stdout)
+	case "status":
+		err = printStatus(db)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		slog.Error("Migration failed", "cmd", cmd, "err", err)
+		os.Exit(1)
+	}
+}
+
+func printStatus(db *gorm.DB) error {
+	statuses, err := database.FetchMigrationStatus(db)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		applied := "pending"
+		if s.Applied {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, applied)
 	}
+	return nil
 }
@@ -2,13 +2,34 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"log/slog"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	fbAuth "firebase.google.com/go/v4/auth"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
 )
 
+// apiTokenPrefix distinguishes personal API tokens from Firebase ID tokens.
+const apiTokenPrefix = "lcp_"
+
+// apiTokenOperations lists the operations usable with a personal API token and
+// the scope each requires. An empty scope accepts any token.
+var apiTokenOperations = map[string]restapi.APITokenScope{
+	"GetCurrentUserInfo": "",
+	"PostSubmit":         restapi.Submit,
+	"PostRejudge":        restapi.Submit,
+	"PostHack":           restapi.Hack,
+}
+
 // AuthClient provides minimal interface for verifying ID tokens.
 type AuthClient interface {
 	parseUID(ctx context.Context, token string) string
@@ -42,11 +63,16 @@ func parseBearerToken(r *http.Request) string {
 	return ""
 }
 
-func (s *server) uidFromRequest(r *http.Request) (string, error) {
+// uidFromRequest returns the uid of the bearer token of r. operation is the
+// OpenAPI operation being served, which decides whether API tokens are accepted.
+func (s *server) uidFromRequest(r *http.Request, operation string) (string, error) {
 	token := parseBearerToken(r)
 	if token == "" {
 		return "", errors.New("no bearer token")
 	}
+	if strings.HasPrefix(token, apiTokenPrefix) {
+		return s.uidFromAPIToken(token, operation)
+	}
 	if s.authClient == nil {
 		return "", errors.New("auth client not configured")
 	}
@@ -62,5 +88,45 @@ func (s *server) uidFromContext(ctx context.Context) (string, error) {
 	if !ok {
 		return "", errors.New("request not found in context")
 	}
-	return s.uidFromRequest(r)
+	return s.uidFromRequest(r, operationFromContext(ctx))
+}
+
+func (s *server) uidFromAPIToken(secret string, operation string) (string, error) {
+	scope, ok := apiTokenOperations[operation]
+	if !ok {
+		return "", errors.New("api token is not allowed for this operation")
+	}
+	token, err := database.FetchAPITokenFromHash(s.db, hashAPIToken(secret))
+	if err != nil {
+		return "", errors.New("invalid token")
+	}
+	now := time.Now()
+	if !token.Active(now) {
+		return "", errors.New("token is revoked or expired")
+	}
+	if scope != "" && !slices.Contains(strings.Fields(token.Scopes), string(scope)) {
+		return "", errors.New("token does not have the required scope")
+	}
+	user, err := database.FetchUserFromName(s.db, token.UserName)
+	if err != nil || user == nil {
+		return "", errors.New("token owner not found")
+	}
+	if err := database.TouchAPIToken(s.db, token.ID, now); err != nil {
+		slog.Warn("failed to update last use of api token", "id", token.ID, "error", err)
+	}
+	return user.UID, nil
+}
+
+// newAPITokenSecret returns a random token. Only its hash is stored.
+func newAPITokenSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
 }
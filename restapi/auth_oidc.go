@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	// jwksRefreshInterval is how long fetched keys are trusted before refetching.
+	jwksRefreshInterval = time.Hour
+	// jwksMinRefreshInterval limits refetches triggered by unknown key ids.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// asymmetricAlgorithms are accepted for tokens verified with a JWKS.
+var asymmetricAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// keySource returns the verification keys matching kid.
+type keySource interface {
+	keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error)
+}
+
+// OIDCAuthClient implements AuthClient by validating JWTs issued by an
+// OpenID Connect provider (or signed with a static key for local runs).
+type OIDCAuthClient struct {
+	issuer     string // empty skips the iss check (static key mode only)
+	audience   string // empty skips the aud check
+	uidClaim   string
+	algorithms []jose.SignatureAlgorithm
+	keySource  keySource
+	now        func() time.Time
+}
+
+func (c *OIDCAuthClient) parseUID(ctx context.Context, token string) string {
+	if c == nil || token == "" {
+		return ""
+	}
+	uid, err := c.verify(ctx, token)
+	if err != nil {
+		slog.Debug("verify jwt failed", "error", err)
+		return ""
+	}
+	return uid
+}
+
+func (c *OIDCAuthClient) verify(ctx context.Context, token string) (string, error) {
+	tok, err := jwt.ParseSigned(token, c.algorithms)
+	if err != nil {
+		return "", err
+	}
+	kid := ""
+	if len(tok.Headers) > 0 {
+		kid = tok.Headers[0].KeyID
+	}
+	keys, err := c.keySource.keys(ctx, kid)
+	if err != nil {
+		return "", err
+	}
+
+	var claims jwt.Claims
+	var raw map[string]interface{}
+	verified := false
+	for _, key := range keys {
+		if err := tok.Claims(key.Key, &claims, &raw); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return "", errors.New("invalid signature")
+	}
+
+	if claims.Expiry == nil {
+		return "", errors.New("exp claim is required")
+	}
+	expected := jwt.Expected{Issuer: c.issuer, Time: c.now()}
+	if c.audience != "" {
+		expected.AnyAudience = jwt.Audience{c.audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return "", err
+	}
+
+	uid, _ := raw[c.uidClaim].(string)
+	if uid == "" {
+		return "", fmt.Errorf("claim %q is empty", c.uidClaim)
+	}
+	return uid, nil
+}
+
+// staticKeys is a fixed key set, loaded from a file or given directly.
+type staticKeys struct {
+	set jose.JSONWebKeySet
+}
+
+func (s *staticKeys) keys(_ context.Context, kid string) ([]jose.JSONWebKey, error) {
+	return selectKeys(s.set, kid)
+}
+
+// remoteJWKS fetches a key set from url and caches it.
+type remoteJWKS struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	set       jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func (r *remoteJWKS) keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	age := time.Since(r.fetchedAt)
+	if r.fetchedAt.IsZero() || age > jwksRefreshInterval {
+		if err := r.fetch(ctx); err != nil {
+			return nil, err
+		}
+		return selectKeys(r.set, kid)
+	}
+	keys, err := selectKeys(r.set, kid)
+	if err != nil && age > jwksMinRefreshInterval {
+		// the provider may have rotated its keys
+		if err := r.fetch(ctx); err != nil {
+			return nil, err
+		}
+		return selectKeys(r.set, kid)
+	}
+	return keys, err
+}
+
+func (r *remoteJWKS) fetch(ctx context.Context) error {
+	set := jose.JSONWebKeySet{}
+	if err := getJSON(ctx, r.client, r.url, &set); err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	r.set = set
+	r.fetchedAt = time.Now()
+	return nil
+}
+
+// selectKeys returns the keys matching kid. A set with a single key without
+// kid matches any token.
+func selectKeys(set jose.JSONWebKeySet, kid string) ([]jose.JSONWebKey, error) {
+	if len(set.Keys) == 1 && set.Keys[0].KeyID == "" {
+		return set.Keys, nil
+	}
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+	keys := set.Key(kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("unknown kid: %s", kid)
+	}
+	return keys, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// oidcConfig is the configuration of OIDCAuthClient.
+type oidcConfig struct {
+	Issuer   string
+	Audience string
+	UIDClaim string // default: sub
+	JWKSURL  string // if both JWKSURL and JWKSFile are empty, the issuer's discovery document is used
+	JWKSFile string
+}
+
+func newOIDCAuthClient(ctx context.Context, cfg oidcConfig) (*OIDCAuthClient, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("issuer must be set")
+	}
+	c := &OIDCAuthClient{
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		uidClaim:   cfg.UIDClaim,
+		algorithms: asymmetricAlgorithms,
+		now:        time.Now,
+	}
+	if c.uidClaim == "" {
+		c.uidClaim = "sub"
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	switch {
+	case cfg.JWKSFile != "":
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		set := jose.JSONWebKeySet{}
+		if err := json.Unmarshal(data, &set); err != nil {
+			return nil, fmt.Errorf("parse jwks file: %w", err)
+		}
+		c.keySource = &staticKeys{set: set}
+	case cfg.JWKSURL != "":
+		c.keySource = &remoteJWKS{url: cfg.JWKSURL, client: client}
+	default:
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		url := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := getJSON(ctx, client, url, &discovery); err != nil {
+			return nil, fmt.Errorf("oidc discovery: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("oidc discovery: jwks_uri is empty")
+		}
+		c.keySource = &remoteJWKS{url: discovery.JWKSURI, client: client}
+	}
+	return c, nil
+}
+
+// newStaticKeyAuthClient returns a client accepting HS256 tokens signed with
+// key. It is intended for local runs without an identity provider.
+func newStaticKeyAuthClient(key []byte, issuer string) (*OIDCAuthClient, error) {
+	if len(key) < 32 {
+		return nil, errors.New("static key must be at least 32 bytes")
+	}
+	return &OIDCAuthClient{
+		issuer:     issuer,
+		uidClaim:   "sub",
+		algorithms: []jose.SignatureAlgorithm{jose.HS256},
+		keySource: &staticKeys{set: jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: key, Algorithm: string(jose.HS256)}},
+		}},
+		now: time.Now,
+	}, nil
+}
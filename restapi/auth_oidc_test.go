@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const testIssuer = "https://issuer.example.com"
+
+func newTestKey(t *testing.T, kid string) jose.JSONWebKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return jose.JSONWebKey{Key: priv, KeyID: kid, Algorithm: string(jose.ES256), Use: "sig"}
+}
+
+func signTestToken(t *testing.T, key jose.JSONWebKey, alg jose.SignatureAlgorithm, claims interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return token
+}
+
+func testClaims(issuer, subject string, expiry time.Time) jwt.Claims {
+	return jwt.Claims{
+		Issuer:   issuer,
+		Subject:  subject,
+		Audience: jwt.Audience{"library-checker"},
+		Expiry:   jwt.NewNumericDate(expiry),
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+}
+
+func TestOIDCAuthClient_JWKSURL(t *testing.T) {
+	key1 := newTestKey(t, "k1")
+	key2 := newTestKey(t, "k2")
+	var keys atomic.Pointer[jose.JSONWebKeySet]
+	keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key1.Public()}})
+	var fetches atomic.Int32
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(keys.Load())
+	}))
+	defer jwks.Close()
+
+	c, err := newOIDCAuthClient(context.Background(), oidcConfig{
+		Issuer:   testIssuer,
+		Audience: "library-checker",
+		JWKSURL:  jwks.URL,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+
+	if uid := c.parseUID(ctx, signTestToken(t, key1, jose.ES256, testClaims(testIssuer, "uid-1", future))); uid != "uid-1" {
+		t.Fatalf("expected uid-1, got %q", uid)
+	}
+
+	for name, token := range map[string]string{
+		"expired":      signTestToken(t, key1, jose.ES256, testClaims(testIssuer, "uid-1", time.Now().Add(-time.Hour))),
+		"wrong issuer": signTestToken(t, key1, jose.ES256, testClaims("https://evil.example.com", "uid-1", future)),
+		"wrong audience": signTestToken(t, key1, jose.ES256, jwt.Claims{
+			Issuer: testIssuer, Subject: "uid-1", Audience: jwt.Audience{"other"}, Expiry: jwt.NewNumericDate(future),
+		}),
+		"no expiry":   signTestToken(t, key1, jose.ES256, jwt.Claims{Issuer: testIssuer, Subject: "uid-1", Audience: jwt.Audience{"library-checker"}}),
+		"unknown kid": signTestToken(t, key2, jose.ES256, testClaims(testIssuer, "uid-1", future)),
+		"hmac":        signTestToken(t, jose.JSONWebKey{Key: make([]byte, 32), KeyID: "k1"}, jose.HS256, testClaims(testIssuer, "uid-1", future)),
+		"garbage":     "not-a-jwt",
+	} {
+		if uid := c.parseUID(ctx, token); uid != "" {
+			t.Errorf("%s: expected rejection, got %q", name, uid)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("unknown kid must not refetch within the min interval, fetched %d times", n)
+	}
+
+	// after key rotation, an unknown kid triggers a refetch
+	keys.Store(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key1.Public(), key2.Public()}})
+	c.keySource.(*remoteJWKS).fetchedAt = time.Now().Add(-2 * jwksMinRefreshInterval)
+	if uid := c.parseUID(ctx, signTestToken(t, key2, jose.ES256, testClaims(testIssuer, "uid-2", future))); uid != "uid-2" {
+		t.Fatalf("expected uid-2 after rotation, got %q", uid)
+	}
+}
+
+func TestOIDCAuthClient_DiscoveryAndFile(t *testing.T) {
+	key := newTestKey(t, "k1")
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{key.Public()}}
+
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": ts.URL, "jwks_uri": ts.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(set)
+	})
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+
+	for name, cfg := range map[string]oidcConfig{
+		"discovery": {Issuer: ts.URL, UIDClaim: "email"},
+		"file":      {Issuer: ts.URL, UIDClaim: "email", JWKSFile: path},
+	} {
+		c, err := newOIDCAuthClient(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("%s: new client: %v", name, err)
+		}
+		token := signTestToken(t, key, jose.ES256, map[string]interface{}{
+			"iss":   ts.URL,
+			"sub":   "uid-1",
+			"email": "alice@example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+		if uid := c.parseUID(context.Background(), token); uid != "alice@example.com" {
+			t.Errorf("%s: expected the email claim, got %q", name, uid)
+		}
+	}
+
+	if _, err := newOIDCAuthClient(context.Background(), oidcConfig{}); err == nil {
+		t.Fatal("issuer must be required")
+	}
+}
+
+func TestStaticKeyAuthClient(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	if _, err := newStaticKeyAuthClient(secret[:16], ""); err == nil {
+		t.Fatal("short keys must be rejected")
+	}
+	c, err := newStaticKeyAuthClient(secret, "")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+
+	token := signTestToken(t, jose.JSONWebKey{Key: secret}, jose.HS256, testClaims("local", "dev-user", future))
+	if uid := c.parseUID(ctx, token); uid != "dev-user" {
+		t.Fatalf("expected dev-user, got %q", uid)
+	}
+	other := signTestToken(t, jose.JSONWebKey{Key: []byte("fedcba9876543210fedcba9876543210")}, jose.HS256, testClaims("local", "dev-user", future))
+	if uid := c.parseUID(ctx, other); uid != "" {
+		t.Fatalf("token signed with another key must be rejected, got %q", uid)
+	}
+}
+
+func TestNewAuthClient_Provider(t *testing.T) {
+	t.Setenv("AUTH_PROVIDER", "static")
+	t.Setenv("AUTH_STATIC_KEY", "0123456789abcdef0123456789abcdef")
+	if ac, err := newAuthClient(context.Background()); err != nil {
+		t.Fatalf("static: %v", err)
+	} else if _, ok := ac.(*OIDCAuthClient); !ok {
+		t.Fatalf("static: unexpected client %T", ac)
+	}
+
+	t.Setenv("AUTH_PROVIDER", "firebase")
+	t.Setenv("FIREBASE_PROJECT", "")
+	if _, err := newAuthClient(context.Background()); err == nil {
+		t.Fatal("firebase without FIREBASE_PROJECT must fail")
+	}
+
+	t.Setenv("AUTH_PROVIDER", "unknown")
+	if _, err := newAuthClient(context.Background()); err == nil {
+		t.Fatal("unknown provider must fail")
+	}
+}
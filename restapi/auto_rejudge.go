@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"gorm.io/gorm"
+)
+
+const (
+	// autoRejudgePriority is the lowest priority, behind every other task.
+	autoRejudgePriority          = 0
+	autoRejudgeInterval          = 10 * time.Second
+	defaultAutoRejudgeMaxPending = 50
+)
+
+// autoRejudger periodically enqueues rejudges of AC submissions whose test
+// cases were updated. Its progress is stored per problem in the database, so
+// several servers may run it at the same time.
+type autoRejudger struct {
+	db *gorm.DB
+	// tasks enqueued per interval
+	batch int
+	// nothing is enqueued while the queue has at least this many tasks
+	maxPending int64
+}
+
+func (a *autoRejudger) run(ctx context.Context) {
+	slog.Info("Start auto rejudge", "batch", a.batch, "interval", autoRejudgeInterval, "max_pending", a.maxPending)
+	ticker := time.NewTicker(autoRejudgeInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.step(); err != nil {
+			slog.Error("auto rejudge failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *autoRejudger) step() error {
+	pending, err := database.CountTasks(a.db)
+	if err != nil {
+		return err
+	}
+	if pending >= a.maxPending {
+		return nil
+	}
+	n, err := database.QueueAutoRejudge(a.db, a.batch, autoRejudgePriority)
+	if n > 0 {
+		slog.Info("auto rejudge queued", "count", n)
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func TestAutoRejudger(t *testing.T) {
+	db := setupTestDB(t)
+	createTestSubmission(t, db, "aplusb-auto")
+	a := &autoRejudger{db: db, batch: 10, maxPending: 1}
+	// records the current test cases version
+	if err := a.step(); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := database.SaveSubmission(db, database.Submission{
+			ProblemName:      "aplusb-auto",
+			Lang:             "cpp",
+			Status:           "AC",
+			Source:           "int main() { return 0; }",
+			TestCasesVersion: "v1",
+		}); err != nil {
+			t.Fatalf("save submission: %v", err)
+		}
+	}
+	if err := db.Model(&database.Problem{}).Where("name = ?", "aplusb-auto").Update("test_cases_version", "v2").Error; err != nil {
+		t.Fatalf("update problem: %v", err)
+	}
+
+	if err := a.step(); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	if count, err := database.CountTasks(db); err != nil || count != 2 {
+		t.Fatalf("expected 2 tasks, got %d %v", count, err)
+	}
+
+	if err := database.RegisterUser(db, "admin", "uid-admin"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+	if err := database.UpdateUserRole(db, "admin", database.RoleAdmin); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	rec := doJSON(t, newRouterAs(db, "uid-admin"), http.MethodGet, "/admin/auto_rejudge", "token", nil)
+	var resp restapi.AutoRejudgeProgressListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if len(resp.Progresses) != 1 || resp.Progresses[0].TestCasesVersion != "v2" || resp.Progresses[0].Total != 2 || resp.Progresses[0].Queued != 2 {
+		t.Fatalf("unexpected progress: %s", rec.Body.String())
+	}
+}
+
+func TestAutoRejudger_WaitsForBusyQueue(t *testing.T) {
+	db := setupTestDB(t)
+	id := createTestSubmission(t, db, "aplusb-busy")
+	if err := database.PushSubmissionTask(db, database.SubmissionData{ID: id}, 45); err != nil {
+		t.Fatalf("push task: %v", err)
+	}
+	a := &autoRejudger{db: db, batch: 10, maxPending: 1}
+	if err := a.step(); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	progresses, err := database.FetchAutoRejudgeProgresses(db)
+	if err != nil {
+		t.Fatalf("fetch progress: %v", err)
+	}
+	if len(progresses) != 0 {
+		t.Fatalf("nothing must be done while the queue is busy: %+v", progresses)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+const (
+	bulkRejudgeDefaultPriority = 5
+	bulkRejudgeDefaultRate     = 10
+	bulkRejudgeJobListLimit    = 100
+)
+
+// GetAdminUsers handles GET /admin/users
+func (s *server) GetAdminUsers(ctx context.Context, _ restapi.GetAdminUsersRequestObject) (restapi.GetAdminUsersResponseObject, error) {
+	if _, err := s.requirePermission(ctx, permManageUsers); err != nil {
+		return nil, err
+	}
+	users, err := database.FetchStaffUsers(s.db)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch users")
+	}
+	resp := restapi.AdminUserListResponse{Users: make([]restapi.User, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, toRESTUser(u))
+	}
+	return restapi.GetAdminUsers200JSONResponse(resp), nil
+}
+
+// PutUserRole handles PUT /admin/users/{name}/role
+func (s *server) PutUserRole(ctx context.Context, request restapi.PutUserRoleRequestObject) (restapi.PutUserRoleResponseObject, error) {
+	if request.Body == nil || !request.Body.Role.Valid() {
+		return nil, newFieldError("role", "invalid role")
+	}
+	admin, err := s.requirePermission(ctx, permManageUsers)
+	if err != nil {
+		return nil, err
+	}
+	// prevents the last admin from locking everyone out
+	if admin.Name == request.Name {
+		return nil, newHTTPError(http.StatusBadRequest, "cannot change own role")
+	}
+	if err := database.UpdateUserRole(s.db, request.Name, database.UserRole(request.Body.Role)); err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "user not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to update role")
+	}
+	return restapi.PutUserRole200JSONResponse(restapi.UpdateUserRoleResponse{}), nil
+}
+
+// PostBulkRejudge handles POST /admin/rejudge
+func (s *server) PostBulkRejudge(ctx context.Context, request restapi.PostBulkRejudgeRequestObject) (restapi.PostBulkRejudgeResponseObject, error) {
+	if request.Body == nil {
+		return nil, newHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	body := request.Body
+	filter := database.SubmissionFilter{
+		Problem:  deref(body.Problem),
+		Statuses: splitList(deref(body.Status)),
+		Langs:    splitList(deref(body.Lang)),
+		User:     deref(body.User),
+		Since:    deref(body.Since),
+		Until:    deref(body.Until),
+		IsLatest: body.IsLatest,
+		Hacked:   body.Hacked,
+	}
+	if deref(body.OutdatedOnly) {
+		if deref(body.IsLatest) {
+			return nil, newFieldError("outdated_only", "outdated_only cannot be used with is_latest=true")
+		}
+		isLatest := false
+		filter.IsLatest = &isLatest
+	}
+	if filter.Empty() {
+		return nil, newHTTPError(http.StatusBadRequest, "at least one filter is required")
+	}
+	priority := int32(bulkRejudgeDefaultPriority)
+	if body.Priority != nil {
+		priority = *body.Priority
+	}
+	rate := int32(bulkRejudgeDefaultRate)
+	if body.Rate != nil {
+		rate = *body.Rate
+	}
+	// keep bulk rejudges behind submissions (45) and rejudges by users (40)
+	if priority < 0 || priority >= 40 {
+		return nil, newFieldError("priority", "priority must be in [0, 40)")
+	}
+	if rate < 1 || rate > 100 {
+		return nil, newFieldError("rate", "rate must be in [1, 100]")
+	}
+
+	admin, err := s.requirePermission(ctx, permManageProblems)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := deref(body.DryRun)
+	if dryRun {
+		count, err := database.CountRejudgeTargets(s.db, filter)
+		if err != nil {
+			return nil, newHTTPError(http.StatusInternalServerError, "failed to count submissions")
+		}
+		return restapi.PostBulkRejudge200JSONResponse(restapi.BulkRejudgeResponse{Count: count, DryRun: true}), nil
+	}
+
+	job, err := database.CreateRejudgeJob(s.db, filter, priority, rate, admin.Name)
+	if errors.Is(err, database.ErrAlreadyExists) {
+		return nil, newHTTPError(http.StatusConflict, "the same bulk rejudge is running")
+	} else if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to create bulk rejudge")
+	}
+	slog.Info("bulk rejudge", "admin", admin.Name, "job", job.ID, "filter", job.Filter, "count", job.Total, "priority", priority, "rate", rate)
+	return restapi.PostBulkRejudge200JSONResponse(restapi.BulkRejudgeResponse{Count: int64(job.Total), DryRun: false, JobId: &job.ID}), nil
+}
+
+// GetBulkRejudgeJobs handles GET /admin/rejudge
+func (s *server) GetBulkRejudgeJobs(ctx context.Context, _ restapi.GetBulkRejudgeJobsRequestObject) (restapi.GetBulkRejudgeJobsResponseObject, error) {
+	if _, err := s.requirePermission(ctx, permManageProblems); err != nil {
+		return nil, err
+	}
+	jobs, err := database.FetchRejudgeJobs(s.db, bulkRejudgeJobListLimit)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch bulk rejudges")
+	}
+	resp := restapi.BulkRejudgeJobListResponse{Jobs: make([]restapi.BulkRejudgeJob, 0, len(jobs))}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, toRESTRejudgeJob(job))
+	}
+	return restapi.GetBulkRejudgeJobs200JSONResponse(resp), nil
+}
+
+// CancelBulkRejudgeJob handles DELETE /admin/rejudge/{id}
+func (s *server) CancelBulkRejudgeJob(ctx context.Context, request restapi.CancelBulkRejudgeJobRequestObject) (restapi.CancelBulkRejudgeJobResponseObject, error) {
+	admin, err := s.requirePermission(ctx, permManageProblems)
+	if err != nil {
+		return nil, err
+	}
+	job, err := database.CancelRejudgeJob(s.db, request.Id)
+	if errors.Is(err, database.ErrNotExist) {
+		return nil, newHTTPError(http.StatusNotFound, "bulk rejudge not found")
+	} else if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to cancel bulk rejudge")
+	}
+	slog.Info("bulk rejudge canceled", "admin", admin.Name, "job", job.ID, "queued", job.Queued, "total", job.Total)
+	return restapi.CancelBulkRejudgeJob200JSONResponse(toRESTRejudgeJob(job)), nil
+}
+
+func toRESTRejudgeJob(job database.RejudgeJob) restapi.BulkRejudgeJob {
+	resp := restapi.BulkRejudgeJob{
+		Id:        job.ID,
+		Filter:    job.Filter,
+		Priority:  job.Priority,
+		Rate:      job.Rate,
+		CreatedBy: job.CreatedBy,
+		Total:     job.Total,
+		Queued:    job.Queued,
+		StartedAt: job.StartedAt,
+		Canceled:  job.Canceled,
+	}
+	if job.FinishedAt.Valid {
+		v := job.FinishedAt.Time
+		resp.FinishedAt = &v
+	}
+	return resp
+}
+
+// GetAutoRejudgeProgress handles GET /admin/auto_rejudge
+func (s *server) GetAutoRejudgeProgress(ctx context.Context, _ restapi.GetAutoRejudgeProgressRequestObject) (restapi.GetAutoRejudgeProgressResponseObject, error) {
+	if _, err := s.requirePermission(ctx, permManageProblems); err != nil {
+		return nil, err
+	}
+	progresses, err := database.FetchAutoRejudgeProgresses(s.db)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch progress")
+	}
+	resp := restapi.AutoRejudgeProgressListResponse{Progresses: make([]restapi.AutoRejudgeProgress, 0, len(progresses))}
+	for _, p := range progresses {
+		progress := restapi.AutoRejudgeProgress{
+			Problem:          p.ProblemName,
+			TestCasesVersion: p.TestCasesVersion,
+			Total:            p.Total,
+			Queued:           p.Queued,
+			StartedAt:        p.StartedAt,
+		}
+		if p.FinishedAt.Valid {
+			v := p.FinishedAt.Time
+			progress.FinishedAt = &v
+		}
+		resp.Progresses = append(resp.Progresses, progress)
+	}
+	return restapi.GetAutoRejudgeProgress200JSONResponse(resp), nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func TestPostBulkRejudge(t *testing.T) {
+	db := setupTestDB(t)
+	for _, name := range []string{"admin", "moderator"} {
+		if err := database.RegisterUser(db, name, "uid-"+name); err != nil {
+			t.Fatalf("register user: %v", err)
+		}
+	}
+	if err := database.UpdateUserRole(db, "admin", database.RoleAdmin); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	if err := database.UpdateUserRole(db, "moderator", database.RoleModerator); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	id := createTestSubmission(t, db, "aplusb-bulk")
+	if _, err := database.SaveSubmission(db, database.Submission{
+		ProblemName: "aplusb-bulk",
+		Lang:        "rust",
+		Status:      "AC",
+		Source:      "fn main() {}",
+	}); err != nil {
+		t.Fatalf("save submission: %v", err)
+	}
+	asAdmin := newRouterAs(db, "uid-admin")
+	problem := "aplusb-bulk"
+	lang := "cpp"
+	dryRun := true
+
+	if rec := doJSON(t, newRouterAs(db, "uid-moderator"), http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem}); rec.Code != http.StatusForbidden {
+		t.Fatalf("moderators must not bulk rejudge: %d", rec.Code)
+	}
+	if rec := doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty filter must be rejected: %d", rec.Code)
+	}
+	priority := int32(40)
+	if rec := doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, Priority: &priority}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("high priority must be rejected: %d", rec.Code)
+	}
+
+	rec := doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, DryRun: &dryRun})
+	var resp restapi.BulkRejudgeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if !resp.DryRun || resp.Count != 2 {
+		t.Fatalf("unexpected dry run: %+v", resp)
+	}
+	// the same filters as GET /submissions
+	langs := "cpp,python3"
+	rec = doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, Lang: &langs, DryRun: &dryRun})
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if resp.Count != 1 {
+		t.Fatalf("unexpected dry run of languages: %+v", resp)
+	}
+	yes := true
+	if rec := doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, IsLatest: &yes, OutdatedOnly: &yes}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("outdated_only with is_latest must be rejected: %d", rec.Code)
+	}
+	if _, task, err := database.PopTask(db); err != nil || task.Data != nil {
+		t.Fatalf("dry run must not enqueue: %+v %v", task, err)
+	}
+
+	rec = doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, Lang: &lang})
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if resp.DryRun || resp.Count != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.JobId == nil {
+		t.Fatalf("job id is not returned: %+v", resp)
+	}
+	if rec := doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, Lang: &lang}); rec.Code != http.StatusConflict {
+		t.Fatalf("the running job must conflict: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// tasks are enqueued by the worker
+	if n, err := database.QueueRejudgeJobs(db, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("queue jobs: %d %v", n, err)
+	}
+	_, task, err := database.PopTask(db)
+	if err != nil || task.Data == nil || task.Data.(database.SubmissionData).ID != id {
+		t.Fatalf("unexpected task: %+v %v", task, err)
+	}
+
+	rec = doJSON(t, asAdmin, http.MethodGet, "/admin/rejudge", "token", nil)
+	var jobs restapi.BulkRejudgeJobListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &jobs); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if len(jobs.Jobs) != 1 || jobs.Jobs[0].Id != *resp.JobId || jobs.Jobs[0].Queued != 1 || jobs.Jobs[0].FinishedAt == nil {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+
+	// a new job can be canceled
+	rec = doJSON(t, asAdmin, http.MethodPost, "/admin/rejudge", "token", restapi.BulkRejudgeRequest{Problem: &problem, Lang: &lang})
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.JobId == nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	var job restapi.BulkRejudgeJob
+	rec = doJSON(t, asAdmin, http.MethodDelete, fmt.Sprintf("/admin/rejudge/%d", *resp.JobId), "token", nil)
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil || !job.Canceled {
+		t.Fatalf("unexpected cancel: %v %s", err, rec.Body.String())
+	}
+	if rec := doJSON(t, asAdmin, http.MethodDelete, "/admin/rejudge/12345", "token", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown job must be not found: %d", rec.Code)
+	}
+}
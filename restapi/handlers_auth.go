@@ -34,11 +34,8 @@ func (s *server) GetCurrentUserInfo(ctx context.Context, _ restapi.GetCurrentUse
 	if err != nil || user == nil {
 		return restapi.GetCurrentUserInfo200JSONResponse(restapi.CurrentUserInfoResponse{}), nil
 	}
-	resp := restapi.CurrentUserInfoResponse{User: &restapi.User{
-		Name:        user.Name,
-		LibraryUrl:  user.LibraryURL,
-		IsDeveloper: user.IsDeveloper,
-	}}
+	u := toRESTUser(*user)
+	resp := restapi.CurrentUserInfoResponse{User: &u}
 	return restapi.GetCurrentUserInfo200JSONResponse(resp), nil
 }
 
@@ -51,11 +48,11 @@ func (s *server) PatchCurrentUserInfo(ctx context.Context, request restapi.Patch
 	if err != nil || uid == "" {
 		return nil, newHTTPError(http.StatusUnauthorized, "unauthorized")
 	}
+	// is_developer and role are derived from the stored role and cannot be changed here
 	user := database.User{
-		Name:        request.Body.User.Name,
-		UID:         uid,
-		LibraryURL:  request.Body.User.LibraryUrl,
-		IsDeveloper: request.Body.User.IsDeveloper,
+		Name:       request.Body.User.Name,
+		UID:        uid,
+		LibraryURL: request.Body.User.LibraryUrl,
 	}
 	if err := s.updateUser(s.db, user); err != nil {
 		return nil, newHTTPError(http.StatusBadRequest, "update failed")
@@ -72,13 +69,7 @@ func (s *server) GetUserInfo(_ context.Context, request restapi.GetUserInfoReque
 	if err != nil || user == nil {
 		return nil, newHTTPError(http.StatusBadRequest, "invalid user name")
 	}
-	resp := restapi.UserInfoResponse{
-		User: restapi.User{
-			Name:        user.Name,
-			LibraryUrl:  user.LibraryURL,
-			IsDeveloper: user.IsDeveloper,
-		},
-	}
+	resp := restapi.UserInfoResponse{User: toRESTUser(*user)}
 	return restapi.GetUserInfo200JSONResponse(resp), nil
 }
 
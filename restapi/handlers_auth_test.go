@@ -91,8 +91,28 @@ func TestPatchCurrentUserInfo_Succeeds(t *testing.T) {
 	if captured.LibraryURL != "https://example.com" {
 		t.Fatalf("library url not propagated: %q", captured.LibraryURL)
 	}
-	if !captured.IsDeveloper {
-		t.Fatalf("is_developer not propagated")
+}
+
+func TestPatchCurrentUserInfo_CannotGrantRole(t *testing.T) {
+	db := setupTestDB(t)
+	if err := database.RegisterUser(db, "alice", "uid-123"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+	s := &server{db: db, authClient: fakeAuthClient{uid: "uid-123"}}
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+
+	body := `{"user":{"name":"alice","library_url":"https://example.com","is_developer":true,"role":"admin"}}`
+	rec := doJSON(t, r, http.MethodPatch, "/auth/current_user", "token", json.RawMessage(body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PATCH /auth/current_user status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	user, err := database.FetchUserFromName(db, "alice")
+	if err != nil || user == nil {
+		t.Fatalf("fetch user: %v", err)
+	}
+	if user.Role != database.RoleUser || user.LibraryURL != "https://example.com" {
+		t.Fatalf("unexpected user: %+v", user)
 	}
 }
 
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+const (
+	// eventPollInterval is how often a stream re-reads the database while
+	// notifications are not delivered.
+	eventPollInterval = 3 * time.Second
+	// eventFallbackPollInterval is how often a stream re-reads the database
+	// while notifications are delivered, in case one is missed.
+	eventFallbackPollInterval = 30 * time.Second
+	// eventKeepAliveInterval keeps idle streams open through proxies.
+	eventKeepAliveInterval = 15 * time.Second
+)
+
+// judgingStatuses are set while a submission or hack is queued or being judged.
+var judgingStatuses = map[string]bool{
+	"WJ":         true,
+	"-":          true,
+	"Fetching":   true,
+	"Compiling":  true,
+	"Generating": true,
+	"Verifying":  true,
+}
+
+func isJudgingStatus(status string) bool {
+	if judgingStatuses[status] {
+		return true
+	}
+	// progress such as "3/40"
+	var done, total int
+	n, _ := fmt.Sscanf(status, "%d/%d", &done, &total)
+	return n == 2
+}
+
+// statusHub fans out database update notifications to streaming clients.
+type statusHub struct {
+	mu   sync.Mutex
+	subs map[database.Update]map[chan struct{}]struct{}
+	// listening is set while the notifications are delivered
+	listening atomic.Bool
+}
+
+func newStatusHub() *statusHub {
+	return &statusHub{subs: map[database.Update]map[chan struct{}]struct{}{}}
+}
+
+// subscribe returns a channel signaled on each update of u. Signals are
+// coalesced, so readers must re-read the current state.
+func (h *statusHub) subscribe(u database.Update) (<-chan struct{}, func()) {
+	if h == nil {
+		return nil, func() {}
+	}
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	if h.subs[u] == nil {
+		h.subs[u] = map[chan struct{}]struct{}{}
+	}
+	h.subs[u][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[u], ch)
+		if len(h.subs[u]) == 0 {
+			delete(h.subs, u)
+		}
+	}
+}
+
+func (h *statusHub) publish(u database.Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[u] {
+		signal(ch)
+	}
+}
+
+// setListening records whether the notifications are delivered. When they are
+// delivered again, every subscriber is signaled, as updates may be missed.
+func (h *statusHub) setListening(listening bool) {
+	if h.listening.Swap(listening) || !listening {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for ch := range subs {
+			signal(ch)
+		}
+	}
+}
+
+// isListening reports whether subscribers are signaled on updates, so that
+// the streams can rely on them instead of polling.
+func (h *statusHub) isListening() bool {
+	return h != nil && h.listening.Load()
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// eventSource returns the next event to send, or nil if nothing changed.
+type eventSource func() (event interface{}, final bool, err error)
+
+// streamEvents writes Server-Sent Events produced by next until the event is final or the client leaves.
+func (s *server) streamEvents(ctx context.Context, w http.ResponseWriter, u database.Update, name string, next eventSource) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("streaming is not supported")
+	}
+
+	updates, unsubscribe := s.hub.subscribe(u)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	poll := time.NewTicker(eventPollInterval)
+	defer poll.Stop()
+	lastWrite := time.Now()
+	for {
+		lastRead := time.Now()
+		event, final, err := next()
+		if err != nil {
+			// headers are already sent, so the error can only be logged
+			slog.Error("event stream failed", "event", name, "id", u.ID, "error", err)
+			return nil
+		}
+		if event != nil {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data); err != nil {
+				return nil
+			}
+			flusher.Flush()
+			lastWrite = time.Now()
+		}
+		if final {
+			return nil
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-updates:
+				break wait
+			case <-poll.C:
+				if time.Since(lastWrite) >= eventKeepAliveInterval {
+					if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+						return nil
+					}
+					flusher.Flush()
+					lastWrite = time.Now()
+				}
+				if !s.hub.isListening() || time.Since(lastRead) >= eventFallbackPollInterval {
+					break wait
+				}
+			}
+		}
+	}
+}
+
+type submissionEventStream struct {
+	ctx context.Context
+	s   *server
+	id  int32
+}
+
+func (st submissionEventStream) VisitGetSubmissionEventsResponse(w http.ResponseWriter) error {
+	first := true
+	var prev restapi.SubmissionStatusEvent
+	prevCases := map[string]restapi.SubmissionCaseResult{}
+	next := func() (interface{}, bool, error) {
+		sub, err := database.FetchSubmission(st.s.db, st.id)
+		if err != nil {
+			return nil, false, err
+		}
+		cases, err := database.FetchTestcaseResults(st.s.db, st.id)
+		if err != nil {
+			return nil, false, err
+		}
+
+		event := restapi.SubmissionStatusEvent{
+			Id:     sub.ID,
+			Status: sub.Status,
+			Time:   float32(sub.MaxTime) / 1000.0,
+			Memory: sub.MaxMemory,
+			Final:  !isJudgingStatus(sub.Status),
+		}
+		changed := []restapi.SubmissionCaseResult{}
+		for _, c := range cases {
+			r := toRESTCaseResult(c)
+			if p, ok := prevCases[c.Testcase]; ok && p.Status == r.Status && p.Time == r.Time && p.Memory == r.Memory {
+				continue
+			}
+			prevCases[c.Testcase] = r
+			changed = append(changed, r)
+		}
+		if len(changed) > 0 {
+			event.CaseResults = &changed
+		}
+
+		if !first && len(changed) == 0 && event.Status == prev.Status && event.Time == prev.Time && event.Memory == prev.Memory {
+			return nil, event.Final, nil
+		}
+		first = false
+		prev = event
+		return event, event.Final, nil
+	}
+	return st.s.streamEvents(st.ctx, w, database.Update{Kind: database.SubmissionUpdate, ID: st.id}, "submission", next)
+}
+
+// GetSubmissionEvents handles GET /submissions/{id}/events
+func (s *server) GetSubmissionEvents(ctx context.Context, request restapi.GetSubmissionEventsRequestObject) (restapi.GetSubmissionEventsResponseObject, error) {
+	if _, err := database.FetchSubmission(s.db, request.Id); err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch submission")
+	}
+	return submissionEventStream{ctx: ctx, s: s, id: request.Id}, nil
+}
+
+type hackEventStream struct {
+	ctx context.Context
+	s   *server
+	id  int32
+}
+
+func (st hackEventStream) VisitGetHackEventsResponse(w http.ResponseWriter) error {
+	var prev *restapi.HackStatusEvent
+	next := func() (interface{}, bool, error) {
+		h, err := database.FetchHack(st.s.db, st.id)
+		if err != nil {
+			return nil, false, err
+		}
+
+		event := restapi.HackStatusEvent{
+			Id:     h.ID,
+			Status: h.Status,
+			Final:  !isJudgingStatus(h.Status),
+		}
+		if h.Time.Valid {
+			v := float32(h.Time.Int32) / 1000.0
+			event.Time = &v
+		}
+		if h.Memory.Valid {
+			v := h.Memory.Int64
+			event.Memory = &v
+		}
+
+		if prev != nil && prev.Status == event.Status && equalPtr(prev.Time, event.Time) && equalPtr(prev.Memory, event.Memory) {
+			return nil, event.Final, nil
+		}
+		prev = &event
+		return event, event.Final, nil
+	}
+	return st.s.streamEvents(st.ctx, w, database.Update{Kind: database.HackUpdate, ID: st.id}, "hack", next)
+}
+
+// GetHackEvents handles GET /hacks/{id}/events
+func (s *server) GetHackEvents(ctx context.Context, request restapi.GetHackEventsRequestObject) (restapi.GetHackEventsResponseObject, error) {
+	if _, err := database.FetchHack(s.db, request.Id); err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch hack")
+	}
+	return hackEventStream{ctx: ctx, s: s, id: request.Id}, nil
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func TestIsJudgingStatus(t *testing.T) {
+	for status, expected := range map[string]bool{
+		"WJ":        true,
+		"Compiling": true,
+		"3/40":      true,
+		"AC":        false,
+		"CE":        false,
+		"IE":        false,
+	} {
+		if actual := isJudgingStatus(status); actual != expected {
+			t.Errorf("isJudgingStatus(%q) = %v, want %v", status, actual, expected)
+		}
+	}
+}
+
+func TestGetSubmissionEvents(t *testing.T) {
+	db := setupTestDB(t)
+	problem := database.Problem{
+		Name:             "aplusb-events",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "v1",
+		Version:          "1",
+		OverallVersion:   "1",
+	}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	id, err := database.SaveSubmission(db, database.Submission{
+		ProblemName: problem.Name,
+		Lang:        "cpp",
+		Status:      "0/2",
+		Source:      "#include <bits/stdc++.h>\nint main(){return 0;}",
+		MaxTime:     -1,
+		MaxMemory:   -1,
+	})
+	if err != nil {
+		t.Fatalf("save submission: %v", err)
+	}
+	if err := database.SaveTestcaseResults(db, []database.SubmissionTestcaseResult{
+		{Submission: id, Testcase: "a", Status: "-", DisplayOrder: 0},
+		{Submission: id, Testcase: "b", Status: "-", DisplayOrder: 1},
+	}); err != nil {
+		t.Fatalf("save results: %v", err)
+	}
+
+	hub := newStatusHub()
+	s := &server{db: db, hub: hub}
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, err := http.Get(fmt.Sprintf("%s/submissions/%d/events", ts.URL, id))
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	first := readSubmissionEvent(t, reader)
+	if first.Status != "0/2" || first.Final || first.CaseResults == nil || len(*first.CaseResults) != 2 {
+		t.Fatalf("unexpected first event: %+v", first)
+	}
+
+	// the judge finishes case a
+	if err := database.SaveTestcaseResults(db, []database.SubmissionTestcaseResult{
+		{Submission: id, Testcase: "a", Status: "AC", Time: 10, DisplayOrder: 0},
+	}); err != nil {
+		t.Fatalf("save results: %v", err)
+	}
+	if err := database.UpdateSubmissionStatus(db, id, "1/2"); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	hub.publish(database.Update{Kind: database.SubmissionUpdate, ID: id})
+
+	second := readSubmissionEvent(t, reader)
+	if second.Status != "1/2" || second.CaseResults == nil || len(*second.CaseResults) != 1 || (*second.CaseResults)[0].Case != "a" {
+		t.Fatalf("unexpected second event: %+v", second)
+	}
+
+	if err := database.UpdateSubmissionStatus(db, id, "AC"); err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	hub.publish(database.Update{Kind: database.SubmissionUpdate, ID: id})
+
+	last := readSubmissionEvent(t, reader)
+	if last.Status != "AC" || !last.Final {
+		t.Fatalf("unexpected last event: %+v", last)
+	}
+	// the stream ends after the final event
+	if rest, err := io.ReadAll(reader); err != nil || strings.TrimSpace(string(rest)) != "" {
+		t.Fatalf("unexpected data after the final event: %q, %v", rest, err)
+	}
+}
+
+func TestStatusHubListening(t *testing.T) {
+	var nilHub *statusHub
+	if nilHub.isListening() {
+		t.Fatal("nil hub must not be listening")
+	}
+
+	hub := newStatusHub()
+	updates, unsubscribe := hub.subscribe(database.Update{Kind: database.SubmissionUpdate, ID: 1})
+	defer unsubscribe()
+	signaled := func() bool {
+		select {
+		case <-updates:
+			return true
+		default:
+			return false
+		}
+	}
+
+	hub.setListening(true)
+	if !hub.isListening() || !signaled() {
+		t.Fatal("streams must re-read when the notifications are delivered again")
+	}
+	hub.setListening(true)
+	if signaled() {
+		t.Fatal("unexpected signal without reconnection")
+	}
+	hub.setListening(false)
+	if hub.isListening() || signaled() {
+		t.Fatal("unexpected state after disconnection")
+	}
+}
+
+func TestGetSubmissionEvents_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	s := &server{db: db}
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/12345/events", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func readSubmissionEvent(t *testing.T, reader *bufio.Reader) restapi.SubmissionStatusEvent {
+	t.Helper()
+	name := ""
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if name != "submission" {
+				t.Fatalf("unexpected event %q", name)
+			}
+			var event restapi.SubmissionStatusEvent
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				t.Fatalf("decode event: %v", err)
+			}
+			return event
+		}
+	}
+}
@@ -24,7 +24,7 @@ func (s *server) PostHack(ctx context.Context, request restapi.PostHackRequestOb
 	}
 	body := request.Body
 	if body.Submission < 0 {
-		return nil, newHTTPError(http.StatusBadRequest, "invalid submission id")
+		return nil, newFieldError("submission", "invalid submission id")
 	}
 
 	txt := []byte(nil)
@@ -37,10 +37,10 @@ func (s *server) PostHack(ctx context.Context, request restapi.PostHackRequestOb
 	}
 
 	if len(txt) > testCaseTextLengthLimit {
-		return nil, newHTTPError(http.StatusBadRequest, "test case is too long")
+		return nil, newFieldError("test_case_txt", "test case is too long")
 	}
 	if len(cpp) > testCaseSourceLengthLimit {
-		return nil, newHTTPError(http.StatusBadRequest, "test case generator is too long")
+		return nil, newFieldError("test_case_cpp", "test case generator is too long")
 	}
 
 	uid, err := s.uidFromContext(ctx)
@@ -59,6 +59,11 @@ func (s *server) PostHack(ctx context.Context, request restapi.PostHackRequestOb
 		}
 		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch submission")
 	}
+	if userName != "" {
+		if err := s.checkPendingTaskLimit(userName); err != nil {
+			return nil, err
+		}
+	}
 
 	hack := database.Hack{
 		HackTime:     time.Now(),
@@ -88,6 +93,28 @@ func (s *server) PostHack(ctx context.Context, request restapi.PostHackRequestOb
 	return restapi.PostHack200JSONResponse(restapi.HackResponse{Id: id}), nil
 }
 
+// PostHackRejudge handles POST /hacks/{id}/rejudge
+func (s *server) PostHackRejudge(ctx context.Context, request restapi.PostHackRejudgeRequestObject) (restapi.PostHackRejudgeResponseObject, error) {
+	if _, err := s.requirePermission(ctx, permModerateHacks); err != nil {
+		return nil, err
+	}
+	h, err := database.FetchHack(s.db, request.Id)
+	if err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch hack")
+	}
+	h.Status = "WJ"
+	if err := database.UpdateHack(s.db, h); err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to update hack")
+	}
+	if err := database.PushHackTask(s.db, database.HackData{ID: h.ID}, hackTaskPriority); err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "enqueue failed")
+	}
+	return restapi.PostHackRejudge200JSONResponse(restapi.HackRejudgeResponse{}), nil
+}
+
 func (s *server) GetHackInfo(_ context.Context, request restapi.GetHackInfoRequestObject) (restapi.GetHackInfoResponseObject, error) {
 	h, err := database.FetchHack(s.db, request.Id)
 	if err != nil {
@@ -150,7 +177,7 @@ func (s *server) GetHackList(_ context.Context, request restapi.GetHackListReque
 		limit = int(*request.Params.Limit)
 	}
 	if limit > 1000 {
-		return nil, newHTTPError(http.StatusBadRequest, "limit must not be greater than 1000")
+		return nil, newFieldError("limit", "limit must not be greater than 1000")
 	}
 
 	user := ""
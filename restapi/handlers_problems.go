@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/yosupo06/library-checker-judge/database"
 	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+	"github.com/yosupo06/library-checker-judge/storage"
 )
 
 // GetProblems handles GET /problems
@@ -30,7 +34,7 @@ func (s *server) GetProblemInfo(ctx context.Context, request restapi.GetProblemI
 	p, err := database.FetchProblem(s.db, request.Name)
 	if err != nil {
 		if err == database.ErrNotExist {
-			return nil, newHTTPError(http.StatusNotFound, "problem not found")
+			return nil, newCodedError(http.StatusNotFound, restapi.ProblemNotFound, "problem not found")
 		}
 		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch problem")
 	}
@@ -44,3 +48,136 @@ func (s *server) GetProblemInfo(ctx context.Context, request restapi.GetProblemI
 	}
 	return restapi.GetProblemInfo200JSONResponse(resp), nil
 }
+
+// fetchStorageProblem returns the current version of the problem in storage.
+func (s *server) fetchStorageProblem(name string) (storage.Problem, error) {
+	if s.files == nil {
+		return storage.Problem{}, newHTTPError(http.StatusServiceUnavailable, "storage is not configured")
+	}
+	return s.currentStorageProblem(name)
+}
+
+func (s *server) currentStorageProblem(name string) (storage.Problem, error) {
+	p, err := database.FetchProblem(s.db, name)
+	if err != nil {
+		if err == database.ErrNotExist {
+			return storage.Problem{}, newCodedError(http.StatusNotFound, restapi.ProblemNotFound, "problem not found")
+		}
+		return storage.Problem{}, newHTTPError(http.StatusInternalServerError, "failed to fetch problem")
+	}
+	return storage.Problem{
+		Name:            p.Name,
+		Version:         p.Version,
+		OverallVersion:  p.OverallVersion,
+		TestCaseVersion: p.TestCasesVersion,
+	}, nil
+}
+
+func storageError(err error, msg string) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return newHTTPError(http.StatusNotFound, msg+" not found")
+	}
+	slog.Error("read storage failed", "error", err)
+	return newHTTPError(http.StatusInternalServerError, "failed to read "+msg)
+}
+
+// GetProblemStatement handles GET /problems/{name}/statement
+func (s *server) GetProblemStatement(ctx context.Context, request restapi.GetProblemStatementRequestObject) (restapi.GetProblemStatementResponseObject, error) {
+	p, err := s.fetchStorageProblem(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := p.Statement(ctx, s.files)
+	if err != nil {
+		return nil, storageError(err, "statement")
+	}
+	return restapi.GetProblemStatement200JSONResponse(restapi.ProblemStatementResponse{
+		OverallVersion: p.OverallVersion,
+		Statement:      string(statement),
+	}), nil
+}
+
+// GetProblemExamples handles GET /problems/{name}/examples
+func (s *server) GetProblemExamples(ctx context.Context, request restapi.GetProblemExamplesRequestObject) (restapi.GetProblemExamplesResponseObject, error) {
+	p, err := s.fetchStorageProblem(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	examples, err := p.Examples(ctx, s.files)
+	if err != nil {
+		return nil, storageError(err, "examples")
+	}
+	resp := restapi.ProblemExamplesResponse{
+		TestcasesVersion: p.TestCaseVersion,
+		Examples:         make([]restapi.ProblemExample, 0, len(examples)),
+	}
+	for _, e := range examples {
+		resp.Examples = append(resp.Examples, restapi.ProblemExample{Name: e.Name, In: string(e.In), Out: string(e.Out)})
+	}
+	return restapi.GetProblemExamples200JSONResponse(resp), nil
+}
+
+// GetProblemFiles handles GET /problems/{name}/files
+func (s *server) GetProblemFiles(ctx context.Context, request restapi.GetProblemFilesRequestObject) (restapi.GetProblemFilesResponseObject, error) {
+	p, err := s.fetchStorageProblem(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	files, err := p.PublicFiles(ctx, s.files)
+	if err != nil {
+		return nil, storageError(err, "files")
+	}
+	resp := restapi.ProblemFilesResponse{
+		OverallVersion: p.OverallVersion,
+		Files:          make([]restapi.ProblemFile, 0, len(files)),
+	}
+	for _, f := range files {
+		resp.Files = append(resp.Files, restapi.ProblemFile{Path: f.Path, Size: f.Size})
+	}
+	return restapi.GetProblemFiles200JSONResponse(resp), nil
+}
+
+// GetProblemFile handles GET /problems/{name}/file
+func (s *server) GetProblemFile(ctx context.Context, request restapi.GetProblemFileRequestObject) (restapi.GetProblemFileResponseObject, error) {
+	p, err := s.fetchStorageProblem(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := p.PublicFile(ctx, s.files, request.Params.Path)
+	if err != nil {
+		return nil, storageError(err, "file")
+	}
+	return restapi.GetProblemFile200ApplicationoctetStreamResponse{
+		Body:          bytes.NewReader(data),
+		ContentLength: int64(len(data)),
+	}, nil
+}
+
+// GetProblemTestCases handles GET /problems/{name}/testcases
+func (s *server) GetProblemTestCases(ctx context.Context, request restapi.GetProblemTestCasesRequestObject) (restapi.GetProblemTestCasesResponseObject, error) {
+	if s.cases == nil {
+		return nil, newHTTPError(http.StatusServiceUnavailable, "storage is not configured")
+	}
+	p, err := s.currentStorageProblem(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := p.TestCaseManifest(ctx, s.cases)
+	if err != nil {
+		return nil, storageError(err, "test cases")
+	}
+	resp := restapi.ProblemTestCasesResponse{
+		TestcasesVersion: p.TestCaseVersion,
+		TotalSize:        manifest.TotalSize(),
+		Testcases:        make([]restapi.ProblemTestCase, 0, len(manifest.Cases)),
+	}
+	for _, c := range manifest.Cases {
+		resp.Testcases = append(resp.Testcases, restapi.ProblemTestCase{
+			Name:      c.Name,
+			Generator: c.Generator,
+			InSize:    c.In.Size,
+			OutSize:   c.Out.Size,
+		})
+	}
+	return restapi.GetProblemTestCases200JSONResponse(resp), nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+	"github.com/yosupo06/library-checker-judge/storage"
+)
+
+type fakePublicReader map[string]string
+
+func (f fakePublicReader) ReadPublic(_ context.Context, key string) ([]byte, error) {
+	v, ok := f[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return []byte(v), nil
+}
+
+func (f fakePublicReader) ListPublic(_ context.Context, prefix string) ([]storage.PublicObject, error) {
+	objects := []storage.PublicObject{}
+	for k, v := range f {
+		if strings.HasPrefix(k, prefix) {
+			objects = append(objects, storage.PublicObject{Key: k, Size: int64(len(v))})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+type fakePrivateReader map[string]string
+
+func (f fakePrivateReader) ReadPrivate(_ context.Context, key string) ([]byte, error) {
+	v, ok := f[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return []byte(v), nil
+}
+
+func TestProblemStorageEndpoints(t *testing.T) {
+	db := setupTestDB(t)
+	if err := database.SaveProblem(db, database.Problem{
+		Name:             "aplusb",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "tv",
+		Version:          "v",
+		OverallVersion:   "ov",
+	}); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	files := fakePublicReader{
+		"v4/files/aplusb/ov/aplusb/task.md":          "# A + B",
+		"v4/files/aplusb/ov/aplusb/grader/solve.hpp": "int solve();",
+		"v4/files/aplusb/ov/common/fastio.h":         "// fastio",
+		"v4/examples/aplusb/tv/in/example_00.in":     "1 2\n",
+		"v4/examples/aplusb/tv/out/example_00.out":   "3\n",
+	}
+	s := &server{db: db, files: files}
+	ctx := context.Background()
+
+	statementObj, err := s.GetProblemStatement(ctx, restapi.GetProblemStatementRequestObject{Name: "aplusb"})
+	if err != nil {
+		t.Fatalf("GetProblemStatement returned error: %v", err)
+	}
+	if statement := statementObj.(restapi.GetProblemStatement200JSONResponse); statement.Statement != "# A + B" || statement.OverallVersion != "ov" {
+		t.Fatalf("unexpected statement: %+v", statement)
+	}
+
+	examplesObj, err := s.GetProblemExamples(ctx, restapi.GetProblemExamplesRequestObject{Name: "aplusb"})
+	if err != nil {
+		t.Fatalf("GetProblemExamples returned error: %v", err)
+	}
+	examples := examplesObj.(restapi.GetProblemExamples200JSONResponse)
+	if len(examples.Examples) != 1 || examples.Examples[0] != (restapi.ProblemExample{Name: "example_00", In: "1 2\n", Out: "3\n"}) {
+		t.Fatalf("unexpected examples: %+v", examples)
+	}
+
+	filesObj, err := s.GetProblemFiles(ctx, restapi.GetProblemFilesRequestObject{Name: "aplusb"})
+	if err != nil {
+		t.Fatalf("GetProblemFiles returned error: %v", err)
+	}
+	list := filesObj.(restapi.GetProblemFiles200JSONResponse)
+	if len(list.Files) != 3 || list.Files[0] != (restapi.ProblemFile{Path: "common/fastio.h", Size: 9}) {
+		t.Fatalf("unexpected files: %+v", list)
+	}
+
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+	rec := doJSON(t, r, http.MethodGet, "/problems/aplusb/file?path=grader/solve.hpp", "", nil)
+	if rec.Code != http.StatusOK || rec.Body.String() != "int solve();" {
+		t.Fatalf("unexpected file response: %d %q", rec.Code, rec.Body.String())
+	}
+	for _, path := range []string{
+		"/problems/aplusb/file?path=../../ov2/aplusb/task.md",
+		"/problems/unknown/statement",
+		"/problems/unknown/file?path=task.md",
+	} {
+		if rec := doJSON(t, r, http.MethodGet, path, "", nil); rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404, got %d", path, rec.Code)
+		}
+	}
+
+	s.cases = fakePrivateReader{
+		"v4/testcase/aplusb/tv.json": `{"problem":"aplusb","testcase_version":"tv","cases":[{"name":"example_00","generator":"example.in","in":{"size":4,"sha256":"a"},"out":{"size":2,"sha256":"b"}}]}`,
+	}
+	casesObj, err := s.GetProblemTestCases(ctx, restapi.GetProblemTestCasesRequestObject{Name: "aplusb"})
+	if err != nil {
+		t.Fatalf("GetProblemTestCases returned error: %v", err)
+	}
+	cases := casesObj.(restapi.GetProblemTestCases200JSONResponse)
+	if cases.TestcasesVersion != "tv" || cases.TotalSize != 6 || len(cases.Testcases) != 1 || cases.Testcases[0] != (restapi.ProblemTestCase{Name: "example_00", Generator: "example.in", InSize: 4, OutSize: 2}) {
+		t.Fatalf("unexpected test cases: %+v", cases)
+	}
+	s.cases = fakePrivateReader{}
+	if rec := doJSON(t, r, http.MethodGet, "/problems/aplusb/testcases", "", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without manifest, got %d", rec.Code)
+	}
+
+	// without storage, the endpoints are unavailable
+	rec = doJSON(t, newRouterAs(db, ""), http.MethodGet, "/problems/aplusb/statement", "", nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
@@ -19,7 +19,7 @@ func (s *server) GetRanking(_ context.Context, request restapi.GetRankingRequest
 		limit = int(*request.Params.Limit)
 	}
 	if limit > 1000 {
-		return nil, newHTTPError(http.StatusBadRequest, "limit must not be greater than 1000")
+		return nil, newFieldError("limit", "limit must not be greater than 1000")
 	}
 	results, total, err := database.FetchRanking(s.db, skip, limit)
 	if err != nil {
@@ -3,7 +3,12 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/yosupo06/library-checker-judge/database"
@@ -11,36 +16,42 @@ import (
 	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
 )
 
+// maxPendingTasksPerUser caps queued or running judge tasks per logged-in user.
+const maxPendingTasksPerUser = 50
+
 // PostSubmit handles POST /submit
 func (s *server) PostSubmit(ctx context.Context, request restapi.PostSubmitRequestObject) (restapi.PostSubmitResponseObject, error) {
 	if request.Body == nil {
 		return nil, newHTTPError(http.StatusBadRequest, "invalid json")
 	}
 	body := request.Body
-	if body.Problem == "" || body.Source == "" || body.Lang == "" {
-		return nil, newHTTPError(http.StatusBadRequest, "missing required fields")
+	if err := requireFields(map[string]string{"problem": body.Problem, "source": body.Source, "lang": body.Lang}); err != nil {
+		return nil, err
 	}
 	if len(body.Source) == 0 || len(body.Source) > 1024*1024 {
-		return nil, newHTTPError(http.StatusBadRequest, "invalid source length")
+		return nil, newFieldError("source", "invalid source length")
 	}
 	if _, err := database.FetchProblem(s.db, body.Problem); err != nil {
-		return nil, newHTTPError(http.StatusBadRequest, "unknown problem")
+		return nil, newCodedError(http.StatusBadRequest, restapi.UnknownProblem, "unknown problem").withField("problem", "unknown problem")
 	}
 	if _, ok := langs.GetLang(body.Lang); !ok {
-		return nil, newHTTPError(http.StatusBadRequest, "unknown language")
+		return nil, newCodedError(http.StatusBadRequest, restapi.UnknownLanguage, "unknown language").withField("lang", "unknown language")
 	}
 
 	var userName sql.NullString
-	if req, ok := httpRequestFromContext(ctx); ok && s.authClient != nil {
-		if token := parseBearerToken(req); token != "" {
-			uid := s.authClient.parseUID(req.Context(), token)
-			if uid != "" {
-				if user, err := database.FetchUserFromUID(s.db, uid); err != nil {
-					return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch user")
-				} else if user != nil {
-					userName = sql.NullString{String: user.Name, Valid: true}
-				}
-			}
+	if uid, err := s.uidFromContext(ctx); err == nil && uid != "" {
+		if user, err := database.FetchUserFromUID(s.db, uid); err != nil {
+			return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch user")
+		} else if user != nil {
+			userName = sql.NullString{String: user.Name, Valid: true}
+		}
+	} else if req, ok := httpRequestFromContext(ctx); ok && strings.HasPrefix(parseBearerToken(req), apiTokenPrefix) {
+		// a broken API token must not silently turn automated submissions anonymous
+		return nil, newCodedError(http.StatusUnauthorized, restapi.InvalidApiToken, "invalid api token")
+	}
+	if userName.Valid {
+		if err := s.checkPendingTaskLimit(userName.String); err != nil {
+			return nil, err
 		}
 	}
 
@@ -82,6 +93,10 @@ func (s *server) GetSubmissionInfo(ctx context.Context, request restapi.GetSubmi
 	if err != nil {
 		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch cases")
 	}
+	runs, err := database.FetchJudgeRuns(s.db, request.Id)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch judge runs")
+	}
 
 	var userNamePtr *string
 	if sub.UserName.Valid {
@@ -106,24 +121,7 @@ func (s *server) GetSubmissionInfo(ctx context.Context, request restapi.GetSubmi
 
 	cr := make([]restapi.SubmissionCaseResult, 0, len(cases))
 	for _, c := range cases {
-		var stderr *[]byte
-		if len(c.Stderr) > 0 {
-			b := c.Stderr
-			stderr = &b
-		}
-		var checker *[]byte
-		if len(c.CheckerOut) > 0 {
-			b := c.CheckerOut
-			checker = &b
-		}
-		cr = append(cr, restapi.SubmissionCaseResult{
-			Case:       c.Testcase,
-			Status:     c.Status,
-			Time:       float32(c.Time) / 1000.0,
-			Memory:     c.Memory,
-			Stderr:     stderr,
-			CheckerOut: checker,
-		})
+		cr = append(cr, toRESTCaseResult(c))
 	}
 	var compileErr *[]byte
 	if len(sub.CompileError) > 0 {
@@ -142,9 +140,114 @@ func (s *server) GetSubmissionInfo(ctx context.Context, request restapi.GetSubmi
 	if len(cr) > 0 {
 		resp.CaseResults = &cr
 	}
+	if len(runs) > 0 {
+		rs := make([]restapi.JudgeRun, 0, len(runs))
+		for _, r := range runs {
+			rs = append(rs, toRESTJudgeRun(r))
+		}
+		resp.Runs = &rs
+	}
 	return restapi.GetSubmissionInfo200JSONResponse(resp), nil
 }
 
+// GetJudgeRunDiff handles GET /submissions/{id}/runs/diff
+func (s *server) GetJudgeRunDiff(ctx context.Context, request restapi.GetJudgeRunDiffRequestObject) (restapi.GetJudgeRunDiffResponseObject, error) {
+	base, baseCases, err := s.fetchJudgeRunWithCases(request.Id, request.Params.Base)
+	if err != nil {
+		return nil, err
+	}
+	target, targetCases, err := s.fetchJudgeRunWithCases(request.Id, request.Params.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	// cases are listed in the order of target, followed by ones only in base
+	targetByName := map[string]database.SubmissionTestcaseResult{}
+	for _, c := range targetCases {
+		targetByName[c.Testcase] = c
+	}
+	baseByName := map[string]database.SubmissionTestcaseResult{}
+	for _, c := range baseCases {
+		baseByName[c.Testcase] = c
+	}
+	diffs := make([]restapi.JudgeRunCaseDiff, 0, len(targetCases))
+	for _, c := range targetCases {
+		t := toRESTCaseResult(c)
+		diff := restapi.JudgeRunCaseDiff{Case: c.Testcase, Target: &t, Changed: true}
+		if b, ok := baseByName[c.Testcase]; ok {
+			br := toRESTCaseResult(b)
+			diff.Base = &br
+			diff.Changed = b.Status != c.Status
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, c := range baseCases {
+		if _, ok := targetByName[c.Testcase]; ok {
+			continue
+		}
+		b := toRESTCaseResult(c)
+		diffs = append(diffs, restapi.JudgeRunCaseDiff{Case: c.Testcase, Base: &b, Changed: true})
+	}
+
+	return restapi.GetJudgeRunDiff200JSONResponse(restapi.JudgeRunDiffResponse{
+		Base:   toRESTJudgeRun(base),
+		Target: toRESTJudgeRun(target),
+		Cases:  diffs,
+	}), nil
+}
+
+func (s *server) fetchJudgeRunWithCases(submissionID, runID int32) (database.JudgeRun, []database.SubmissionTestcaseResult, error) {
+	run, err := database.FetchJudgeRun(s.db, runID)
+	if err == database.ErrNotExist || (err == nil && run.SubmissionID != submissionID) {
+		return database.JudgeRun{}, nil, newHTTPError(http.StatusNotFound, fmt.Sprintf("judge run %d not found", runID))
+	} else if err != nil {
+		return database.JudgeRun{}, nil, newHTTPError(http.StatusInternalServerError, "failed to fetch judge run")
+	}
+	cases, err := database.FetchRunTestcaseResults(s.db, runID)
+	if err != nil {
+		return database.JudgeRun{}, nil, newHTTPError(http.StatusInternalServerError, "failed to fetch cases")
+	}
+	return run, cases, nil
+}
+
+func toRESTCaseResult(c database.SubmissionTestcaseResult) restapi.SubmissionCaseResult {
+	var stderr *[]byte
+	if len(c.Stderr) > 0 {
+		b := c.Stderr
+		stderr = &b
+	}
+	var checker *[]byte
+	if len(c.CheckerOut) > 0 {
+		b := c.CheckerOut
+		checker = &b
+	}
+	return restapi.SubmissionCaseResult{
+		Case:       c.Testcase,
+		Status:     c.Status,
+		Time:       float32(c.Time) / 1000.0,
+		Memory:     c.Memory,
+		Stderr:     stderr,
+		CheckerOut: checker,
+	}
+}
+
+func toRESTJudgeRun(r database.JudgeRun) restapi.JudgeRun {
+	run := restapi.JudgeRun{
+		Id:               r.ID,
+		Status:           r.Status,
+		TestcasesVersion: r.TestCasesVersion,
+		Judge:            r.Judge,
+		Time:             float32(r.MaxTime) / 1000.0,
+		Memory:           r.MaxMemory,
+		StartTime:        r.StartTime,
+	}
+	if r.EndTime.Valid {
+		t := r.EndTime.Time
+		run.EndTime = &t
+	}
+	return run
+}
+
 // PostRejudge handles POST /submissions/{id}/rejudge
 func (s *server) PostRejudge(ctx context.Context, request restapi.PostRejudgeRequestObject) (restapi.PostRejudgeResponseObject, error) {
 	currentUser, err := s.currentUserFromContext(ctx)
@@ -178,7 +281,7 @@ func (s *server) GetSubmissionList(_ context.Context, request restapi.GetSubmiss
 		limit = int(*request.Params.Limit)
 	}
 	if limit > 1000 {
-		return nil, newHTTPError(http.StatusBadRequest, "limit must not be greater than 1000")
+		return nil, newFieldError("limit", "limit must not be greater than 1000")
 	}
 	order := ""
 	if request.Params.Order != nil {
@@ -191,17 +294,35 @@ func (s *server) GetSubmissionList(_ context.Context, request restapi.GetSubmiss
 	case "+time":
 		dbOrder = []database.SubmissionOrder{database.MAX_TIME_ASC, database.ID_DESC}
 	default:
-		return nil, newHTTPError(http.StatusBadRequest, "unknown sort order")
+		return nil, newFieldError("order", "unknown sort order")
+	}
+	filter := database.SubmissionFilter{
+		Problem:   deref(request.Params.Problem),
+		Statuses:  splitList(deref(request.Params.Status)),
+		Langs:     splitList(deref(request.Params.Lang)),
+		User:      deref(request.Params.User),
+		DedupUser: deref(request.Params.DedupUser),
+		Since:     deref(request.Params.Since),
+		Until:     deref(request.Params.Until),
+		IsLatest:  request.Params.IsLatest,
+		Hacked:    request.Params.Hacked,
+	}
+	var after *database.SubmissionCursor
+	if request.Params.Cursor != nil {
+		if request.Params.Skip != nil {
+			return nil, newFieldError("cursor", "cursor cannot be used with skip")
+		}
+		c, err := decodeSubmissionCursor(*request.Params.Cursor, order)
+		if err != nil {
+			return nil, newFieldError("cursor", "invalid cursor")
+		}
+		after = &c
 	}
-	problem := deref(request.Params.Problem)
-	status := deref(request.Params.Status)
-	lang := deref(request.Params.Lang)
-	user := deref(request.Params.User)
-	dedup := false
-	if request.Params.DedupUser != nil {
-		dedup = *request.Params.DedupUser
+	withCount := true
+	if request.Params.WithCount != nil {
+		withCount = *request.Params.WithCount
 	}
-	list, count, err := database.FetchSubmissionList(s.db, problem, status, lang, user, dedup, dbOrder, skip, limit)
+	list, count, err := database.FetchSubmissionPage(s.db, filter, dbOrder, after, skip, limit, withCount)
 	if err != nil {
 		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch submissions")
 	}
@@ -229,10 +350,55 @@ func (s *server) GetSubmissionList(_ context.Context, request restapi.GetSubmiss
 		}
 		overviews = append(overviews, overview)
 	}
-	resp := restapi.SubmissionListResponse{Submissions: overviews, Count: int32(count)}
+	resp := restapi.SubmissionListResponse{Submissions: overviews}
+	if withCount {
+		c := int32(count)
+		resp.Count = &c
+	}
+	if len(list) == limit && limit > 0 {
+		next := encodeSubmissionCursor(database.CursorOf(list[len(list)-1]), order)
+		resp.NextCursor = &next
+	}
 	return restapi.GetSubmissionList200JSONResponse(resp), nil
 }
 
+// encodeSubmissionCursor returns an opaque cursor. It records the order so
+// that it is not used with another order.
+func encodeSubmissionCursor(c database.SubmissionCursor, order string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s,%d,%d", order, c.ID, c.MaxTime)))
+}
+
+func decodeSubmissionCursor(cursor, order string) (database.SubmissionCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return database.SubmissionCursor{}, err
+	}
+	parts := strings.Split(string(b), ",")
+	if len(parts) != 3 || parts[0] != order {
+		return database.SubmissionCursor{}, errors.New("cursor of another order")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return database.SubmissionCursor{}, err
+	}
+	maxTime, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil {
+		return database.SubmissionCursor{}, err
+	}
+	return database.SubmissionCursor{ID: int32(id), MaxTime: int32(maxTime)}, nil
+}
+
+// splitList splits a comma separated query value, dropping empty items.
+func splitList(v string) []string {
+	items := []string{}
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func deref[T any](p *T) T {
 	var zero T
 	if p == nil {
@@ -241,12 +407,13 @@ func deref[T any](p *T) T {
 	return *p
 }
 
-func canRejudgeREST(currentUser database.User, submission database.Submission) bool {
-	if currentUser.Name == "" {
-		return false
+func (s *server) checkPendingTaskLimit(userName string) error {
+	count, err := database.CountUserTasks(s.db, userName)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, "failed to count pending tasks")
 	}
-	if submission.UserName.Valid && currentUser.Name == submission.UserName.String {
-		return true
+	if count >= maxPendingTasksPerUser {
+		return newCodedError(http.StatusTooManyRequests, restapi.TooManyPendingTasks, fmt.Sprintf("too many pending tasks (limit: %d)", maxPendingTasksPerUser))
 	}
-	return submission.TestCasesVersion != submission.Problem.TestCasesVersion && submission.Status == "AC"
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/yosupo06/library-checker-judge/database"
 	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
@@ -314,3 +315,239 @@ func TestPostRejudge_RejectsAnonymous(t *testing.T) {
 		t.Fatalf("expected 401, got %v", err)
 	}
 }
+
+func TestPostSubmit_TooManyPendingTasks(t *testing.T) {
+	db := setupTestDB(t)
+	problem := database.Problem{
+		Name:             "aplusb-pending",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "v1",
+		Version:          "1",
+		OverallVersion:   "1",
+	}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	if err := database.RegisterUser(db, "alice", "uid-pending"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+
+	s := &server{db: db, authClient: fakeAuthClient{uid: "uid-pending"}}
+	req := httptest.NewRequest(http.MethodPost, "/submit", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	ctx := withHTTPRequest(context.Background(), req)
+	submit := func() error {
+		_, err := s.PostSubmit(ctx, restapi.PostSubmitRequestObject{
+			Body: &restapi.PostSubmitJSONRequestBody{
+				Problem: problem.Name,
+				Source:  "#include <bits/stdc++.h>\nint main(){return 0;}",
+				Lang:    "cpp",
+			},
+		})
+		return err
+	}
+
+	for i := 0; i < maxPendingTasksPerUser; i++ {
+		if err := submit(); err != nil {
+			t.Fatalf("PostSubmit #%d returned error: %v", i, err)
+		}
+	}
+	err := submit()
+	if httpErr, ok := getHTTPError(err); !ok || httpErr.Status != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %v", err)
+	}
+}
+
+func TestGetJudgeRunDiff(t *testing.T) {
+	db := setupTestDB(t)
+	problem := database.Problem{
+		Name:             "aplusb-runs",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "v2",
+		Version:          "1",
+		OverallVersion:   "1",
+	}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	id, err := database.SaveSubmission(db, database.Submission{
+		ProblemName: problem.Name,
+		Lang:        "cpp",
+		Status:      "WA",
+		Source:      "#include <bits/stdc++.h>\nint main(){return 0;}",
+		MaxTime:     1,
+		MaxMemory:   1,
+	})
+	if err != nil {
+		t.Fatalf("save submission: %v", err)
+	}
+
+	saveRun := func(version, status string, cases map[string]string) int32 {
+		runID, err := database.SaveJudgeRun(db, database.JudgeRun{
+			SubmissionID:     id,
+			TestCasesVersion: version,
+			Judge:            "judge1",
+			Status:           status,
+			StartTime:        time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("save judge run: %v", err)
+		}
+		var results []database.SubmissionTestcaseResult
+		for name, st := range cases {
+			results = append(results, database.SubmissionTestcaseResult{Submission: id, RunID: runID, Testcase: name, Status: st})
+		}
+		if err := database.SaveTestcaseResults(db, results); err != nil {
+			t.Fatalf("save results: %v", err)
+		}
+		return runID
+	}
+	base := saveRun("v1", "AC", map[string]string{"a": "AC", "b": "AC", "old": "AC"})
+	target := saveRun("v2", "WA", map[string]string{"a": "AC", "b": "WA", "new": "AC"})
+
+	s := &server{db: db}
+	infoObj, err := s.GetSubmissionInfo(context.Background(), restapi.GetSubmissionInfoRequestObject{Id: id})
+	if err != nil {
+		t.Fatalf("GetSubmissionInfo returned error: %v", err)
+	}
+	info := infoObj.(restapi.GetSubmissionInfo200JSONResponse)
+	if info.Runs == nil || len(*info.Runs) != 2 || (*info.Runs)[0].Id != base || (*info.Runs)[1].TestcasesVersion != "v2" {
+		t.Fatalf("unexpected runs: %+v", info.Runs)
+	}
+	if info.CaseResults == nil || len(*info.CaseResults) != 3 {
+		t.Fatalf("expected case results of the latest run: %+v", info.CaseResults)
+	}
+
+	respObj, err := s.GetJudgeRunDiff(context.Background(), restapi.GetJudgeRunDiffRequestObject{
+		Id:     id,
+		Params: restapi.GetJudgeRunDiffParams{Base: base, Target: target},
+	})
+	if err != nil {
+		t.Fatalf("GetJudgeRunDiff returned error: %v", err)
+	}
+	resp := respObj.(restapi.GetJudgeRunDiff200JSONResponse)
+	changed := map[string]bool{}
+	for _, c := range resp.Cases {
+		changed[c.Case] = c.Changed
+	}
+	expected := map[string]bool{"a": false, "b": true, "old": true, "new": true}
+	if len(changed) != len(expected) {
+		t.Fatalf("unexpected cases: %+v", resp.Cases)
+	}
+	for name, want := range expected {
+		if got, ok := changed[name]; !ok || got != want {
+			t.Fatalf("case %s: changed=%v, want %v", name, got, want)
+		}
+	}
+
+	// runs of other submissions are not found
+	_, err = s.GetJudgeRunDiff(context.Background(), restapi.GetJudgeRunDiffRequestObject{
+		Id:     id + 1,
+		Params: restapi.GetJudgeRunDiffParams{Base: base, Target: target},
+	})
+	if httpErr, ok := getHTTPError(err); !ok || httpErr.Status != http.StatusNotFound {
+		t.Fatalf("expected 404, got %v", err)
+	}
+}
+
+func TestGetSubmissionList_CursorAndFilters(t *testing.T) {
+	db := setupTestDB(t)
+	problem := database.Problem{
+		Name:             "aplusb-list",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "v2",
+		Version:          "1",
+		OverallVersion:   "1",
+	}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	statuses := []string{"AC", "WA", "TLE", "AC", "WA"}
+	for i, status := range statuses {
+		version := "v2"
+		if i == 0 {
+			version = "v1"
+		}
+		if _, err := database.SaveSubmission(db, database.Submission{
+			SubmissionTime:   time.Now(),
+			ProblemName:      problem.Name,
+			Lang:             "cpp",
+			Status:           status,
+			TestCasesVersion: version,
+			Source:           "int main() {}",
+			MaxTime:          int32(i),
+			MaxMemory:        1,
+		}); err != nil {
+			t.Fatalf("save submission: %v", err)
+		}
+	}
+
+	s := &server{db: db}
+	list := func(params restapi.GetSubmissionListParams) restapi.SubmissionListResponse {
+		t.Helper()
+		respObj, err := s.GetSubmissionList(context.Background(), restapi.GetSubmissionListRequestObject{Params: params})
+		if err != nil {
+			t.Fatalf("GetSubmissionList returned error: %v", err)
+		}
+		return restapi.SubmissionListResponse(respObj.(restapi.GetSubmissionList200JSONResponse))
+	}
+	ptr := func(v string) *string { return &v }
+	limit := int32(2)
+
+	seen := []int32{}
+	params := restapi.GetSubmissionListParams{Problem: ptr(problem.Name), Limit: &limit}
+	for page := 0; ; page++ {
+		resp := list(params)
+		if page == 0 && (resp.Count == nil || *resp.Count != 5) {
+			t.Fatalf("expected count 5 on the first page, got %v", resp.Count)
+		}
+		for _, sub := range resp.Submissions {
+			seen = append(seen, sub.Id)
+		}
+		if resp.NextCursor == nil {
+			break
+		}
+		params.Cursor = resp.NextCursor
+	}
+	if len(seen) != 5 {
+		t.Fatalf("expected 5 submissions over the pages, got %v", seen)
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i] >= seen[i-1] {
+			t.Fatalf("pages must be in descending id order: %v", seen)
+		}
+	}
+
+	noCount := false
+	resp := list(restapi.GetSubmissionListParams{Problem: ptr(problem.Name), Status: ptr("AC,WA"), WithCount: &noCount})
+	if resp.Count != nil || len(resp.Submissions) != 4 {
+		t.Fatalf("expected 4 AC/WA submissions without count, got %d (count %v)", len(resp.Submissions), resp.Count)
+	}
+
+	isLatest := false
+	resp = list(restapi.GetSubmissionListParams{Problem: ptr(problem.Name), IsLatest: &isLatest})
+	if len(resp.Submissions) != 1 || resp.Submissions[0].IsLatest {
+		t.Fatalf("expected the single outdated submission, got %+v", resp.Submissions)
+	}
+
+	// a cursor of another order or with skip is rejected
+	order := restapi.PlusTime
+	first := list(restapi.GetSubmissionListParams{Problem: ptr(problem.Name), Limit: &limit})
+	skip := int32(1)
+	for name, params := range map[string]restapi.GetSubmissionListParams{
+		"other order": {Cursor: first.NextCursor, Order: &order},
+		"with skip":   {Cursor: first.NextCursor, Skip: &skip},
+		"garbage":     {Cursor: ptr("!!")},
+	} {
+		_, err := s.GetSubmissionList(context.Background(), restapi.GetSubmissionListRequestObject{Params: params})
+		if httpErr, ok := getHTTPError(err); !ok || httpErr.Status != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %v", name, err)
+		}
+	}
+}
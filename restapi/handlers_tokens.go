@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+const (
+	maxAPITokensPerUser = 20
+	maxAPITokenName     = 100
+)
+
+// GetAPITokens handles GET /auth/tokens
+func (s *server) GetAPITokens(ctx context.Context, _ restapi.GetAPITokensRequestObject) (restapi.GetAPITokensResponseObject, error) {
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := database.FetchAPITokens(s.db, user.Name)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch tokens")
+	}
+	resp := restapi.APITokenListResponse{Tokens: make([]restapi.APIToken, 0, len(tokens))}
+	for _, t := range tokens {
+		resp.Tokens = append(resp.Tokens, toRESTAPIToken(t))
+	}
+	return restapi.GetAPITokens200JSONResponse(resp), nil
+}
+
+// PostAPIToken handles POST /auth/tokens
+func (s *server) PostAPIToken(ctx context.Context, request restapi.PostAPITokenRequestObject) (restapi.PostAPITokenResponseObject, error) {
+	if request.Body == nil {
+		return nil, newHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	body := request.Body
+	if body.Name == "" || utf8.RuneCountInString(body.Name) > maxAPITokenName {
+		return nil, newFieldError("name", "invalid token name")
+	}
+	if len(body.Scopes) == 0 {
+		return nil, newFieldError("scopes", "scopes must not be empty")
+	}
+	scopes := []string{}
+	for _, scope := range body.Scopes {
+		if !scope.Valid() {
+			return nil, newFieldError("scopes", fmt.Sprintf("unknown scope: %s", scope))
+		}
+		if !slices.Contains(scopes, string(scope)) {
+			scopes = append(scopes, string(scope))
+		}
+	}
+	if body.ExpiresInDays != nil && *body.ExpiresInDays <= 0 {
+		return nil, newFieldError("expires_in_days", "expires_in_days must be positive")
+	}
+
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := database.FetchAPITokens(s.db, user.Name)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch tokens")
+	}
+	if len(tokens) >= maxAPITokensPerUser {
+		return nil, newCodedError(http.StatusBadRequest, restapi.LimitExceeded, fmt.Sprintf("too many tokens (limit: %d)", maxAPITokensPerUser))
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to generate token")
+	}
+	now := time.Now()
+	token := database.APIToken{
+		UserName:  user.Name,
+		Name:      body.Name,
+		TokenHash: hashAPIToken(secret),
+		Prefix:    secret[:len(apiTokenPrefix)+6],
+		Scopes:    strings.Join(scopes, " "),
+		CreatedAt: now,
+	}
+	if body.ExpiresInDays != nil {
+		token.ExpiresAt = sql.NullTime{Time: now.AddDate(0, 0, int(*body.ExpiresInDays)), Valid: true}
+	}
+	id, err := database.SaveAPIToken(s.db, token)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to save token")
+	}
+	token.ID = id
+
+	return restapi.PostAPIToken200JSONResponse(restapi.CreateAPITokenResponse{
+		Token:  toRESTAPIToken(token),
+		Secret: secret,
+	}), nil
+}
+
+// DeleteAPIToken handles DELETE /auth/tokens/{id}
+func (s *server) DeleteAPIToken(ctx context.Context, request restapi.DeleteAPITokenRequestObject) (restapi.DeleteAPITokenResponseObject, error) {
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.RevokeAPIToken(s.db, user.Name, request.Id); err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "token not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to revoke token")
+	}
+	return restapi.DeleteAPIToken200JSONResponse(restapi.RevokeAPITokenResponse{}), nil
+}
+
+func (s *server) registeredUserFromContext(ctx context.Context) (*database.User, error) {
+	uid, err := s.uidFromContext(ctx)
+	if err != nil || uid == "" {
+		return nil, newHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	user, err := database.FetchUserFromUID(s.db, uid)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch user")
+	}
+	if user == nil {
+		return nil, newCodedError(http.StatusForbidden, restapi.UserNotRegistered, "user is not registered")
+	}
+	return user, nil
+}
+
+func toRESTAPIToken(t database.APIToken) restapi.APIToken {
+	scopes := []restapi.APITokenScope{}
+	for _, scope := range strings.Fields(t.Scopes) {
+		scopes = append(scopes, restapi.APITokenScope(scope))
+	}
+	token := restapi.APIToken{
+		Id:        t.ID,
+		Name:      t.Name,
+		Prefix:    t.Prefix,
+		Scopes:    scopes,
+		CreatedAt: t.CreatedAt,
+	}
+	if t.ExpiresAt.Valid {
+		v := t.ExpiresAt.Time
+		token.ExpiresAt = &v
+	}
+	if t.LastUsedAt.Valid {
+		v := t.LastUsedAt.Time
+		token.LastUsedAt = &v
+	}
+	return token
+}
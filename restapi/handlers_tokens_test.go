@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func doJSON(t *testing.T, h http.Handler, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAPITokens(t *testing.T) {
+	db := setupTestDB(t)
+	problem := database.Problem{
+		Name:             "aplusb-token",
+		Title:            "A + B",
+		SourceUrl:        "https://example.com/aplusb",
+		Timelimit:        2000,
+		TestCasesVersion: "v1",
+		Version:          "1",
+		OverallVersion:   "1",
+	}
+	if err := database.SaveProblem(db, problem); err != nil {
+		t.Fatalf("save problem: %v", err)
+	}
+	if err := database.RegisterUser(db, "alice", "uid-alice"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+
+	s := &server{db: db, authClient: fakeAuthClient{uid: "uid-alice"}}
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+
+	// create a token with a Firebase ID token
+	rec := doJSON(t, r, http.MethodPost, "/auth/tokens", "firebase-token", restapi.CreateAPITokenRequest{
+		Name:   "library ci",
+		Scopes: []restapi.APITokenScope{restapi.Submit, restapi.Submit},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("create token: %d %s", rec.Code, rec.Body.String())
+	}
+	var created restapi.CreateAPITokenResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.HasPrefix(created.Secret, apiTokenPrefix) || len(created.Token.Scopes) != 1 || !strings.HasPrefix(created.Secret, created.Token.Prefix) {
+		t.Fatalf("unexpected token: %+v", created)
+	}
+	if _, err := database.FetchAPITokenFromHash(db, created.Secret); err == nil {
+		t.Fatal("the secret must not be stored in plain text")
+	}
+
+	// submit with the API token
+	rec = doJSON(t, r, http.MethodPost, "/submit", created.Secret, restapi.SubmitRequest{
+		Problem: problem.Name,
+		Source:  "#include <bits/stdc++.h>\nint main(){return 0;}",
+		Lang:    "cpp",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("submit: %d %s", rec.Code, rec.Body.String())
+	}
+	var submitted restapi.SubmitResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	sub, err := database.FetchSubmission(db, submitted.Id)
+	if err != nil {
+		t.Fatalf("fetch submission: %v", err)
+	}
+	if !sub.UserName.Valid || sub.UserName.String != "alice" {
+		t.Fatalf("expected user alice, got %+v", sub.UserName)
+	}
+
+	// the token has no hack scope and cannot manage tokens
+	rec = doJSON(t, r, http.MethodPost, "/hacks", created.Secret, restapi.CreateHackRequest{Submission: submitted.Id})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("hack with submit token: expected 401, got %d", rec.Code)
+	}
+	rec = doJSON(t, r, http.MethodGet, "/auth/tokens", created.Secret, nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("list with api token: expected 401, got %d", rec.Code)
+	}
+
+	rec = doJSON(t, r, http.MethodGet, "/auth/tokens", "firebase-token", nil)
+	var list restapi.APITokenListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Tokens) != 1 || list.Tokens[0].LastUsedAt == nil {
+		t.Fatalf("unexpected token list: %s", rec.Body.String())
+	}
+
+	// revoked tokens are rejected instead of submitting anonymously
+	rec = doJSON(t, r, http.MethodDelete, fmt.Sprintf("/auth/tokens/%d", created.Token.Id), "firebase-token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke: %d %s", rec.Code, rec.Body.String())
+	}
+	rec = doJSON(t, r, http.MethodPost, "/submit", created.Secret, restapi.SubmitRequest{
+		Problem: problem.Name,
+		Source:  "#include <bits/stdc++.h>\nint main(){return 0;}",
+		Lang:    "cpp",
+	})
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("submit with revoked token: expected 401, got %d", rec.Code)
+	}
+}
+
+func TestPostAPIToken_RequiresRegisteredUser(t *testing.T) {
+	db := setupTestDB(t)
+	s := &server{db: db, authClient: fakeAuthClient{uid: "uid-unknown"}}
+	r := chi.NewRouter()
+	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+
+	rec := doJSON(t, r, http.MethodPost, "/auth/tokens", "firebase-token", restapi.CreateAPITokenRequest{
+		Name:   "ci",
+		Scopes: []restapi.APITokenScope{restapi.Submit},
+	})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
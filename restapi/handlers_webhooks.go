@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+const (
+	maxWebhooksPerUser = 10
+	maxWebhookURL      = 2000
+)
+
+// GetWebhooks handles GET /webhooks
+func (s *server) GetWebhooks(ctx context.Context, _ restapi.GetWebhooksRequestObject) (restapi.GetWebhooksResponseObject, error) {
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	webhooks, err := database.FetchWebhooks(s.db, user.Name)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch webhooks")
+	}
+	resp := restapi.WebhookListResponse{Webhooks: make([]restapi.Webhook, 0, len(webhooks))}
+	for _, w := range webhooks {
+		resp.Webhooks = append(resp.Webhooks, toRESTWebhook(w))
+	}
+	return restapi.GetWebhooks200JSONResponse(resp), nil
+}
+
+// PostWebhook handles POST /webhooks
+func (s *server) PostWebhook(ctx context.Context, request restapi.PostWebhookRequestObject) (restapi.PostWebhookResponseObject, error) {
+	if request.Body == nil {
+		return nil, newHTTPError(http.StatusBadRequest, "invalid request")
+	}
+	body := request.Body
+	if err := s.validateWebhookURL(body.Url); err != nil {
+		return nil, newFieldError("url", err.Error())
+	}
+	if len(body.Events) == 0 {
+		return nil, newFieldError("events", "events must not be empty")
+	}
+	events := []string{}
+	for _, event := range body.Events {
+		if !event.Valid() {
+			return nil, newFieldError("events", fmt.Sprintf("unknown event: %s", event))
+		}
+		if !slices.Contains(events, string(event)) {
+			events = append(events, string(event))
+		}
+	}
+
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allUsers := deref(body.AllUsers)
+	if allUsers && !hasPermission(user, permAllUsersWebhooks) {
+		return nil, newHTTPError(http.StatusForbidden, "permission denied")
+	}
+	webhooks, err := database.FetchWebhooks(s.db, user.Name)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch webhooks")
+	}
+	if len(webhooks) >= maxWebhooksPerUser {
+		return nil, newCodedError(http.StatusBadRequest, restapi.LimitExceeded, fmt.Sprintf("too many webhooks (limit: %d)", maxWebhooksPerUser))
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to generate secret")
+	}
+	w := database.Webhook{
+		UserName:  user.Name,
+		URL:       body.Url,
+		Secret:    secret,
+		Events:    strings.Join(events, " "),
+		AllUsers:  allUsers,
+		CreatedAt: time.Now(),
+	}
+	id, err := database.SaveWebhook(s.db, w)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to save webhook")
+	}
+	w.ID = id
+
+	return restapi.PostWebhook200JSONResponse(restapi.CreateWebhookResponse{
+		Webhook: toRESTWebhook(w),
+		Secret:  secret,
+	}), nil
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id}
+func (s *server) DeleteWebhook(ctx context.Context, request restapi.DeleteWebhookRequestObject) (restapi.DeleteWebhookResponseObject, error) {
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.DeleteWebhook(s.db, user.Name, request.Id); err != nil {
+		if errors.Is(err, database.ErrNotExist) {
+			return nil, newHTTPError(http.StatusNotFound, "webhook not found")
+		}
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to delete webhook")
+	}
+	return restapi.DeleteWebhook200JSONResponse(restapi.DeleteWebhookResponse{}), nil
+}
+
+// GetWebhookDeliveries handles GET /webhooks/{id}/deliveries
+func (s *server) GetWebhookDeliveries(ctx context.Context, request restapi.GetWebhookDeliveriesRequestObject) (restapi.GetWebhookDeliveriesResponseObject, error) {
+	limit := 20
+	if request.Params.Limit != nil {
+		limit = int(*request.Params.Limit)
+	}
+	if limit < 1 || limit > 100 {
+		return nil, newFieldError("limit", "limit must be in [1, 100]")
+	}
+	user, err := s.registeredUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := database.FetchWebhook(s.db, request.Id)
+	if errors.Is(err, database.ErrNotExist) || (err == nil && w.UserName != user.Name) {
+		return nil, newHTTPError(http.StatusNotFound, "webhook not found")
+	} else if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch webhook")
+	}
+	deliveries, err := database.FetchWebhookDeliveries(s.db, w.ID, limit)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, "failed to fetch deliveries")
+	}
+	resp := restapi.WebhookDeliveryListResponse{Deliveries: make([]restapi.WebhookDelivery, 0, len(deliveries))}
+	for _, d := range deliveries {
+		delivery := restapi.WebhookDelivery{
+			Id:        d.ID,
+			Event:     restapi.WebhookEvent(d.Event),
+			Payload:   d.Payload,
+			Status:    restapi.WebhookDeliveryStatus(d.Status),
+			Attempts:  d.Attempts,
+			CreatedAt: d.CreatedAt,
+		}
+		if d.ResponseCode != 0 {
+			v := d.ResponseCode
+			delivery.ResponseCode = &v
+		}
+		if d.LastError != "" {
+			v := d.LastError
+			delivery.Error = &v
+		}
+		if d.LastAttemptAt.Valid {
+			v := d.LastAttemptAt.Time
+			delivery.LastAttemptAt = &v
+		}
+		if d.Status == database.DeliveryPending {
+			v := d.NextAttemptAt
+			delivery.NextAttemptAt = &v
+		}
+		resp.Deliveries = append(resp.Deliveries, delivery)
+	}
+	return restapi.GetWebhookDeliveries200JSONResponse(resp), nil
+}
+
+// validateWebhookURL rejects URLs the dispatcher must not send to. Names
+// resolving to internal addresses are rejected again when connecting.
+func (s *server) validateWebhookURL(raw string) error {
+	if raw == "" || len(raw) > maxWebhookURL {
+		return errors.New("invalid url")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return errors.New("invalid url")
+	}
+	if s.webhookAllowPrivate {
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return errors.New("url must be http or https")
+		}
+		return nil
+	}
+	if u.Scheme != "https" {
+		return errors.New("url must be https")
+	}
+	host := u.Hostname()
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return errors.New("url must be a public host")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicAddr(addr) {
+		return errors.New("url must be a public host")
+	}
+	return nil
+}
+
+func toRESTWebhook(w database.Webhook) restapi.Webhook {
+	events := []restapi.WebhookEvent{}
+	for _, event := range strings.Fields(w.Events) {
+		events = append(events, restapi.WebhookEvent(event))
+	}
+	return restapi.Webhook{
+		Id:        w.ID,
+		Url:       w.URL,
+		Events:    events,
+		AllUsers:  w.AllUsers,
+		CreatedAt: w.CreatedAt,
+	}
+}
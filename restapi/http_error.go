@@ -1,14 +1,24 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
 )
 
-// HTTPError decorates an error with an HTTP status code.
+// HTTPError decorates an error with an HTTP status code. It is written as an
+// RFC 7807 problem details body by writeHTTPError.
 type HTTPError struct {
 	Status  int
 	Message string
+	// Code is the machine-readable error code, derived from Status if empty
+	Code   restapi.ErrorCode
+	Fields []restapi.FieldError
 }
 
 func (e HTTPError) Error() string {
@@ -18,10 +28,26 @@ func (e HTTPError) Error() string {
 	return fmt.Sprintf("http status %d", e.Status)
 }
 
+// withField returns a copy of e reporting the invalid request field.
+func (e HTTPError) withField(field, reason string) HTTPError {
+	e.Fields = append(append([]restapi.FieldError{}, e.Fields...), restapi.FieldError{Field: field, Reason: reason})
+	return e
+}
+
 func newHTTPError(status int, message string) error {
 	return HTTPError{Status: status, Message: message}
 }
 
+// newCodedError returns an HTTPError with a code more specific than the default one of the status.
+func newCodedError(status int, code restapi.ErrorCode, message string) HTTPError {
+	return HTTPError{Status: status, Message: message, Code: code}
+}
+
+// newFieldError returns a 400 error caused by the value of a request field.
+func newFieldError(field, message string) HTTPError {
+	return HTTPError{Status: http.StatusBadRequest, Message: message, Code: restapi.InvalidParameter}.withField(field, message)
+}
+
 func getHTTPError(err error) (HTTPError, bool) {
 	if err == nil {
 		return HTTPError{}, false
@@ -33,5 +59,102 @@ func getHTTPError(err error) (HTTPError, bool) {
 	if httpErr.Status == 0 {
 		httpErr.Status = 500
 	}
+	if httpErr.Code == "" {
+		httpErr.Code = defaultErrorCode(httpErr.Status)
+	}
 	return httpErr, true
 }
+
+func defaultErrorCode(status int) restapi.ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return restapi.InvalidRequest
+	case http.StatusUnauthorized:
+		return restapi.Unauthorized
+	case http.StatusForbidden:
+		return restapi.Forbidden
+	case http.StatusNotFound:
+		return restapi.NotFound
+	case http.StatusMethodNotAllowed:
+		return restapi.MethodNotAllowed
+	case http.StatusConflict:
+		return restapi.Conflict
+	case http.StatusTooManyRequests:
+		return restapi.TooManyRequests
+	case http.StatusServiceUnavailable:
+		return restapi.ServiceUnavailable
+	}
+	if status >= 500 {
+		return restapi.InternalError
+	}
+	return restapi.InvalidRequest
+}
+
+// writeHTTPError writes err as application/problem+json. Errors other than
+// HTTPError are logged and hidden behind a 500.
+func writeHTTPError(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr, ok := getHTTPError(err)
+	if !ok {
+		slog.Error("handler error", "path", r.URL.Path, "error", err)
+		httpErr, _ = getHTTPError(newHTTPError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)))
+	}
+	body := restapi.ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(httpErr.Status),
+		Status:   int32(httpErr.Status),
+		Code:     httpErr.Code,
+		Detail:   &httpErr.Message,
+		Instance: &r.URL.Path,
+	}
+	if httpErr.Message == "" {
+		body.Detail = nil
+	}
+	if len(httpErr.Fields) > 0 {
+		body.Errors = &httpErr.Fields
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpErr.Status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// requestError converts the errors of decoding a request (parameters and
+// JSON bodies) into a 400 HTTPError naming the offending parameter.
+func requestError(err error) HTTPError {
+	var (
+		invalidFormat *restapi.InvalidParamFormatError
+		required      *restapi.RequiredParamError
+		unmarshaling  *restapi.UnmarshalingParamError
+		tooMany       *restapi.TooManyValuesForParamError
+	)
+	switch {
+	case errors.As(err, &invalidFormat):
+		return newFieldError(invalidFormat.ParamName, fmt.Sprintf("invalid format for parameter %s", invalidFormat.ParamName))
+	case errors.As(err, &required):
+		return newFieldError(required.ParamName, fmt.Sprintf("parameter %s is required", required.ParamName))
+	case errors.As(err, &unmarshaling):
+		return newFieldError(unmarshaling.ParamName, fmt.Sprintf("invalid value for parameter %s", unmarshaling.ParamName))
+	case errors.As(err, &tooMany):
+		return newFieldError(tooMany.ParamName, fmt.Sprintf("too many values for parameter %s", tooMany.ParamName))
+	}
+	return newCodedError(http.StatusBadRequest, restapi.InvalidRequest, err.Error())
+}
+
+// requireFields returns a 400 error listing the empty fields, or nil.
+func requireFields(fields map[string]string) error {
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if value == "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	slices.Sort(names)
+	e := newCodedError(http.StatusBadRequest, restapi.InvalidParameter, "missing required fields")
+	for _, name := range names {
+		e = e.withField(name, "required")
+	}
+	return e
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func decodeProblemDetails(t *testing.T, rec *httptest.ResponseRecorder) restapi.ProblemDetails {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("unexpected content type %q: %s", ct, rec.Body.String())
+	}
+	var body restapi.ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v %s", err, rec.Body.String())
+	}
+	if int(body.Status) != rec.Code || body.Title != http.StatusText(rec.Code) || body.Type != "about:blank" {
+		t.Fatalf("inconsistent problem details: %d %+v", rec.Code, body)
+	}
+	return body
+}
+
+func TestProblemDetails(t *testing.T) {
+	db := setupTestDB(t)
+	createTestSubmission(t, db, "aplusb")
+	h := newRouterAs(db, "")
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		status int
+		code   restapi.ErrorCode
+		field  string
+	}{
+		{"unknown language", http.MethodPost, "/submit", map[string]string{"problem": "aplusb", "lang": "brainfuck", "source": "x"}, http.StatusBadRequest, restapi.UnknownLanguage, "lang"},
+		{"unknown problem", http.MethodPost, "/submit", map[string]string{"problem": "nothing", "lang": "cpp", "source": "x"}, http.StatusBadRequest, restapi.UnknownProblem, "problem"},
+		{"missing field", http.MethodPost, "/submit", map[string]string{"problem": "aplusb", "lang": "cpp"}, http.StatusBadRequest, restapi.InvalidParameter, "source"},
+		{"invalid path parameter", http.MethodGet, "/submissions/abc", nil, http.StatusBadRequest, restapi.InvalidParameter, "id"},
+		{"invalid query parameter", http.MethodGet, "/submissions?limit=x", nil, http.StatusBadRequest, restapi.InvalidParameter, "limit"},
+		{"limit", http.MethodGet, "/submissions?limit=1001", nil, http.StatusBadRequest, restapi.InvalidParameter, "limit"},
+		{"problem not found", http.MethodGet, "/problems/nothing", nil, http.StatusNotFound, restapi.ProblemNotFound, ""},
+		{"unauthorized", http.MethodGet, "/auth/tokens", nil, http.StatusUnauthorized, restapi.Unauthorized, ""},
+		{"unknown route", http.MethodGet, "/nothing", nil, http.StatusNotFound, restapi.NotFound, ""},
+		{"method not allowed", http.MethodPut, "/submit", nil, http.StatusMethodNotAllowed, restapi.MethodNotAllowed, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := doJSON(t, h, tc.method, tc.path, "", tc.body)
+			if rec.Code != tc.status {
+				t.Fatalf("expected %d, got %d %s", tc.status, rec.Code, rec.Body.String())
+			}
+			body := decodeProblemDetails(t, rec)
+			if body.Code != tc.code || deref(body.Instance) != strings.Split(tc.path, "?")[0] {
+				t.Fatalf("unexpected problem details: %+v", body)
+			}
+			if tc.field != "" && (body.Errors == nil || (*body.Errors)[0].Field != tc.field) {
+				t.Fatalf("expected an error of field %s: %s", tc.field, rec.Body.String())
+			}
+		})
+	}
+
+	// bodies that are not JSON
+	req := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader("{"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if body := decodeProblemDetails(t, rec); rec.Code != http.StatusBadRequest || body.Code != restapi.InvalidRequest {
+		t.Fatalf("unexpected response to a broken body: %d %+v", rec.Code, body)
+	}
+}
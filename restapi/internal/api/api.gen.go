@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -23,9 +24,91 @@ import (
 )
 
 const (
+	ApiTokenScopes     apiTokenContextKey     = "apiToken.Scopes"
 	FirebaseAuthScopes firebaseAuthContextKey = "firebaseAuth.Scopes"
 )
 
+// Defines values for APITokenScope.
+const (
+	Hack   APITokenScope = "hack"
+	Submit APITokenScope = "submit"
+)
+
+// Valid indicates whether the value is a known member of the APITokenScope enum.
+func (e APITokenScope) Valid() bool {
+	switch e {
+	case Hack:
+		return true
+	case Submit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ErrorCode.
+const (
+	Conflict            ErrorCode = "conflict"
+	Forbidden           ErrorCode = "forbidden"
+	InternalError       ErrorCode = "internal_error"
+	InvalidApiToken     ErrorCode = "invalid_api_token"
+	InvalidParameter    ErrorCode = "invalid_parameter"
+	InvalidRequest      ErrorCode = "invalid_request"
+	LimitExceeded       ErrorCode = "limit_exceeded"
+	MethodNotAllowed    ErrorCode = "method_not_allowed"
+	NotFound            ErrorCode = "not_found"
+	ProblemNotFound     ErrorCode = "problem_not_found"
+	ServiceUnavailable  ErrorCode = "service_unavailable"
+	TooManyPendingTasks ErrorCode = "too_many_pending_tasks"
+	TooManyRequests     ErrorCode = "too_many_requests"
+	Unauthorized        ErrorCode = "unauthorized"
+	UnknownLanguage     ErrorCode = "unknown_language"
+	UnknownProblem      ErrorCode = "unknown_problem"
+	UserNotRegistered   ErrorCode = "user_not_registered"
+)
+
+// Valid indicates whether the value is a known member of the ErrorCode enum.
+func (e ErrorCode) Valid() bool {
+	switch e {
+	case Conflict:
+		return true
+	case Forbidden:
+		return true
+	case InternalError:
+		return true
+	case InvalidApiToken:
+		return true
+	case InvalidParameter:
+		return true
+	case InvalidRequest:
+		return true
+	case LimitExceeded:
+		return true
+	case MethodNotAllowed:
+		return true
+	case NotFound:
+		return true
+	case ProblemNotFound:
+		return true
+	case ServiceUnavailable:
+		return true
+	case TooManyPendingTasks:
+		return true
+	case TooManyRequests:
+		return true
+	case Unauthorized:
+		return true
+	case UnknownLanguage:
+		return true
+	case UnknownProblem:
+		return true
+	case UserNotRegistered:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for SolvedStatus.
 const (
 	AC       SolvedStatus = "AC"
@@ -62,6 +145,171 @@ func (e SubmissionOrder) Valid() bool {
 	}
 }
 
+// Defines values for UserRole.
+const (
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleUser      UserRole = "user"
+)
+
+// Valid indicates whether the value is a known member of the UserRole enum.
+func (e UserRole) Valid() bool {
+	switch e {
+	case UserRoleAdmin:
+		return true
+	case UserRoleModerator:
+		return true
+	case UserRoleUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+	Succeeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Valid indicates whether the value is a known member of the WebhookDeliveryStatus enum.
+func (e WebhookDeliveryStatus) Valid() bool {
+	switch e {
+	case Failed:
+		return true
+	case Pending:
+		return true
+	case Succeeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookEvent.
+const (
+	HackFinished       WebhookEvent = "hack.finished"
+	SubmissionFinished WebhookEvent = "submission.finished"
+)
+
+// Valid indicates whether the value is a known member of the WebhookEvent enum.
+func (e WebhookEvent) Valid() bool {
+	switch e {
+	case HackFinished:
+		return true
+	case SubmissionFinished:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         int32      `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Name       string     `json:"name"`
+
+	// Prefix First characters of the secret to identify the token.
+	Prefix string          `json:"prefix"`
+	Scopes []APITokenScope `json:"scopes"`
+}
+
+// APITokenListResponse defines model for APITokenListResponse.
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+// APITokenScope submit allows submitting and rejudging, hack allows creating hacks.
+type APITokenScope string
+
+// AdminUserListResponse defines model for AdminUserListResponse.
+type AdminUserListResponse struct {
+	Users []User `json:"users"`
+}
+
+// AutoRejudgeProgress defines model for AutoRejudgeProgress.
+type AutoRejudgeProgress struct {
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+	Problem          string     `json:"problem"`
+	Queued           int32      `json:"queued"`
+	StartedAt        time.Time  `json:"started_at"`
+	TestCasesVersion string     `json:"test_cases_version"`
+
+	// Total Outdated AC submissions when the test case update was noticed.
+	Total int32 `json:"total"`
+}
+
+// AutoRejudgeProgressListResponse defines model for AutoRejudgeProgressListResponse.
+type AutoRejudgeProgressListResponse struct {
+	Progresses []AutoRejudgeProgress `json:"progresses"`
+}
+
+// BulkRejudgeJob defines model for BulkRejudgeJob.
+type BulkRejudgeJob struct {
+	Canceled  bool   `json:"canceled"`
+	CreatedBy string `json:"created_by"`
+
+	// Filter Filters of the job in JSON.
+	Filter     string     `json:"filter"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Id         int32      `json:"id"`
+	Priority   int32      `json:"priority"`
+	Queued     int32      `json:"queued"`
+	Rate       int32      `json:"rate"`
+	StartedAt  time.Time  `json:"started_at"`
+
+	// Total Matched submissions when the job was created.
+	Total int32 `json:"total"`
+}
+
+// BulkRejudgeJobListResponse defines model for BulkRejudgeJobListResponse.
+type BulkRejudgeJobListResponse struct {
+	Jobs []BulkRejudgeJob `json:"jobs"`
+}
+
+// BulkRejudgeRequest At least one filter must be given. Filters are combined with AND, and
+// mean the same as the query parameters of GET /submissions.
+type BulkRejudgeRequest struct {
+	DryRun *bool `json:"dry_run,omitempty"`
+	Hacked *bool `json:"hacked,omitempty"`
+
+	// IsLatest Only submissions judged (or not judged) with the current test cases.
+	IsLatest *bool `json:"is_latest,omitempty"`
+
+	// Lang Language, or comma separated languages (e.g. cpp,rust).
+	Lang *string `json:"lang,omitempty"`
+
+	// OutdatedOnly Same as is_latest=false.
+	OutdatedOnly *bool `json:"outdated_only,omitempty"`
+
+	// Priority Task priority (default 5). Lower than rejudges by users (40).
+	Priority *int32  `json:"priority,omitempty"`
+	Problem  *string `json:"problem,omitempty"`
+
+	// Rate Tasks enqueued per second (default 10).
+	Rate  *int32     `json:"rate,omitempty"`
+	Since *time.Time `json:"since,omitempty"`
+
+	// Status Status, or comma separated statuses (e.g. WA,TLE).
+	Status *string    `json:"status,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+	User   *string    `json:"user,omitempty"`
+}
+
+// BulkRejudgeResponse defines model for BulkRejudgeResponse.
+type BulkRejudgeResponse struct {
+	// Count Number of matched submissions.
+	Count  int64 `json:"count"`
+	DryRun bool  `json:"dry_run"`
+
+	// JobId ID of the created job. Not set with dry_run.
+	JobId *int32 `json:"job_id,omitempty"`
+}
+
 // ChangeCurrentUserInfoRequest defines model for ChangeCurrentUserInfoRequest.
 type ChangeCurrentUserInfoRequest struct {
 	User User `json:"user"`
@@ -70,6 +318,21 @@ type ChangeCurrentUserInfoRequest struct {
 // ChangeCurrentUserInfoResponse defines model for ChangeCurrentUserInfoResponse.
 type ChangeCurrentUserInfoResponse = map[string]interface{}
 
+// CreateAPITokenRequest defines model for CreateAPITokenRequest.
+type CreateAPITokenRequest struct {
+	// ExpiresInDays Days until the token expires. The token never expires if omitted.
+	ExpiresInDays *int32          `json:"expires_in_days,omitempty"`
+	Name          string          `json:"name"`
+	Scopes        []APITokenScope `json:"scopes"`
+}
+
+// CreateAPITokenResponse defines model for CreateAPITokenResponse.
+type CreateAPITokenResponse struct {
+	// Secret Bearer token. It cannot be retrieved again.
+	Secret string   `json:"secret"`
+	Token  APIToken `json:"token"`
+}
+
 // CreateHackRequest defines model for CreateHackRequest.
 type CreateHackRequest struct {
 	Submission  int32   `json:"submission"`
@@ -77,11 +340,43 @@ type CreateHackRequest struct {
 	TestCaseTxt *[]byte `json:"test_case_txt,omitempty"`
 }
 
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	AllUsers *bool          `json:"all_users,omitempty"`
+	Events   []WebhookEvent `json:"events"`
+
+	// Url HTTPS URL receiving POST requests.
+	Url string `json:"url"`
+}
+
+// CreateWebhookResponse defines model for CreateWebhookResponse.
+type CreateWebhookResponse struct {
+	// Secret HMAC-SHA256 key. Each request has X-LibraryChecker-Signature:
+	// sha256=hex(HMAC(secret, X-LibraryChecker-Timestamp + "." + body)).
+	// It cannot be retrieved again.
+	Secret  string  `json:"secret"`
+	Webhook Webhook `json:"webhook"`
+}
+
 // CurrentUserInfoResponse defines model for CurrentUserInfoResponse.
 type CurrentUserInfoResponse struct {
 	User *User `json:"user,omitempty"`
 }
 
+// DeleteWebhookResponse defines model for DeleteWebhookResponse.
+type DeleteWebhookResponse = map[string]interface{}
+
+// ErrorCode Stable machine-readable error code. Clients should branch on this
+// instead of the message; new codes may be added.
+type ErrorCode string
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Name of the request body field or parameter.
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
 // HackInfoResponse defines model for HackInfoResponse.
 type HackInfoResponse struct {
 	JudgeOutput *[]byte      `json:"judge_output,omitempty"`
@@ -108,11 +403,53 @@ type HackOverview struct {
 	UserName     *string   `json:"user_name,omitempty"`
 }
 
+// HackRejudgeResponse defines model for HackRejudgeResponse.
+type HackRejudgeResponse = map[string]interface{}
+
 // HackResponse defines model for HackResponse.
 type HackResponse struct {
 	Id int32 `json:"id"`
 }
 
+// HackStatusEvent defines model for HackStatusEvent.
+type HackStatusEvent struct {
+	// Final True if judging finished and no more events follow.
+	Final  bool     `json:"final"`
+	Id     int32    `json:"id"`
+	Memory *int64   `json:"memory,omitempty"`
+	Status string   `json:"status"`
+	Time   *float32 `json:"time,omitempty"`
+}
+
+// JudgeRun defines model for JudgeRun.
+type JudgeRun struct {
+	EndTime          *time.Time `json:"end_time,omitempty"`
+	Id               int32      `json:"id"`
+	Judge            string     `json:"judge"`
+	Memory           int64      `json:"memory"`
+	StartTime        time.Time  `json:"start_time"`
+	Status           string     `json:"status"`
+	TestcasesVersion string     `json:"testcases_version"`
+	Time             float32    `json:"time"`
+}
+
+// JudgeRunCaseDiff defines model for JudgeRunCaseDiff.
+type JudgeRunCaseDiff struct {
+	Base *SubmissionCaseResult `json:"base,omitempty"`
+	Case string                `json:"case"`
+
+	// Changed True if the status differs or the case exists in only one run.
+	Changed bool                  `json:"changed"`
+	Target  *SubmissionCaseResult `json:"target,omitempty"`
+}
+
+// JudgeRunDiffResponse defines model for JudgeRunDiffResponse.
+type JudgeRunDiffResponse struct {
+	Base   JudgeRun           `json:"base"`
+	Cases  []JudgeRunCaseDiff `json:"cases"`
+	Target JudgeRun           `json:"target"`
+}
+
 // Lang defines model for Lang.
 type Lang struct {
 	Id      string `json:"id"`
@@ -153,6 +490,53 @@ type ProblemCategory struct {
 	Title    string        `json:"title"`
 }
 
+// ProblemDetails Error body (RFC 7807, application/problem+json).
+type ProblemDetails struct {
+	// Code Stable machine-readable error code. Clients should branch on this
+	// instead of the message; new codes may be added.
+	Code ErrorCode `json:"code"`
+
+	// Detail Human-readable message, may change.
+	Detail *string       `json:"detail,omitempty"`
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request.
+	Instance *string `json:"instance,omitempty"`
+	Status   int32   `json:"status"`
+
+	// Title Reason phrase of the status.
+	Title string `json:"title"`
+
+	// Type Always about:blank, use code to identify the error.
+	Type string `json:"type"`
+}
+
+// ProblemExample defines model for ProblemExample.
+type ProblemExample struct {
+	In   string `json:"in"`
+	Name string `json:"name"`
+	Out  string `json:"out"`
+}
+
+// ProblemExamplesResponse defines model for ProblemExamplesResponse.
+type ProblemExamplesResponse struct {
+	Examples         []ProblemExample `json:"examples"`
+	TestcasesVersion string           `json:"testcases_version"`
+}
+
+// ProblemFile defines model for ProblemFile.
+type ProblemFile struct {
+	// Path Path relative to the problem dir, or under common/ for shared headers.
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// ProblemFilesResponse defines model for ProblemFilesResponse.
+type ProblemFilesResponse struct {
+	Files          []ProblemFile `json:"files"`
+	OverallVersion string        `json:"overall_version"`
+}
+
 // ProblemInfoResponse defines model for ProblemInfoResponse.
 type ProblemInfoResponse struct {
 	OverallVersion string `json:"overall_version"`
@@ -173,6 +557,34 @@ type ProblemListResponse struct {
 // ProblemName Problem identifier consisting of lowercase letters, digits, or underscores.
 type ProblemName = string
 
+// ProblemStatementResponse defines model for ProblemStatementResponse.
+type ProblemStatementResponse struct {
+	OverallVersion string `json:"overall_version"`
+
+	// Statement task.md in Markdown.
+	Statement string `json:"statement"`
+}
+
+// ProblemTestCase defines model for ProblemTestCase.
+type ProblemTestCase struct {
+	// Generator Generator in info.toml, e.g. random.cpp. Empty if unknown.
+	Generator string `json:"generator"`
+	InSize    int64  `json:"in_size"`
+
+	// Name Name of the case, e.g. random_00.
+	Name    string `json:"name"`
+	OutSize int64  `json:"out_size"`
+}
+
+// ProblemTestCasesResponse defines model for ProblemTestCasesResponse.
+type ProblemTestCasesResponse struct {
+	Testcases        []ProblemTestCase `json:"testcases"`
+	TestcasesVersion string            `json:"testcases_version"`
+
+	// TotalSize Total size of the input and output files.
+	TotalSize int64 `json:"total_size"`
+}
+
 // RankingResponse defines model for RankingResponse.
 type RankingResponse struct {
 	Count      int32            `json:"count"`
@@ -191,6 +603,9 @@ type RegisterResponse = map[string]interface{}
 // RejudgeResponse defines model for RejudgeResponse.
 type RejudgeResponse = map[string]interface{}
 
+// RevokeAPITokenResponse defines model for RevokeAPITokenResponse.
+type RevokeAPITokenResponse = map[string]interface{}
+
 // SolvedStatus Solved status for a problem.
 type SolvedStatus string
 
@@ -210,12 +625,19 @@ type SubmissionInfoResponse struct {
 	CaseResults  *[]SubmissionCaseResult `json:"case_results,omitempty"`
 	CompileError *[]byte                 `json:"compile_error,omitempty"`
 	Overview     SubmissionOverview      `json:"overview"`
-	Source       string                  `json:"source"`
+
+	// Runs Judge runs of the submission, oldest first.
+	Runs   *[]JudgeRun `json:"runs,omitempty"`
+	Source string      `json:"source"`
 }
 
 // SubmissionListResponse defines model for SubmissionListResponse.
 type SubmissionListResponse struct {
-	Count       int32                `json:"count"`
+	// Count Number of matched submissions, omitted if with_count is false.
+	Count *int32 `json:"count,omitempty"`
+
+	// NextCursor Cursor of the next page, omitted on the last page.
+	NextCursor  *string              `json:"next_cursor,omitempty"`
 	Submissions []SubmissionOverview `json:"submissions"`
 }
 
@@ -236,6 +658,19 @@ type SubmissionOverview struct {
 	UserName       *string    `json:"user_name,omitempty"`
 }
 
+// SubmissionStatusEvent defines model for SubmissionStatusEvent.
+type SubmissionStatusEvent struct {
+	// CaseResults Case results changed since the previous event.
+	CaseResults *[]SubmissionCaseResult `json:"case_results,omitempty"`
+
+	// Final True if judging finished and no more events follow.
+	Final  bool    `json:"final"`
+	Id     int32   `json:"id"`
+	Memory int64   `json:"memory"`
+	Status string  `json:"status"`
+	Time   float32 `json:"time"`
+}
+
 // SubmitRequest defines model for SubmitRequest.
 type SubmitRequest struct {
 	Lang string `json:"lang"`
@@ -260,8 +695,18 @@ type TaskQueueInfo struct {
 	TotalTasks   int32 `json:"total_tasks"`
 }
 
+// UpdateUserRoleRequest defines model for UpdateUserRoleRequest.
+type UpdateUserRoleRequest struct {
+	// Role Set only by admins through PUT /admin/users/{name}/role.
+	Role UserRole `json:"role"`
+}
+
+// UpdateUserRoleResponse defines model for UpdateUserRoleResponse.
+type UpdateUserRoleResponse = map[string]interface{}
+
 // User defines model for User.
 type User struct {
+	// IsDeveloper True for moderators and admins. Ignored on update.
 	IsDeveloper bool `json:"is_developer"`
 
 	// LibraryUrl Optional URL for the user's library profile. Use an empty string to keep it unset.
@@ -269,6 +714,9 @@ type User struct {
 
 	// Name Unique user identifier consisting of letters, digits, hyphen, or underscore.
 	Name Username `json:"name"`
+
+	// Role Set only by admins through PUT /admin/users/{name}/role.
+	Role *UserRole `json:"role,omitempty"`
 }
 
 // UserInfoResponse defines model for UserInfoResponse.
@@ -276,6 +724,9 @@ type UserInfoResponse struct {
 	User User `json:"user"`
 }
 
+// UserRole Set only by admins through PUT /admin/users/{name}/role.
+type UserRole string
+
 // UserSolvedStatisticsResponse defines model for UserSolvedStatisticsResponse.
 type UserSolvedStatisticsResponse struct {
 	SolvedMap map[string]SolvedStatus `json:"solved_map"`
@@ -292,6 +743,56 @@ type UserStatistics struct {
 // Username Unique user identifier consisting of letters, digits, hyphen, or underscore.
 type Username = string
 
+// Webhook defines model for Webhook.
+type Webhook struct {
+	// AllUsers Whether the webhook receives the events of every user.
+	AllUsers  bool           `json:"all_users"`
+	CreatedAt time.Time      `json:"created_at"`
+	Events    []WebhookEvent `json:"events"`
+	Id        int32          `json:"id"`
+	Url       string         `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Error Error of the last attempt.
+	Error         *string      `json:"error,omitempty"`
+	Event         WebhookEvent `json:"event"`
+	Id            int32        `json:"id"`
+	LastAttemptAt *time.Time   `json:"last_attempt_at,omitempty"`
+
+	// NextAttemptAt Set while pending.
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	// Payload JSON body sent to the webhook.
+	Payload string `json:"payload"`
+
+	// ResponseCode HTTP status of the last attempt, omitted if there was no response.
+	ResponseCode *int32 `json:"response_code,omitempty"`
+
+	// Status pending deliveries are retried with backoff, failed ones were given up.
+	Status WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryListResponse defines model for WebhookDeliveryListResponse.
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// WebhookDeliveryStatus pending deliveries are retried with backoff, failed ones were given up.
+type WebhookDeliveryStatus string
+
+// WebhookEvent defines model for WebhookEvent.
+type WebhookEvent string
+
+// WebhookListResponse defines model for WebhookListResponse.
+type WebhookListResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
 // HackId defines model for HackId.
 type HackId = int32
 
@@ -319,6 +820,15 @@ type SubmissionSkip = int32
 // UserNamePath Unique user identifier consisting of letters, digits, hyphen, or underscore.
 type UserNamePath = Username
 
+// WebhookId defines model for WebhookId.
+type WebhookId = int32
+
+// Error Error body (RFC 7807, application/problem+json).
+type Error = ProblemDetails
+
+// apiTokenContextKey is the context key for apiToken security scheme
+type apiTokenContextKey string
+
 // firebaseAuthContextKey is the context key for firebaseAuth security scheme
 type firebaseAuthContextKey string
 
@@ -334,6 +844,12 @@ type GetHackListParams struct {
 	Order  *string    `form:"order,omitempty" json:"order,omitempty"`
 }
 
+// GetProblemFileParams defines parameters for GetProblemFile.
+type GetProblemFileParams struct {
+	// Path Path of the file as listed by /problems/{name}/files (e.g. grader/solve.hpp, common/fastio.h).
+	Path string `form:"path" json:"path"`
+}
+
 // GetRankingParams defines parameters for GetRanking.
 type GetRankingParams struct {
 	// Skip Number of ranking records to skip before collecting results.
@@ -349,30 +865,92 @@ type GetSubmissionListParams struct {
 	Skip *SubmissionSkip `form:"skip,omitempty" json:"skip,omitempty"`
 
 	// Limit Maximum number of submissions to return (1-1000).
-	Limit     *SubmissionLimit `form:"limit,omitempty" json:"limit,omitempty"`
-	Problem   *ProblemName     `form:"problem,omitempty" json:"problem,omitempty"`
-	Status    *string          `form:"status,omitempty" json:"status,omitempty"`
-	Hacked    *bool            `form:"hacked,omitempty" json:"hacked,omitempty"`
-	User      *Username        `form:"user,omitempty" json:"user,omitempty"`
-	DedupUser *bool            `form:"dedupUser,omitempty" json:"dedupUser,omitempty"`
-	Lang      *string          `form:"lang,omitempty" json:"lang,omitempty"`
-	Order     *SubmissionOrder `form:"order,omitempty" json:"order,omitempty"`
+	Limit   *SubmissionLimit `form:"limit,omitempty" json:"limit,omitempty"`
+	Problem *ProblemName     `form:"problem,omitempty" json:"problem,omitempty"`
+
+	// Status Status, or comma separated statuses (e.g. WA,TLE).
+	Status    *string   `form:"status,omitempty" json:"status,omitempty"`
+	Hacked    *bool     `form:"hacked,omitempty" json:"hacked,omitempty"`
+	User      *Username `form:"user,omitempty" json:"user,omitempty"`
+	DedupUser *bool     `form:"dedupUser,omitempty" json:"dedupUser,omitempty"`
+
+	// Lang Language, or comma separated languages (e.g. cpp,rust).
+	Lang  *string          `form:"lang,omitempty" json:"lang,omitempty"`
+	Order *SubmissionOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Since Only submissions at or after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only submissions before this time.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// IsLatest Only submissions judged (or not judged) with the current test cases.
+	IsLatest *bool `form:"is_latest,omitempty" json:"is_latest,omitempty"`
+
+	// Cursor next_cursor of the previous page. Pages are fetched by keyset instead
+	// of skip, which stays fast on deep pages. Cannot be used with skip.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// WithCount Whether to count the matched submissions (default true). Counting is slow on large results.
+	WithCount *bool `form:"with_count,omitempty" json:"with_count,omitempty"`
+}
+
+// GetJudgeRunDiffParams defines parameters for GetJudgeRunDiff.
+type GetJudgeRunDiffParams struct {
+	Base   int32 `form:"base" json:"base"`
+	Target int32 `form:"target" json:"target"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// Limit Maximum number of deliveries to return (1-100).
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// PostBulkRejudgeJSONRequestBody defines body for PostBulkRejudge for application/json ContentType.
+type PostBulkRejudgeJSONRequestBody = BulkRejudgeRequest
+
+// PutUserRoleJSONRequestBody defines body for PutUserRole for application/json ContentType.
+type PutUserRoleJSONRequestBody = UpdateUserRoleRequest
+
 // PatchCurrentUserInfoJSONRequestBody defines body for PatchCurrentUserInfo for application/json ContentType.
 type PatchCurrentUserInfoJSONRequestBody = ChangeCurrentUserInfoRequest
 
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody = RegisterRequest
 
+// PostAPITokenJSONRequestBody defines body for PostAPIToken for application/json ContentType.
+type PostAPITokenJSONRequestBody = CreateAPITokenRequest
+
 // PostHackJSONRequestBody defines body for PostHack for application/json ContentType.
 type PostHackJSONRequestBody = CreateHackRequest
 
 // PostSubmitJSONRequestBody defines body for PostSubmit for application/json ContentType.
 type PostSubmitJSONRequestBody = SubmitRequest
 
+// PostWebhookJSONRequestBody defines body for PostWebhook for application/json ContentType.
+type PostWebhookJSONRequestBody = CreateWebhookRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Progress of the automatic rejudge of outdated AC submissions
+	// (GET /admin/auto_rejudge)
+	GetAutoRejudgeProgress(w http.ResponseWriter, r *http.Request)
+	// List bulk rejudge jobs, newest first
+	// (GET /admin/rejudge)
+	GetBulkRejudgeJobs(w http.ResponseWriter, r *http.Request)
+	// Rejudge submissions matched by filters
+	// (POST /admin/rejudge)
+	PostBulkRejudge(w http.ResponseWriter, r *http.Request)
+	// Cancel a bulk rejudge job
+	// (DELETE /admin/rejudge/{id})
+	CancelBulkRejudgeJob(w http.ResponseWriter, r *http.Request, id int32)
+	// List users with a role other than user
+	// (GET /admin/users)
+	GetAdminUsers(w http.ResponseWriter, r *http.Request)
+	// Change the role of a user
+	// (PUT /admin/users/{name}/role)
+	PutUserRole(w http.ResponseWriter, r *http.Request, name UserNamePath)
 	// Get current user info
 	// (GET /auth/current_user)
 	GetCurrentUserInfo(w http.ResponseWriter, r *http.Request)
@@ -382,6 +960,15 @@ type ServerInterface interface {
 	// Register user
 	// (POST /auth/register)
 	PostRegister(w http.ResponseWriter, r *http.Request)
+	// List personal API tokens of the current user
+	// (GET /auth/tokens)
+	GetAPITokens(w http.ResponseWriter, r *http.Request)
+	// Create a personal API token
+	// (POST /auth/tokens)
+	PostAPIToken(w http.ResponseWriter, r *http.Request)
+	// Revoke a personal API token
+	// (DELETE /auth/tokens/{id})
+	DeleteAPIToken(w http.ResponseWriter, r *http.Request, id int32)
 	// Get problem categories
 	// (GET /categories)
 	GetProblemCategories(w http.ResponseWriter, r *http.Request)
@@ -394,6 +981,12 @@ type ServerInterface interface {
 	// Get hack info
 	// (GET /hacks/{id})
 	GetHackInfo(w http.ResponseWriter, r *http.Request, id HackId)
+	// Stream status changes of a hack
+	// (GET /hacks/{id}/events)
+	GetHackEvents(w http.ResponseWriter, r *http.Request, id HackId)
+	// Judge a hack again
+	// (POST /hacks/{id}/rejudge)
+	PostHackRejudge(w http.ResponseWriter, r *http.Request, id HackId)
 	// Get language list
 	// (GET /langs)
 	GetLangList(w http.ResponseWriter, r *http.Request)
@@ -406,6 +999,21 @@ type ServerInterface interface {
 	// Get problem info
 	// (GET /problems/{name})
 	GetProblemInfo(w http.ResponseWriter, r *http.Request, name ProblemName)
+	// Get the examples of the current test cases
+	// (GET /problems/{name}/examples)
+	GetProblemExamples(w http.ResponseWriter, r *http.Request, name ProblemName)
+	// Download a public file of the current version
+	// (GET /problems/{name}/file)
+	GetProblemFile(w http.ResponseWriter, r *http.Request, name ProblemName, params GetProblemFileParams)
+	// List the public files (grader, headers, ...) of the current version
+	// (GET /problems/{name}/files)
+	GetProblemFiles(w http.ResponseWriter, r *http.Request, name ProblemName)
+	// Get the problem statement (task.md) of the current version
+	// (GET /problems/{name}/statement)
+	GetProblemStatement(w http.ResponseWriter, r *http.Request, name ProblemName)
+	// List the test cases of the current version with their sizes
+	// (GET /problems/{name}/testcases)
+	GetProblemTestCases(w http.ResponseWriter, r *http.Request, name ProblemName)
 	// Get ranking
 	// (GET /ranking)
 	GetRanking(w http.ResponseWriter, r *http.Request, params GetRankingParams)
@@ -415,9 +1023,15 @@ type ServerInterface interface {
 	// Get submission info
 	// (GET /submissions/{id})
 	GetSubmissionInfo(w http.ResponseWriter, r *http.Request, id SubmissionId)
+	// Stream status changes of a submission
+	// (GET /submissions/{id}/events)
+	GetSubmissionEvents(w http.ResponseWriter, r *http.Request, id SubmissionId)
 	// Rejudge a submission
 	// (POST /submissions/{id}/rejudge)
 	PostRejudge(w http.ResponseWriter, r *http.Request, id SubmissionId)
+	// Compare the case results of two judge runs of a submission
+	// (GET /submissions/{id}/runs/diff)
+	GetJudgeRunDiff(w http.ResponseWriter, r *http.Request, id SubmissionId, params GetJudgeRunDiffParams)
 	// Submit a solution
 	// (POST /submit)
 	PostSubmit(w http.ResponseWriter, r *http.Request)
@@ -427,12 +1041,60 @@ type ServerInterface interface {
 	// Get user solved statistics
 	// (GET /users/{name}/statistics)
 	GetUserStatistics(w http.ResponseWriter, r *http.Request, name UserNamePath)
+	// List webhooks of the current user
+	// (GET /webhooks)
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	// Register a webhook
+	// (POST /webhooks)
+	PostWebhook(w http.ResponseWriter, r *http.Request)
+	// Delete a webhook and its delivery log
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, id WebhookId)
+	// Get the delivery log of a webhook, newest first
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, id WebhookId, params GetWebhookDeliveriesParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Progress of the automatic rejudge of outdated AC submissions
+// (GET /admin/auto_rejudge)
+func (_ Unimplemented) GetAutoRejudgeProgress(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List bulk rejudge jobs, newest first
+// (GET /admin/rejudge)
+func (_ Unimplemented) GetBulkRejudgeJobs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rejudge submissions matched by filters
+// (POST /admin/rejudge)
+func (_ Unimplemented) PostBulkRejudge(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a bulk rejudge job
+// (DELETE /admin/rejudge/{id})
+func (_ Unimplemented) CancelBulkRejudgeJob(w http.ResponseWriter, r *http.Request, id int32) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List users with a role other than user
+// (GET /admin/users)
+func (_ Unimplemented) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the role of a user
+// (PUT /admin/users/{name}/role)
+func (_ Unimplemented) PutUserRole(w http.ResponseWriter, r *http.Request, name UserNamePath) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get current user info
 // (GET /auth/current_user)
 func (_ Unimplemented) GetCurrentUserInfo(w http.ResponseWriter, r *http.Request) {
@@ -451,6 +1113,24 @@ func (_ Unimplemented) PostRegister(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List personal API tokens of the current user
+// (GET /auth/tokens)
+func (_ Unimplemented) GetAPITokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a personal API token
+// (POST /auth/tokens)
+func (_ Unimplemented) PostAPIToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a personal API token
+// (DELETE /auth/tokens/{id})
+func (_ Unimplemented) DeleteAPIToken(w http.ResponseWriter, r *http.Request, id int32) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get problem categories
 // (GET /categories)
 func (_ Unimplemented) GetProblemCategories(w http.ResponseWriter, r *http.Request) {
@@ -475,6 +1155,18 @@ func (_ Unimplemented) GetHackInfo(w http.ResponseWriter, r *http.Request, id Ha
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Stream status changes of a hack
+// (GET /hacks/{id}/events)
+func (_ Unimplemented) GetHackEvents(w http.ResponseWriter, r *http.Request, id HackId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Judge a hack again
+// (POST /hacks/{id}/rejudge)
+func (_ Unimplemented) PostHackRejudge(w http.ResponseWriter, r *http.Request, id HackId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get language list
 // (GET /langs)
 func (_ Unimplemented) GetLangList(w http.ResponseWriter, r *http.Request) {
@@ -499,6 +1191,36 @@ func (_ Unimplemented) GetProblemInfo(w http.ResponseWriter, r *http.Request, na
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the examples of the current test cases
+// (GET /problems/{name}/examples)
+func (_ Unimplemented) GetProblemExamples(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Download a public file of the current version
+// (GET /problems/{name}/file)
+func (_ Unimplemented) GetProblemFile(w http.ResponseWriter, r *http.Request, name ProblemName, params GetProblemFileParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the public files (grader, headers, ...) of the current version
+// (GET /problems/{name}/files)
+func (_ Unimplemented) GetProblemFiles(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the problem statement (task.md) of the current version
+// (GET /problems/{name}/statement)
+func (_ Unimplemented) GetProblemStatement(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the test cases of the current version with their sizes
+// (GET /problems/{name}/testcases)
+func (_ Unimplemented) GetProblemTestCases(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get ranking
 // (GET /ranking)
 func (_ Unimplemented) GetRanking(w http.ResponseWriter, r *http.Request, params GetRankingParams) {
@@ -517,12 +1239,24 @@ func (_ Unimplemented) GetSubmissionInfo(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Stream status changes of a submission
+// (GET /submissions/{id}/events)
+func (_ Unimplemented) GetSubmissionEvents(w http.ResponseWriter, r *http.Request, id SubmissionId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Rejudge a submission
 // (POST /submissions/{id}/rejudge)
 func (_ Unimplemented) PostRejudge(w http.ResponseWriter, r *http.Request, id SubmissionId) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Compare the case results of two judge runs of a submission
+// (GET /submissions/{id}/runs/diff)
+func (_ Unimplemented) GetJudgeRunDiff(w http.ResponseWriter, r *http.Request, id SubmissionId, params GetJudgeRunDiffParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Submit a solution
 // (POST /submit)
 func (_ Unimplemented) PostSubmit(w http.ResponseWriter, r *http.Request) {
@@ -541,15 +1275,183 @@ func (_ Unimplemented) GetUserStatistics(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// List webhooks of the current user
+// (GET /webhooks)
+func (_ Unimplemented) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a webhook
+// (POST /webhooks)
+func (_ Unimplemented) PostWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a webhook and its delivery log
+// (DELETE /webhooks/{id})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, id WebhookId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the delivery log of a webhook, newest first
+// (GET /webhooks/{id}/deliveries)
+func (_ Unimplemented) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, id WebhookId, params GetWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
 }
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetAutoRejudgeProgress operation middleware
+func (siw *ServerInterfaceWrapper) GetAutoRejudgeProgress(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAutoRejudgeProgress(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBulkRejudgeJobs operation middleware
+func (siw *ServerInterfaceWrapper) GetBulkRejudgeJobs(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBulkRejudgeJobs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostBulkRejudge operation middleware
+func (siw *ServerInterfaceWrapper) PostBulkRejudge(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostBulkRejudge(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelBulkRejudgeJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelBulkRejudgeJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelBulkRejudgeJob(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminUsers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutUserRole operation middleware
+func (siw *ServerInterfaceWrapper) PutUserRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name UserNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutUserRole(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetCurrentUserInfo operation middleware
 func (siw *ServerInterfaceWrapper) GetCurrentUserInfo(w http.ResponseWriter, r *http.Request) {
 
@@ -604,6 +1506,78 @@ func (siw *ServerInterfaceWrapper) PostRegister(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetAPITokens operation middleware
+func (siw *ServerInterfaceWrapper) GetAPITokens(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAPITokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) PostAPIToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAPIToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPIToken(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetProblemCategories operation middleware
 func (siw *ServerInterfaceWrapper) GetProblemCategories(w http.ResponseWriter, r *http.Request) {
 
@@ -710,6 +1684,8 @@ func (siw *ServerInterfaceWrapper) PostHack(w http.ResponseWriter, r *http.Reque
 
 	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -749,6 +1725,64 @@ func (siw *ServerInterfaceWrapper) GetHackInfo(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// GetHackEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetHackEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id HackId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHackEvents(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHackRejudge operation middleware
+func (siw *ServerInterfaceWrapper) PostHackRejudge(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id HackId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHackRejudge(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetLangList operation middleware
 func (siw *ServerInterfaceWrapper) GetLangList(w http.ResponseWriter, r *http.Request) {
 
@@ -817,6 +1851,152 @@ func (siw *ServerInterfaceWrapper) GetProblemInfo(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// GetProblemExamples operation middleware
+func (siw *ServerInterfaceWrapper) GetProblemExamples(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name ProblemName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProblemExamples(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProblemFile operation middleware
+func (siw *ServerInterfaceWrapper) GetProblemFile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name ProblemName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProblemFileParams
+
+	// ------------- Required query parameter "path" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "path", r.URL.Query(), &params.Path, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "path"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "path", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProblemFile(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProblemFiles operation middleware
+func (siw *ServerInterfaceWrapper) GetProblemFiles(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name ProblemName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProblemFiles(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProblemStatement operation middleware
+func (siw *ServerInterfaceWrapper) GetProblemStatement(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name ProblemName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProblemStatement(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProblemTestCases operation middleware
+func (siw *ServerInterfaceWrapper) GetProblemTestCases(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "name" -------------
+	var name ProblemName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProblemTestCases(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetRanking operation middleware
 func (siw *ServerInterfaceWrapper) GetRanking(w http.ResponseWriter, r *http.Request) {
 
@@ -989,6 +2169,71 @@ func (siw *ServerInterfaceWrapper) GetSubmissionList(w http.ResponseWriter, r *h
 		return
 	}
 
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "since", r.URL.Query(), &params.Since, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "since"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "until", r.URL.Query(), &params.Until, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "until"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "is_latest" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "is_latest", r.URL.Query(), &params.IsLatest, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "is_latest"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "is_latest", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "with_count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "with_count", r.URL.Query(), &params.WithCount, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "with_count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "with_count", Err: err})
+		}
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetSubmissionList(w, r, params)
 	}))
@@ -1026,6 +2271,32 @@ func (siw *ServerInterfaceWrapper) GetSubmissionInfo(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
+// GetSubmissionEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetSubmissionEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id SubmissionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubmissionEvents(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostRejudge operation middleware
 func (siw *ServerInterfaceWrapper) PostRejudge(w http.ResponseWriter, r *http.Request) {
 
@@ -1045,6 +2316,8 @@ func (siw *ServerInterfaceWrapper) PostRejudge(w http.ResponseWriter, r *http.Re
 
 	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1058,6 +2331,61 @@ func (siw *ServerInterfaceWrapper) PostRejudge(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// GetJudgeRunDiff operation middleware
+func (siw *ServerInterfaceWrapper) GetJudgeRunDiff(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id SubmissionId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetJudgeRunDiffParams
+
+	// ------------- Required query parameter "base" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "base", r.URL.Query(), &params.Base, runtime.BindQueryParameterOptions{Type: "integer", Format: "int32"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "base"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		}
+		return
+	}
+
+	// ------------- Required query parameter "target" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "target", r.URL.Query(), &params.Target, runtime.BindQueryParameterOptions{Type: "integer", Format: "int32"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "target"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJudgeRunDiff(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostSubmit operation middleware
 func (siw *ServerInterfaceWrapper) PostSubmit(w http.ResponseWriter, r *http.Request) {
 
@@ -1065,6 +2393,8 @@ func (siw *ServerInterfaceWrapper) PostSubmit(w http.ResponseWriter, r *http.Req
 
 	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
 
+	ctx = context.WithValue(ctx, ApiTokenScopes, []string{})
+
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1130,23 +2460,143 @@ func (siw *ServerInterfaceWrapper) GetUserStatistics(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostWebhook operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhook(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "id" -------------
+	var id WebhookId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "integer", Format: "int32"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, FirebaseAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: "int32"})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhookDeliveries(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
 
 func (e *UnmarshalingParamError) Error() string {
 	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
@@ -1243,6 +2693,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/auto_rejudge", wrapper.GetAutoRejudgeProgress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/rejudge", wrapper.GetBulkRejudgeJobs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/rejudge", wrapper.PostBulkRejudge)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/rejudge/{id}", wrapper.CancelBulkRejudgeJob)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/users", wrapper.GetAdminUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{name}/role", wrapper.PutUserRole)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/auth/current_user", wrapper.GetCurrentUserInfo)
 	})
@@ -1252,6 +2720,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/register", wrapper.PostRegister)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/tokens", wrapper.GetAPITokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/tokens", wrapper.PostAPIToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/tokens/{id}", wrapper.DeleteAPIToken)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/categories", wrapper.GetProblemCategories)
 	})
@@ -1264,6 +2741,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/hacks/{id}", wrapper.GetHackInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/hacks/{id}/events", wrapper.GetHackEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/hacks/{id}/rejudge", wrapper.PostHackRejudge)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/langs", wrapper.GetLangList)
 	})
@@ -1276,6 +2759,21 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/problems/{name}", wrapper.GetProblemInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/problems/{name}/examples", wrapper.GetProblemExamples)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/problems/{name}/file", wrapper.GetProblemFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/problems/{name}/files", wrapper.GetProblemFiles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/problems/{name}/statement", wrapper.GetProblemStatement)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/problems/{name}/testcases", wrapper.GetProblemTestCases)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/ranking", wrapper.GetRanking)
 	})
@@ -1285,9 +2783,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/submissions/{id}", wrapper.GetSubmissionInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/submissions/{id}/events", wrapper.GetSubmissionEvents)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/submissions/{id}/rejudge", wrapper.PostRejudge)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/submissions/{id}/runs/diff", wrapper.GetJudgeRunDiff)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/submit", wrapper.PostSubmit)
 	})
@@ -1297,20 +2801,34 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/users/{name}/statistics", wrapper.GetUserStatistics)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/webhooks", wrapper.PostWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/webhooks/{id}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/webhooks/{id}/deliveries", wrapper.GetWebhookDeliveries)
+	})
 
 	return r
 }
 
-type GetCurrentUserInfoRequestObject struct {
+type ErrorApplicationProblemPlusJSONResponse ProblemDetails
+
+type GetAutoRejudgeProgressRequestObject struct {
 }
 
-type GetCurrentUserInfoResponseObject interface {
-	VisitGetCurrentUserInfoResponse(w http.ResponseWriter) error
+type GetAutoRejudgeProgressResponseObject interface {
+	VisitGetAutoRejudgeProgressResponse(w http.ResponseWriter) error
 }
 
-type GetCurrentUserInfo200JSONResponse CurrentUserInfoResponse
+type GetAutoRejudgeProgress200JSONResponse AutoRejudgeProgressListResponse
 
-func (response GetCurrentUserInfo200JSONResponse) VisitGetCurrentUserInfoResponse(w http.ResponseWriter) error {
+func (response GetAutoRejudgeProgress200JSONResponse) VisitGetAutoRejudgeProgressResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1322,39 +2840,33 @@ func (response GetCurrentUserInfo200JSONResponse) VisitGetCurrentUserInfoRespons
 	return err
 }
 
-type PatchCurrentUserInfoRequestObject struct {
-	Body *PatchCurrentUserInfoJSONRequestBody
-}
-
-type PatchCurrentUserInfoResponseObject interface {
-	VisitPatchCurrentUserInfoResponse(w http.ResponseWriter) error
+type GetAutoRejudgeProgressdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type PatchCurrentUserInfo200JSONResponse ChangeCurrentUserInfoResponse
-
-func (response PatchCurrentUserInfo200JSONResponse) VisitPatchCurrentUserInfoResponse(w http.ResponseWriter) error {
+func (response GetAutoRejudgeProgressdefaultApplicationProblemPlusJSONResponse) VisitGetAutoRejudgeProgressResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type PostRegisterRequestObject struct {
-	Body *PostRegisterJSONRequestBody
+type GetBulkRejudgeJobsRequestObject struct {
 }
 
-type PostRegisterResponseObject interface {
-	VisitPostRegisterResponse(w http.ResponseWriter) error
+type GetBulkRejudgeJobsResponseObject interface {
+	VisitGetBulkRejudgeJobsResponse(w http.ResponseWriter) error
 }
 
-type PostRegister200JSONResponse RegisterResponse
+type GetBulkRejudgeJobs200JSONResponse BulkRejudgeJobListResponse
 
-func (response PostRegister200JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+func (response GetBulkRejudgeJobs200JSONResponse) VisitGetBulkRejudgeJobsResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1366,38 +2878,34 @@ func (response PostRegister200JSONResponse) VisitPostRegisterResponse(w http.Res
 	return err
 }
 
-type GetProblemCategoriesRequestObject struct {
-}
-
-type GetProblemCategoriesResponseObject interface {
-	VisitGetProblemCategoriesResponse(w http.ResponseWriter) error
+type GetBulkRejudgeJobsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type GetProblemCategories200JSONResponse ProblemCategoriesResponse
-
-func (response GetProblemCategories200JSONResponse) VisitGetProblemCategoriesResponse(w http.ResponseWriter) error {
+func (response GetBulkRejudgeJobsdefaultApplicationProblemPlusJSONResponse) VisitGetBulkRejudgeJobsResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetHackListRequestObject struct {
-	Params GetHackListParams
+type PostBulkRejudgeRequestObject struct {
+	Body *PostBulkRejudgeJSONRequestBody
 }
 
-type GetHackListResponseObject interface {
-	VisitGetHackListResponse(w http.ResponseWriter) error
+type PostBulkRejudgeResponseObject interface {
+	VisitPostBulkRejudgeResponse(w http.ResponseWriter) error
 }
 
-type GetHackList200JSONResponse HackListResponse
+type PostBulkRejudge200JSONResponse BulkRejudgeResponse
 
-func (response GetHackList200JSONResponse) VisitGetHackListResponse(w http.ResponseWriter) error {
+func (response PostBulkRejudge200JSONResponse) VisitPostBulkRejudgeResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1409,39 +2917,34 @@ func (response GetHackList200JSONResponse) VisitGetHackListResponse(w http.Respo
 	return err
 }
 
-type PostHackRequestObject struct {
-	Body *PostHackJSONRequestBody
-}
-
-type PostHackResponseObject interface {
-	VisitPostHackResponse(w http.ResponseWriter) error
+type PostBulkRejudgedefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type PostHack200JSONResponse HackResponse
-
-func (response PostHack200JSONResponse) VisitPostHackResponse(w http.ResponseWriter) error {
+func (response PostBulkRejudgedefaultApplicationProblemPlusJSONResponse) VisitPostBulkRejudgeResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetHackInfoRequestObject struct {
-	Id HackId `json:"id"`
+type CancelBulkRejudgeJobRequestObject struct {
+	Id int32 `json:"id"`
 }
 
-type GetHackInfoResponseObject interface {
-	VisitGetHackInfoResponse(w http.ResponseWriter) error
+type CancelBulkRejudgeJobResponseObject interface {
+	VisitCancelBulkRejudgeJobResponse(w http.ResponseWriter) error
 }
 
-type GetHackInfo200JSONResponse HackInfoResponse
+type CancelBulkRejudgeJob200JSONResponse BulkRejudgeJob
 
-func (response GetHackInfo200JSONResponse) VisitGetHackInfoResponse(w http.ResponseWriter) error {
+func (response CancelBulkRejudgeJob200JSONResponse) VisitCancelBulkRejudgeJobResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1453,37 +2956,33 @@ func (response GetHackInfo200JSONResponse) VisitGetHackInfoResponse(w http.Respo
 	return err
 }
 
-type GetLangListRequestObject struct {
-}
-
-type GetLangListResponseObject interface {
-	VisitGetLangListResponse(w http.ResponseWriter) error
+type CancelBulkRejudgeJobdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type GetLangList200JSONResponse LangListResponse
-
-func (response GetLangList200JSONResponse) VisitGetLangListResponse(w http.ResponseWriter) error {
+func (response CancelBulkRejudgeJobdefaultApplicationProblemPlusJSONResponse) VisitCancelBulkRejudgeJobResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetMonitoringRequestObject struct {
+type GetAdminUsersRequestObject struct {
 }
 
-type GetMonitoringResponseObject interface {
-	VisitGetMonitoringResponse(w http.ResponseWriter) error
+type GetAdminUsersResponseObject interface {
+	VisitGetAdminUsersResponse(w http.ResponseWriter) error
 }
 
-type GetMonitoring200JSONResponse MonitoringResponse
+type GetAdminUsers200JSONResponse AdminUserListResponse
 
-func (response GetMonitoring200JSONResponse) VisitGetMonitoringResponse(w http.ResponseWriter) error {
+func (response GetAdminUsers200JSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1495,38 +2994,35 @@ func (response GetMonitoring200JSONResponse) VisitGetMonitoringResponse(w http.R
 	return err
 }
 
-type GetProblemsRequestObject struct {
-}
-
-type GetProblemsResponseObject interface {
-	VisitGetProblemsResponse(w http.ResponseWriter) error
+type GetAdminUsersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type GetProblems200JSONResponse ProblemListResponse
-
-func (response GetProblems200JSONResponse) VisitGetProblemsResponse(w http.ResponseWriter) error {
+func (response GetAdminUsersdefaultApplicationProblemPlusJSONResponse) VisitGetAdminUsersResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetProblemInfoRequestObject struct {
-	Name ProblemName `json:"name"`
+type PutUserRoleRequestObject struct {
+	Name UserNamePath `json:"name"`
+	Body *PutUserRoleJSONRequestBody
 }
 
-type GetProblemInfoResponseObject interface {
-	VisitGetProblemInfoResponse(w http.ResponseWriter) error
+type PutUserRoleResponseObject interface {
+	VisitPutUserRoleResponse(w http.ResponseWriter) error
 }
 
-type GetProblemInfo200JSONResponse ProblemInfoResponse
+type PutUserRole200JSONResponse UpdateUserRoleResponse
 
-func (response GetProblemInfo200JSONResponse) VisitGetProblemInfoResponse(w http.ResponseWriter) error {
+func (response PutUserRole200JSONResponse) VisitPutUserRoleResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1538,39 +3034,1263 @@ func (response GetProblemInfo200JSONResponse) VisitGetProblemInfoResponse(w http
 	return err
 }
 
-type GetRankingRequestObject struct {
-	Params GetRankingParams
+type PutUserRoledefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PutUserRoledefaultApplicationProblemPlusJSONResponse) VisitPutUserRoleResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetCurrentUserInfoRequestObject struct {
+}
+
+type GetCurrentUserInfoResponseObject interface {
+	VisitGetCurrentUserInfoResponse(w http.ResponseWriter) error
+}
+
+type GetCurrentUserInfo200JSONResponse CurrentUserInfoResponse
+
+func (response GetCurrentUserInfo200JSONResponse) VisitGetCurrentUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetCurrentUserInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetCurrentUserInfodefaultApplicationProblemPlusJSONResponse) VisitGetCurrentUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchCurrentUserInfoRequestObject struct {
+	Body *PatchCurrentUserInfoJSONRequestBody
+}
+
+type PatchCurrentUserInfoResponseObject interface {
+	VisitPatchCurrentUserInfoResponse(w http.ResponseWriter) error
+}
+
+type PatchCurrentUserInfo200JSONResponse ChangeCurrentUserInfoResponse
+
+func (response PatchCurrentUserInfo200JSONResponse) VisitPatchCurrentUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PatchCurrentUserInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PatchCurrentUserInfodefaultApplicationProblemPlusJSONResponse) VisitPatchCurrentUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostRegisterRequestObject struct {
+	Body *PostRegisterJSONRequestBody
+}
+
+type PostRegisterResponseObject interface {
+	VisitPostRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostRegister200JSONResponse RegisterResponse
+
+func (response PostRegister200JSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostRegisterdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostRegisterdefaultApplicationProblemPlusJSONResponse) VisitPostRegisterResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetAPITokensRequestObject struct {
+}
+
+type GetAPITokensResponseObject interface {
+	VisitGetAPITokensResponse(w http.ResponseWriter) error
+}
+
+type GetAPITokens200JSONResponse APITokenListResponse
+
+func (response GetAPITokens200JSONResponse) VisitGetAPITokensResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetAPITokensdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetAPITokensdefaultApplicationProblemPlusJSONResponse) VisitGetAPITokensResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostAPITokenRequestObject struct {
+	Body *PostAPITokenJSONRequestBody
+}
+
+type PostAPITokenResponseObject interface {
+	VisitPostAPITokenResponse(w http.ResponseWriter) error
+}
+
+type PostAPIToken200JSONResponse CreateAPITokenResponse
+
+func (response PostAPIToken200JSONResponse) VisitPostAPITokenResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostAPITokendefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostAPITokendefaultApplicationProblemPlusJSONResponse) VisitPostAPITokenResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type DeleteAPITokenRequestObject struct {
+	Id int32 `json:"id"`
+}
+
+type DeleteAPITokenResponseObject interface {
+	VisitDeleteAPITokenResponse(w http.ResponseWriter) error
+}
+
+type DeleteAPIToken200JSONResponse RevokeAPITokenResponse
+
+func (response DeleteAPIToken200JSONResponse) VisitDeleteAPITokenResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type DeleteAPITokendefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response DeleteAPITokendefaultApplicationProblemPlusJSONResponse) VisitDeleteAPITokenResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemCategoriesRequestObject struct {
+}
+
+type GetProblemCategoriesResponseObject interface {
+	VisitGetProblemCategoriesResponse(w http.ResponseWriter) error
+}
+
+type GetProblemCategories200JSONResponse ProblemCategoriesResponse
+
+func (response GetProblemCategories200JSONResponse) VisitGetProblemCategoriesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemCategoriesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemCategoriesdefaultApplicationProblemPlusJSONResponse) VisitGetProblemCategoriesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetHackListRequestObject struct {
+	Params GetHackListParams
+}
+
+type GetHackListResponseObject interface {
+	VisitGetHackListResponse(w http.ResponseWriter) error
+}
+
+type GetHackList200JSONResponse HackListResponse
+
+func (response GetHackList200JSONResponse) VisitGetHackListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetHackListdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetHackListdefaultApplicationProblemPlusJSONResponse) VisitGetHackListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostHackRequestObject struct {
+	Body *PostHackJSONRequestBody
+}
+
+type PostHackResponseObject interface {
+	VisitPostHackResponse(w http.ResponseWriter) error
+}
+
+type PostHack200JSONResponse HackResponse
+
+func (response PostHack200JSONResponse) VisitPostHackResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostHackdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostHackdefaultApplicationProblemPlusJSONResponse) VisitPostHackResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetHackInfoRequestObject struct {
+	Id HackId `json:"id"`
+}
+
+type GetHackInfoResponseObject interface {
+	VisitGetHackInfoResponse(w http.ResponseWriter) error
+}
+
+type GetHackInfo200JSONResponse HackInfoResponse
+
+func (response GetHackInfo200JSONResponse) VisitGetHackInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetHackInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetHackInfodefaultApplicationProblemPlusJSONResponse) VisitGetHackInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetHackEventsRequestObject struct {
+	Id HackId `json:"id"`
+}
+
+type GetHackEventsResponseObject interface {
+	VisitGetHackEventsResponse(w http.ResponseWriter) error
+}
+
+type GetHackEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetHackEvents200TexteventStreamResponse) VisitGetHackEventsResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		// If w doesn't support flushing, fall back to io.Copy.
+		_, err := io.Copy(w, response.Body)
+		return err
+	}
+	// text/event-stream messages are typically small; use a
+	// modest buffer and flush after each chunk so clients see
+	// events immediately instead of waiting on OS buffering.
+	buf := make([]byte, 4096)
+	for {
+		n, err := response.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+type GetHackEventsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetHackEventsdefaultApplicationProblemPlusJSONResponse) VisitGetHackEventsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostHackRejudgeRequestObject struct {
+	Id HackId `json:"id"`
+}
+
+type PostHackRejudgeResponseObject interface {
+	VisitPostHackRejudgeResponse(w http.ResponseWriter) error
+}
+
+type PostHackRejudge200JSONResponse HackRejudgeResponse
+
+func (response PostHackRejudge200JSONResponse) VisitPostHackRejudgeResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostHackRejudgedefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostHackRejudgedefaultApplicationProblemPlusJSONResponse) VisitPostHackRejudgeResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetLangListRequestObject struct {
+}
+
+type GetLangListResponseObject interface {
+	VisitGetLangListResponse(w http.ResponseWriter) error
+}
+
+type GetLangList200JSONResponse LangListResponse
+
+func (response GetLangList200JSONResponse) VisitGetLangListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetLangListdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetLangListdefaultApplicationProblemPlusJSONResponse) VisitGetLangListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetMonitoringRequestObject struct {
+}
+
+type GetMonitoringResponseObject interface {
+	VisitGetMonitoringResponse(w http.ResponseWriter) error
+}
+
+type GetMonitoring200JSONResponse MonitoringResponse
+
+func (response GetMonitoring200JSONResponse) VisitGetMonitoringResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetMonitoringdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetMonitoringdefaultApplicationProblemPlusJSONResponse) VisitGetMonitoringResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemsRequestObject struct {
+}
+
+type GetProblemsResponseObject interface {
+	VisitGetProblemsResponse(w http.ResponseWriter) error
+}
+
+type GetProblems200JSONResponse ProblemListResponse
+
+func (response GetProblems200JSONResponse) VisitGetProblemsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemsdefaultApplicationProblemPlusJSONResponse) VisitGetProblemsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemInfoRequestObject struct {
+	Name ProblemName `json:"name"`
+}
+
+type GetProblemInfoResponseObject interface {
+	VisitGetProblemInfoResponse(w http.ResponseWriter) error
+}
+
+type GetProblemInfo200JSONResponse ProblemInfoResponse
+
+func (response GetProblemInfo200JSONResponse) VisitGetProblemInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemInfodefaultApplicationProblemPlusJSONResponse) VisitGetProblemInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemExamplesRequestObject struct {
+	Name ProblemName `json:"name"`
+}
+
+type GetProblemExamplesResponseObject interface {
+	VisitGetProblemExamplesResponse(w http.ResponseWriter) error
+}
+
+type GetProblemExamples200JSONResponse ProblemExamplesResponse
+
+func (response GetProblemExamples200JSONResponse) VisitGetProblemExamplesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemExamplesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemExamplesdefaultApplicationProblemPlusJSONResponse) VisitGetProblemExamplesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemFileRequestObject struct {
+	Name   ProblemName `json:"name"`
+	Params GetProblemFileParams
+}
+
+type GetProblemFileResponseObject interface {
+	VisitGetProblemFileResponse(w http.ResponseWriter) error
+}
+
+type GetProblemFile200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetProblemFile200ApplicationoctetStreamResponse) VisitGetProblemFileResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetProblemFiledefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemFiledefaultApplicationProblemPlusJSONResponse) VisitGetProblemFileResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemFilesRequestObject struct {
+	Name ProblemName `json:"name"`
+}
+
+type GetProblemFilesResponseObject interface {
+	VisitGetProblemFilesResponse(w http.ResponseWriter) error
+}
+
+type GetProblemFiles200JSONResponse ProblemFilesResponse
+
+func (response GetProblemFiles200JSONResponse) VisitGetProblemFilesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemFilesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemFilesdefaultApplicationProblemPlusJSONResponse) VisitGetProblemFilesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemStatementRequestObject struct {
+	Name ProblemName `json:"name"`
+}
+
+type GetProblemStatementResponseObject interface {
+	VisitGetProblemStatementResponse(w http.ResponseWriter) error
+}
+
+type GetProblemStatement200JSONResponse ProblemStatementResponse
+
+func (response GetProblemStatement200JSONResponse) VisitGetProblemStatementResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemStatementdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemStatementdefaultApplicationProblemPlusJSONResponse) VisitGetProblemStatementResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemTestCasesRequestObject struct {
+	Name ProblemName `json:"name"`
+}
+
+type GetProblemTestCasesResponseObject interface {
+	VisitGetProblemTestCasesResponse(w http.ResponseWriter) error
+}
+
+type GetProblemTestCases200JSONResponse ProblemTestCasesResponse
+
+func (response GetProblemTestCases200JSONResponse) VisitGetProblemTestCasesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetProblemTestCasesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetProblemTestCasesdefaultApplicationProblemPlusJSONResponse) VisitGetProblemTestCasesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetRankingRequestObject struct {
+	Params GetRankingParams
 }
 
 type GetRankingResponseObject interface {
 	VisitGetRankingResponse(w http.ResponseWriter) error
 }
 
-type GetRanking200JSONResponse RankingResponse
+type GetRanking200JSONResponse RankingResponse
+
+func (response GetRanking200JSONResponse) VisitGetRankingResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetRankingdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetRankingdefaultApplicationProblemPlusJSONResponse) VisitGetRankingResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetSubmissionListRequestObject struct {
+	Params GetSubmissionListParams
+}
+
+type GetSubmissionListResponseObject interface {
+	VisitGetSubmissionListResponse(w http.ResponseWriter) error
+}
+
+type GetSubmissionList200JSONResponse SubmissionListResponse
+
+func (response GetSubmissionList200JSONResponse) VisitGetSubmissionListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetSubmissionListdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetSubmissionListdefaultApplicationProblemPlusJSONResponse) VisitGetSubmissionListResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetSubmissionInfoRequestObject struct {
+	Id SubmissionId `json:"id"`
+}
+
+type GetSubmissionInfoResponseObject interface {
+	VisitGetSubmissionInfoResponse(w http.ResponseWriter) error
+}
+
+type GetSubmissionInfo200JSONResponse SubmissionInfoResponse
+
+func (response GetSubmissionInfo200JSONResponse) VisitGetSubmissionInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetSubmissionInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetSubmissionInfodefaultApplicationProblemPlusJSONResponse) VisitGetSubmissionInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetSubmissionEventsRequestObject struct {
+	Id SubmissionId `json:"id"`
+}
+
+type GetSubmissionEventsResponseObject interface {
+	VisitGetSubmissionEventsResponse(w http.ResponseWriter) error
+}
+
+type GetSubmissionEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetSubmissionEvents200TexteventStreamResponse) VisitGetSubmissionEventsResponse(w http.ResponseWriter) error {
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		// If w doesn't support flushing, fall back to io.Copy.
+		_, err := io.Copy(w, response.Body)
+		return err
+	}
+	// text/event-stream messages are typically small; use a
+	// modest buffer and flush after each chunk so clients see
+	// events immediately instead of waiting on OS buffering.
+	buf := make([]byte, 4096)
+	for {
+		n, err := response.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+			flusher.Flush()
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+type GetSubmissionEventsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetSubmissionEventsdefaultApplicationProblemPlusJSONResponse) VisitGetSubmissionEventsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostRejudgeRequestObject struct {
+	Id SubmissionId `json:"id"`
+}
+
+type PostRejudgeResponseObject interface {
+	VisitPostRejudgeResponse(w http.ResponseWriter) error
+}
+
+type PostRejudge200JSONResponse RejudgeResponse
+
+func (response PostRejudge200JSONResponse) VisitPostRejudgeResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostRejudgedefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostRejudgedefaultApplicationProblemPlusJSONResponse) VisitPostRejudgeResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetJudgeRunDiffRequestObject struct {
+	Id     SubmissionId `json:"id"`
+	Params GetJudgeRunDiffParams
+}
+
+type GetJudgeRunDiffResponseObject interface {
+	VisitGetJudgeRunDiffResponse(w http.ResponseWriter) error
+}
+
+type GetJudgeRunDiff200JSONResponse JudgeRunDiffResponse
+
+func (response GetJudgeRunDiff200JSONResponse) VisitGetJudgeRunDiffResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetJudgeRunDiffdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetJudgeRunDiffdefaultApplicationProblemPlusJSONResponse) VisitGetJudgeRunDiffResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostSubmitRequestObject struct {
+	Body *PostSubmitJSONRequestBody
+}
+
+type PostSubmitResponseObject interface {
+	VisitPostSubmitResponse(w http.ResponseWriter) error
+}
+
+type PostSubmit200JSONResponse SubmitResponse
+
+func (response PostSubmit200JSONResponse) VisitPostSubmitResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type PostSubmitdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response PostSubmitdefaultApplicationProblemPlusJSONResponse) VisitPostSubmitResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetUserInfoRequestObject struct {
+	Name UserNamePath `json:"name"`
+}
+
+type GetUserInfoResponseObject interface {
+	VisitGetUserInfoResponse(w http.ResponseWriter) error
+}
+
+type GetUserInfo200JSONResponse UserInfoResponse
+
+func (response GetUserInfo200JSONResponse) VisitGetUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetUserInfodefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetUserInfodefaultApplicationProblemPlusJSONResponse) VisitGetUserInfoResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetUserStatisticsRequestObject struct {
+	Name UserNamePath `json:"name"`
+}
+
+type GetUserStatisticsResponseObject interface {
+	VisitGetUserStatisticsResponse(w http.ResponseWriter) error
+}
+
+type GetUserStatistics200JSONResponse UserSolvedStatisticsResponse
+
+func (response GetUserStatistics200JSONResponse) VisitGetUserStatisticsResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+type GetUserStatisticsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
 
-func (response GetRanking200JSONResponse) VisitGetRankingResponse(w http.ResponseWriter) error {
+func (response GetUserStatisticsdefaultApplicationProblemPlusJSONResponse) VisitGetUserStatisticsResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetSubmissionListRequestObject struct {
-	Params GetSubmissionListParams
+type GetWebhooksRequestObject struct {
 }
 
-type GetSubmissionListResponseObject interface {
-	VisitGetSubmissionListResponse(w http.ResponseWriter) error
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(w http.ResponseWriter) error
 }
 
-type GetSubmissionList200JSONResponse SubmissionListResponse
+type GetWebhooks200JSONResponse WebhookListResponse
 
-func (response GetSubmissionList200JSONResponse) VisitGetSubmissionListResponse(w http.ResponseWriter) error {
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1582,39 +4302,34 @@ func (response GetSubmissionList200JSONResponse) VisitGetSubmissionListResponse(
 	return err
 }
 
-type GetSubmissionInfoRequestObject struct {
-	Id SubmissionId `json:"id"`
-}
-
-type GetSubmissionInfoResponseObject interface {
-	VisitGetSubmissionInfoResponse(w http.ResponseWriter) error
+type GetWebhooksdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type GetSubmissionInfo200JSONResponse SubmissionInfoResponse
-
-func (response GetSubmissionInfo200JSONResponse) VisitGetSubmissionInfoResponse(w http.ResponseWriter) error {
+func (response GetWebhooksdefaultApplicationProblemPlusJSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type PostRejudgeRequestObject struct {
-	Id SubmissionId `json:"id"`
+type PostWebhookRequestObject struct {
+	Body *PostWebhookJSONRequestBody
 }
 
-type PostRejudgeResponseObject interface {
-	VisitPostRejudgeResponse(w http.ResponseWriter) error
+type PostWebhookResponseObject interface {
+	VisitPostWebhookResponse(w http.ResponseWriter) error
 }
 
-type PostRejudge200JSONResponse RejudgeResponse
+type PostWebhook200JSONResponse CreateWebhookResponse
 
-func (response PostRejudge200JSONResponse) VisitPostRejudgeResponse(w http.ResponseWriter) error {
+func (response PostWebhook200JSONResponse) VisitPostWebhookResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1626,39 +4341,34 @@ func (response PostRejudge200JSONResponse) VisitPostRejudgeResponse(w http.Respo
 	return err
 }
 
-type PostSubmitRequestObject struct {
-	Body *PostSubmitJSONRequestBody
-}
-
-type PostSubmitResponseObject interface {
-	VisitPostSubmitResponse(w http.ResponseWriter) error
+type PostWebhookdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type PostSubmit200JSONResponse SubmitResponse
-
-func (response PostSubmit200JSONResponse) VisitPostSubmitResponse(w http.ResponseWriter) error {
+func (response PostWebhookdefaultApplicationProblemPlusJSONResponse) VisitPostWebhookResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
 		return err
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
 	_, err := buf.WriteTo(w)
 	return err
 }
 
-type GetUserInfoRequestObject struct {
-	Name UserNamePath `json:"name"`
+type DeleteWebhookRequestObject struct {
+	Id WebhookId `json:"id"`
 }
 
-type GetUserInfoResponseObject interface {
-	VisitGetUserInfoResponse(w http.ResponseWriter) error
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
 }
 
-type GetUserInfo200JSONResponse UserInfoResponse
+type DeleteWebhook200JSONResponse DeleteWebhookResponse
 
-func (response GetUserInfo200JSONResponse) VisitGetUserInfoResponse(w http.ResponseWriter) error {
+func (response DeleteWebhook200JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1670,17 +4380,35 @@ func (response GetUserInfo200JSONResponse) VisitGetUserInfoResponse(w http.Respo
 	return err
 }
 
-type GetUserStatisticsRequestObject struct {
-	Name UserNamePath `json:"name"`
+type DeleteWebhookdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
 }
 
-type GetUserStatisticsResponseObject interface {
-	VisitGetUserStatisticsResponse(w http.ResponseWriter) error
+func (response DeleteWebhookdefaultApplicationProblemPlusJSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
 }
 
-type GetUserStatistics200JSONResponse UserSolvedStatisticsResponse
+type GetWebhookDeliveriesRequestObject struct {
+	Id     WebhookId `json:"id"`
+	Params GetWebhookDeliveriesParams
+}
 
-func (response GetUserStatistics200JSONResponse) VisitGetUserStatisticsResponse(w http.ResponseWriter) error {
+type GetWebhookDeliveriesResponseObject interface {
+	VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type GetWebhookDeliveries200JSONResponse WebhookDeliveryListResponse
+
+func (response GetWebhookDeliveries200JSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
@@ -1692,8 +4420,43 @@ func (response GetUserStatistics200JSONResponse) VisitGetUserStatisticsResponse(
 	return err
 }
 
+type GetWebhookDeliveriesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       ProblemDetails
+	StatusCode int
+}
+
+func (response GetWebhookDeliveriesdefaultApplicationProblemPlusJSONResponse) VisitGetWebhookDeliveriesResponse(w http.ResponseWriter) error {
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(response.Body); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+	_, err := buf.WriteTo(w)
+	return err
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Progress of the automatic rejudge of outdated AC submissions
+	// (GET /admin/auto_rejudge)
+	GetAutoRejudgeProgress(ctx context.Context, request GetAutoRejudgeProgressRequestObject) (GetAutoRejudgeProgressResponseObject, error)
+	// List bulk rejudge jobs, newest first
+	// (GET /admin/rejudge)
+	GetBulkRejudgeJobs(ctx context.Context, request GetBulkRejudgeJobsRequestObject) (GetBulkRejudgeJobsResponseObject, error)
+	// Rejudge submissions matched by filters
+	// (POST /admin/rejudge)
+	PostBulkRejudge(ctx context.Context, request PostBulkRejudgeRequestObject) (PostBulkRejudgeResponseObject, error)
+	// Cancel a bulk rejudge job
+	// (DELETE /admin/rejudge/{id})
+	CancelBulkRejudgeJob(ctx context.Context, request CancelBulkRejudgeJobRequestObject) (CancelBulkRejudgeJobResponseObject, error)
+	// List users with a role other than user
+	// (GET /admin/users)
+	GetAdminUsers(ctx context.Context, request GetAdminUsersRequestObject) (GetAdminUsersResponseObject, error)
+	// Change the role of a user
+	// (PUT /admin/users/{name}/role)
+	PutUserRole(ctx context.Context, request PutUserRoleRequestObject) (PutUserRoleResponseObject, error)
 	// Get current user info
 	// (GET /auth/current_user)
 	GetCurrentUserInfo(ctx context.Context, request GetCurrentUserInfoRequestObject) (GetCurrentUserInfoResponseObject, error)
@@ -1703,6 +4466,15 @@ type StrictServerInterface interface {
 	// Register user
 	// (POST /auth/register)
 	PostRegister(ctx context.Context, request PostRegisterRequestObject) (PostRegisterResponseObject, error)
+	// List personal API tokens of the current user
+	// (GET /auth/tokens)
+	GetAPITokens(ctx context.Context, request GetAPITokensRequestObject) (GetAPITokensResponseObject, error)
+	// Create a personal API token
+	// (POST /auth/tokens)
+	PostAPIToken(ctx context.Context, request PostAPITokenRequestObject) (PostAPITokenResponseObject, error)
+	// Revoke a personal API token
+	// (DELETE /auth/tokens/{id})
+	DeleteAPIToken(ctx context.Context, request DeleteAPITokenRequestObject) (DeleteAPITokenResponseObject, error)
 	// Get problem categories
 	// (GET /categories)
 	GetProblemCategories(ctx context.Context, request GetProblemCategoriesRequestObject) (GetProblemCategoriesResponseObject, error)
@@ -1715,6 +4487,12 @@ type StrictServerInterface interface {
 	// Get hack info
 	// (GET /hacks/{id})
 	GetHackInfo(ctx context.Context, request GetHackInfoRequestObject) (GetHackInfoResponseObject, error)
+	// Stream status changes of a hack
+	// (GET /hacks/{id}/events)
+	GetHackEvents(ctx context.Context, request GetHackEventsRequestObject) (GetHackEventsResponseObject, error)
+	// Judge a hack again
+	// (POST /hacks/{id}/rejudge)
+	PostHackRejudge(ctx context.Context, request PostHackRejudgeRequestObject) (PostHackRejudgeResponseObject, error)
 	// Get language list
 	// (GET /langs)
 	GetLangList(ctx context.Context, request GetLangListRequestObject) (GetLangListResponseObject, error)
@@ -1727,6 +4505,21 @@ type StrictServerInterface interface {
 	// Get problem info
 	// (GET /problems/{name})
 	GetProblemInfo(ctx context.Context, request GetProblemInfoRequestObject) (GetProblemInfoResponseObject, error)
+	// Get the examples of the current test cases
+	// (GET /problems/{name}/examples)
+	GetProblemExamples(ctx context.Context, request GetProblemExamplesRequestObject) (GetProblemExamplesResponseObject, error)
+	// Download a public file of the current version
+	// (GET /problems/{name}/file)
+	GetProblemFile(ctx context.Context, request GetProblemFileRequestObject) (GetProblemFileResponseObject, error)
+	// List the public files (grader, headers, ...) of the current version
+	// (GET /problems/{name}/files)
+	GetProblemFiles(ctx context.Context, request GetProblemFilesRequestObject) (GetProblemFilesResponseObject, error)
+	// Get the problem statement (task.md) of the current version
+	// (GET /problems/{name}/statement)
+	GetProblemStatement(ctx context.Context, request GetProblemStatementRequestObject) (GetProblemStatementResponseObject, error)
+	// List the test cases of the current version with their sizes
+	// (GET /problems/{name}/testcases)
+	GetProblemTestCases(ctx context.Context, request GetProblemTestCasesRequestObject) (GetProblemTestCasesResponseObject, error)
 	// Get ranking
 	// (GET /ranking)
 	GetRanking(ctx context.Context, request GetRankingRequestObject) (GetRankingResponseObject, error)
@@ -1736,9 +4529,15 @@ type StrictServerInterface interface {
 	// Get submission info
 	// (GET /submissions/{id})
 	GetSubmissionInfo(ctx context.Context, request GetSubmissionInfoRequestObject) (GetSubmissionInfoResponseObject, error)
+	// Stream status changes of a submission
+	// (GET /submissions/{id}/events)
+	GetSubmissionEvents(ctx context.Context, request GetSubmissionEventsRequestObject) (GetSubmissionEventsResponseObject, error)
 	// Rejudge a submission
 	// (POST /submissions/{id}/rejudge)
 	PostRejudge(ctx context.Context, request PostRejudgeRequestObject) (PostRejudgeResponseObject, error)
+	// Compare the case results of two judge runs of a submission
+	// (GET /submissions/{id}/runs/diff)
+	GetJudgeRunDiff(ctx context.Context, request GetJudgeRunDiffRequestObject) (GetJudgeRunDiffResponseObject, error)
 	// Submit a solution
 	// (POST /submit)
 	PostSubmit(ctx context.Context, request PostSubmitRequestObject) (PostSubmitResponseObject, error)
@@ -1748,6 +4547,18 @@ type StrictServerInterface interface {
 	// Get user solved statistics
 	// (GET /users/{name}/statistics)
 	GetUserStatistics(ctx context.Context, request GetUserStatisticsRequestObject) (GetUserStatisticsResponseObject, error)
+	// List webhooks of the current user
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// Register a webhook
+	// (POST /webhooks)
+	PostWebhook(ctx context.Context, request PostWebhookRequestObject) (PostWebhookResponseObject, error)
+	// Delete a webhook and its delivery log
+	// (DELETE /webhooks/{id})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+	// Get the delivery log of a webhook, newest first
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhookDeliveries(ctx context.Context, request GetWebhookDeliveriesRequestObject) (GetWebhookDeliveriesResponseObject, error)
 }
 
 type StrictHandlerFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error)
@@ -1779,6 +4590,168 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// GetAutoRejudgeProgress operation middleware
+func (sh *strictHandler) GetAutoRejudgeProgress(w http.ResponseWriter, r *http.Request) {
+	var request GetAutoRejudgeProgressRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAutoRejudgeProgress(ctx, request.(GetAutoRejudgeProgressRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAutoRejudgeProgress")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAutoRejudgeProgressResponseObject); ok {
+		if err := validResponse.VisitGetAutoRejudgeProgressResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBulkRejudgeJobs operation middleware
+func (sh *strictHandler) GetBulkRejudgeJobs(w http.ResponseWriter, r *http.Request) {
+	var request GetBulkRejudgeJobsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBulkRejudgeJobs(ctx, request.(GetBulkRejudgeJobsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBulkRejudgeJobs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBulkRejudgeJobsResponseObject); ok {
+		if err := validResponse.VisitGetBulkRejudgeJobsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostBulkRejudge operation middleware
+func (sh *strictHandler) PostBulkRejudge(w http.ResponseWriter, r *http.Request) {
+	var request PostBulkRejudgeRequestObject
+
+	var body PostBulkRejudgeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostBulkRejudge(ctx, request.(PostBulkRejudgeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostBulkRejudge")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostBulkRejudgeResponseObject); ok {
+		if err := validResponse.VisitPostBulkRejudgeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelBulkRejudgeJob operation middleware
+func (sh *strictHandler) CancelBulkRejudgeJob(w http.ResponseWriter, r *http.Request, id int32) {
+	var request CancelBulkRejudgeJobRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelBulkRejudgeJob(ctx, request.(CancelBulkRejudgeJobRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelBulkRejudgeJob")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelBulkRejudgeJobResponseObject); ok {
+		if err := validResponse.VisitCancelBulkRejudgeJobResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminUsers operation middleware
+func (sh *strictHandler) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	var request GetAdminUsersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminUsers(ctx, request.(GetAdminUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminUsersResponseObject); ok {
+		if err := validResponse.VisitGetAdminUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutUserRole operation middleware
+func (sh *strictHandler) PutUserRole(w http.ResponseWriter, r *http.Request, name UserNamePath) {
+	var request PutUserRoleRequestObject
+
+	request.Name = name
+
+	var body PutUserRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUserRole(ctx, request.(PutUserRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUserRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutUserRoleResponseObject); ok {
+		if err := validResponse.VisitPutUserRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetCurrentUserInfo operation middleware
 func (sh *strictHandler) GetCurrentUserInfo(w http.ResponseWriter, r *http.Request) {
 	var request GetCurrentUserInfoRequestObject
@@ -1846,18 +4819,99 @@ func (sh *strictHandler) PostRegister(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostRegister(ctx, request.(PostRegisterRequestObject))
+		return sh.ssi.PostRegister(ctx, request.(PostRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostRegisterResponseObject); ok {
+		if err := validResponse.VisitPostRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAPITokens operation middleware
+func (sh *strictHandler) GetAPITokens(w http.ResponseWriter, r *http.Request) {
+	var request GetAPITokensRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAPITokens(ctx, request.(GetAPITokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAPITokens")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAPITokensResponseObject); ok {
+		if err := validResponse.VisitGetAPITokensResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostAPIToken operation middleware
+func (sh *strictHandler) PostAPIToken(w http.ResponseWriter, r *http.Request) {
+	var request PostAPITokenRequestObject
+
+	var body PostAPITokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAPIToken(ctx, request.(PostAPITokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAPIToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostAPITokenResponseObject); ok {
+		if err := validResponse.VisitPostAPITokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteAPIToken operation middleware
+func (sh *strictHandler) DeleteAPIToken(w http.ResponseWriter, r *http.Request, id int32) {
+	var request DeleteAPITokenRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAPIToken(ctx, request.(DeleteAPITokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostRegister")
+		handler = middleware(handler, "DeleteAPIToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostRegisterResponseObject); ok {
-		if err := validResponse.VisitPostRegisterResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteAPITokenResponseObject); ok {
+		if err := validResponse.VisitDeleteAPITokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1972,6 +5026,58 @@ func (sh *strictHandler) GetHackInfo(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
+// GetHackEvents operation middleware
+func (sh *strictHandler) GetHackEvents(w http.ResponseWriter, r *http.Request, id HackId) {
+	var request GetHackEventsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHackEvents(ctx, request.(GetHackEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHackEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHackEventsResponseObject); ok {
+		if err := validResponse.VisitGetHackEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostHackRejudge operation middleware
+func (sh *strictHandler) PostHackRejudge(w http.ResponseWriter, r *http.Request, id HackId) {
+	var request PostHackRejudgeRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostHackRejudge(ctx, request.(PostHackRejudgeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostHackRejudge")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostHackRejudgeResponseObject); ok {
+		if err := validResponse.VisitPostHackRejudgeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetLangList operation middleware
 func (sh *strictHandler) GetLangList(w http.ResponseWriter, r *http.Request) {
 	var request GetLangListRequestObject
@@ -2070,6 +5176,137 @@ func (sh *strictHandler) GetProblemInfo(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// GetProblemExamples operation middleware
+func (sh *strictHandler) GetProblemExamples(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	var request GetProblemExamplesRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProblemExamples(ctx, request.(GetProblemExamplesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProblemExamples")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProblemExamplesResponseObject); ok {
+		if err := validResponse.VisitGetProblemExamplesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProblemFile operation middleware
+func (sh *strictHandler) GetProblemFile(w http.ResponseWriter, r *http.Request, name ProblemName, params GetProblemFileParams) {
+	var request GetProblemFileRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProblemFile(ctx, request.(GetProblemFileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProblemFile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProblemFileResponseObject); ok {
+		if err := validResponse.VisitGetProblemFileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProblemFiles operation middleware
+func (sh *strictHandler) GetProblemFiles(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	var request GetProblemFilesRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProblemFiles(ctx, request.(GetProblemFilesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProblemFiles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProblemFilesResponseObject); ok {
+		if err := validResponse.VisitGetProblemFilesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProblemStatement operation middleware
+func (sh *strictHandler) GetProblemStatement(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	var request GetProblemStatementRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProblemStatement(ctx, request.(GetProblemStatementRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProblemStatement")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProblemStatementResponseObject); ok {
+		if err := validResponse.VisitGetProblemStatementResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProblemTestCases operation middleware
+func (sh *strictHandler) GetProblemTestCases(w http.ResponseWriter, r *http.Request, name ProblemName) {
+	var request GetProblemTestCasesRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProblemTestCases(ctx, request.(GetProblemTestCasesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProblemTestCases")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProblemTestCasesResponseObject); ok {
+		if err := validResponse.VisitGetProblemTestCasesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetRanking operation middleware
 func (sh *strictHandler) GetRanking(w http.ResponseWriter, r *http.Request, params GetRankingParams) {
 	var request GetRankingRequestObject
@@ -2148,6 +5385,32 @@ func (sh *strictHandler) GetSubmissionInfo(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// GetSubmissionEvents operation middleware
+func (sh *strictHandler) GetSubmissionEvents(w http.ResponseWriter, r *http.Request, id SubmissionId) {
+	var request GetSubmissionEventsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubmissionEvents(ctx, request.(GetSubmissionEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubmissionEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSubmissionEventsResponseObject); ok {
+		if err := validResponse.VisitGetSubmissionEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PostRejudge operation middleware
 func (sh *strictHandler) PostRejudge(w http.ResponseWriter, r *http.Request, id SubmissionId) {
 	var request PostRejudgeRequestObject
@@ -2174,6 +5437,33 @@ func (sh *strictHandler) PostRejudge(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
+// GetJudgeRunDiff operation middleware
+func (sh *strictHandler) GetJudgeRunDiff(w http.ResponseWriter, r *http.Request, id SubmissionId, params GetJudgeRunDiffParams) {
+	var request GetJudgeRunDiffRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetJudgeRunDiff(ctx, request.(GetJudgeRunDiffRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetJudgeRunDiff")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetJudgeRunDiffResponseObject); ok {
+		if err := validResponse.VisitGetJudgeRunDiffResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // PostSubmit operation middleware
 func (sh *strictHandler) PostSubmit(w http.ResponseWriter, r *http.Request) {
 	var request PostSubmitRequestObject
@@ -2257,53 +5547,230 @@ func (sh *strictHandler) GetUserStatistics(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request GetWebhooksRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhooks(ctx, request.(GetWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostWebhook operation middleware
+func (sh *strictHandler) PostWebhook(w http.ResponseWriter, r *http.Request) {
+	var request PostWebhookRequestObject
+
+	var body PostWebhookJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostWebhook(ctx, request.(PostWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostWebhook")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostWebhookResponseObject); ok {
+		if err := validResponse.VisitPostWebhookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, id WebhookId) {
+	var request DeleteWebhookRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebhook")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetWebhookDeliveries operation middleware
+func (sh *strictHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, id WebhookId, params GetWebhookDeliveriesParams) {
+	var request GetWebhookDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhookDeliveries(ctx, request.(GetWebhookDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhookDeliveries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetWebhookDeliveriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, compressed with deflate, json marshaled OpenAPI spec.
 // Stored as a slice of fixed-width chunks rather than one concatenated
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"zFt7b9u6Ff8qBHeBtbhK7LRdt/m/3KDbuqW3WdJgwIJMYKRjm7VEqiSVW6/wd78gqQf1tKTYQf5qY/Nx",
-	"zo/nfY5/4IDHCWfAlMSLHzghgsSgQJi//kGCzcdQ/y8EGQiaKMoZXpjPEQ2BKbqkIE6xh6n+PCFqjT3M",
-	"SAx4gWmIPSzgW0oFhHihRAoelsEaYqKPXHIRE6XXMfX2DfZwTBmN0xgv5h5W2wTsV7ACgXc7z1x6SWOq",
-	"mvR8It/1TsTS+AEE4ku0JsFGIsWRAJUKhl6dnZzN5/PXBanfUhDbktbIHOySF8KSpJHCi7P53Gsh1l5p",
-	"vp47tJ910n6zoUmT9F+bJMsNTdADLLkAFPAogkBRtkICZBop2cWB3tXOQCv5/VhfCf4QQfyrObpOcvbl",
-	"fgEw//SJwE8ClniB/zArhXBmv5UzlwRN0jVhG8pWgyVA2PVIQMBF+IJkIWNknzi00P8CBOMmfYiplJSz",
-	"NrtQfvvs1qG8erCEyGLLC5KOko99AlIj/wUIx60EofX1Sj90g3L97ZFthr6CWYOxy/cYR3axJmwFF6kQ",
-	"wJRe9ZEt+TV8S0EaUSFhSDWZJLoSPAGhKEi8WJJIgocT56MfOJUghtBhECnZuLMb7wvg+MNXCBTeeV3E",
-	"yYQzCXupax4ngCjQHsdhsMpDKTqjVc3DCqTyAyLBD5Kksv9hq/TbZVukEpStqjvUdzVgRw04h9pW+CYC",
-	"94RnbdBgAqXa5dXzv6bhCnyeqiRVg0DjjyAeKfy2jyh99ed8rZZ6FYIQI9/lmV6y4Om+A8NLKlU3hgFP",
-	"mWqV2KaUmmhKr6UKYjkWxOw8IgTZNriwR3sZOV2sfHber8qG3u8rakOrgpWQKDgxn7bgTsMJihpDzMW2",
-	"vvH9u1a8pCIqNeQ1Li/1z59ER4PVZcSJKqmwHlmv1Erosyzo7BclEz5UKSuY8ByIu56nW8om8Nikre3a",
-	"S8JWI62SpaXxIh0QefgRRG7VB8CX+dp8UxfNdaUcQX9E2Gq4FhqA9mmfPbKVVvogiNjeiqgZfHxOLMXo",
-	"9voSLblAag1Ii9sfJYrsPpQIvqQRnKJbCYgwBHGitsgCqCOsDUCCqEIpk6BObWR3CWylg50383mL2n7i",
-	"jCqu/5qInyJy439LIYV92H0hcvNvvVC7IQMiVyTynSBxoOG0+zQ0w3bUnsfd3kaE5/LU9opZ3jcSqFwj",
-	"BmeU2iypaIChyZTEru4h+IIoWHFBQU5866A4YLDCVK/e7tUd54r9nGxH0p/Y3aOpL96jQvng97HLvPL6",
-	"Hs6eEB/q0IVEkd9tYT0seSoC8NM2A/Thu9K5SYQELEEAC6AwQxnlSHsviIEZ29IaeunIS/aSoH2eH+UZ",
-	"cMPjtjix0vt2AT7CreSP4SBRoak8qo0hr4Fyz1s+wStNldS9+jVEBofW1FDAmaTSJPF8iSL+GwgNF4pA",
-	"KRDSQyFdUSU9xAVKWQhCBlyArDmms6zmUPzt6YRbyyJe4P/dkZP/z0/+6t///FObzGWlqqkGrTNc748X",
-	"tR5oxoPhD6Qzs5ty2753cm7oC+OvYUWlAjGtUDDEJTkFixav00/T1PrANZhcdPoBNzx6hPCmSBhqNUDz",
-	"LbKhuLFxJLdwWjSB6Xe/w5fnXz7cfPHPL7CHzy8cVkvpK0thF0Rqek1dqpEUEtlutII1BBsQOucelNse",
-	"LF0akYAPzIwablyCm+1kWWPGQJvUOGXb3ipFQJgvrHw4vD1wHgFhBlSd+2c1xcHa2fqQLR5fb6UR+CAE",
-	"FwctkpQUVEolxkvt92rFLcUWr4JUP+KHq2nUgvmR2A+ub1TD9W7z6BwtQls9K6rH+MRkmHXTIKw708uN",
-	"aXCuco2D3fxzLYFvswzdVZaONL6JKpV+RFRm35sSH2VZ+9PsRWYB/c7MPV/QHYQNq9CMqysdrzRTYbjO",
-	"Xoaqi/14c6amueX8PZ0Y6f27eojU9TwjU5rSwNQVQX+OAh4CUhwZK4JexeQ7OkOf6C+vGzHcu7/86c/v",
-	"9xKpIvA3jAebzOXVhbk9WnWtmgGnD/FJgeDRKmrVosfIJABYSNnKV0RuhtZGRMrY2D22BDJ8R/2RKmTW",
-	"Sage3wbRbdbXGPNc0g/hESL9WYdNtBWzPNPtremVRTmncDk9Jq5e7lWJ7QLgufpCg9p9JlcpQmibhkwk",
-	"Tppj/Jgk3Rv3hAduLN/sbNUjg/K+Ts4qydszZIuHEKnuGKfY2WxnM/ottTXknqy9nquvt8kaWC1nr5n7",
-	"t70Z+/nJf23SftKetWu3A0EqqNreaPYtuksq4IFIOE9ta/4BiADxtxzqf/7nSz4FYFTdfFuevVYqsT11",
-	"mhnaLEzJa+7owiZb6PrDzRd0fvURvTKvRqLXTs1ngeenZ6dzE7gnwEhC8QK/PZ2fvsWGx7UhdUZStZ4F",
-	"tqvr5/q3AiMcWmKIysZP8N9B1bq/ZnTA6pI57M18boWLKbDiRZIkooE5Y/ZV2qLWsOmCrkazAabWbviX",
-	"fYg0jomOETWlKGMpE5msTJ8QFaybnF3pj9t4MyHPLzzcHo6tvtmIXVVjlEhhd0yIe0cheoDOJB4v7uqy",
-	"fne/u3dfwl7R9hg7L5M9kdVYTJjAZYvgXXGd02WrjvMs9eLTM79Eo850EPDzUw3sFvFq26NLzRtNlmMq",
-	"endHZ6iq5yV9hznDbDGV0MVnPgRhDGI5invXTnC5ZFZMmO68QWvtlJxe3DYWZp7HnbpqOJn2fUUON3qn",
-	"qQnUJtHqtYOscPDqhIYoqzR4iIYe0hmjh0yW+7qleaJl8GjC0hhbGSIjeoMd+DU+oNPG6MOPZfYbY2LP",
-	"bGEqUxgHsS42STW4IgVSIVMoLRVv9oOGu33al3na8dr3McRHF7RJcYfBo3RxxUxGFwr5zMcxTWxjrmQo",
-	"M5r6lKwARVTaBGUWF1MWfVyVsxjH5Ktl4mMoZyUbKCSKWN7cVuUe3/gcLnHSkxU8VDia/dCWfzeAsUka",
-	"WanE3R8fmUmamYcJpXJmU/99qGRN2dGIuL87GBAkVH5vcVQE613moejlWBngar2RLvCqbZnRGNam8wfA",
-	"WP9dQlf84xRjp/w85tDhmPYZELbtdKrJA2PHoZWZ9uNCCNPktiMe3UtM1mc4QDg6sMlm9h034uxoLQ5V",
-	"G/d3I6UTdT7dGyZVu8lPUKJjh0wdbe/xSDkWug7UzOmX9xUL7KKXi1V9LORA+b7tbREHSwdG1Q+ajeeP",
-	"lP9Ue4jPnPvU2mmHzH4IkjxKVQG0GcUdEGw5VcZxIlr51ddRRXRy5bVW5HMhmVWnzfrQuXGnxl4yRp3d",
-	"pVF4yXKQKx+rsxIpHnOuTf8Pz/Dufvd7AAAA//8=",
+	"7H3pcty2lvCroPjdqk+qUC05271XU/eHIjuJM3askeTy1ESeDpo83Y2IBBgAlNRx6d2nsHEFt5Za0lTN",
+	"L1tNAjg4G86Gwy9BxNKMUaBSBMdfggxznIIErv/6GUfXb2P1vxhExEkmCaPBsf4dkRioJEsCfBaEAVG/",
+	"Z1iugzCgOIXgOCBxEAYc/swJhzg4ljyHMBDRGlKsplwynmKp3qPym6+DMEgJJWmeBsdHYSA3GZhHsAIe",
+	"3N+HetF3JCWyDc97fKdGIpqnC+CILdEaR9cCSYY4yJxTtPfq4NXR0dF+AeqfOfBNCWuiJ66CF8MS54kM",
+	"jl8dHYUeYM2S+vFRBfZXnbBfXJOsDfqvbZDFNcnQApaMA4pYkkAkCV0hDiJPpOjagRrl34AX/H5cn3G2",
+	"SCD9VU/dBNk+HGYA/U8fC/yNwzI4Dv7fYcmEh+apOKyCoEA6x/Sa0NVoDuDmfcQhYjx+QbxgNzLEDh74",
+	"XwBjXOSLlAhBGPXphfLpk2uHcunRHCKKIS+IO8p9DDFIA/wXwBwfBXAlr2eK0C3I1dMd6wy1BHUK4xMs",
+	"1ozZ8+sRGLC14Xs1XGSMCtCn5RvOGVf/iRiVQDUP4ixLSIQVCg4zo9G++kMofHyZpgdfg8QkEWbZOmLN",
+	"uup3O0ZNeXL29pJdg14o4ywDLokBM+KAJcRzLGvbjLGEA0lSKLcqJCd0FdyHAdxlhIOYNIbEo9AYBgkW",
+	"cp6LiSBReza1HmQcluSuzX8/Ei4kitaY40gCF0qG5BqQgIiDVCJkmXOjf5YKezPfyiJimUElkZCKIQI6",
+	"SlyoYcF9MSHmHG+C+/sqE/5muNIKgd1JsWJYpd3nYiK2+AMiqWZ2S70jQp5b1mwzgN7ZdPgHQbfz9gFm",
+	"cNAijVZlEuEkYbfCKDaptRemMeLwRx6vCF2F2kRyb2lUqHfUj2J2RYMwAKqU0292viAM1LPgs4eGJ3FK",
+	"qFIXTVThOCYKKpycVZC2xImAsIHHXAAfj0a12CAKzZReDOaSnWtMwBlnKw5CTAR3SSgR64lSZnWWV9D+",
+	"zCGHsUIuJOZTtY4EIecRFiDmN8AFMWqz/RqTOGkz1YdcqtljdHJaOytv10CNhIPSB1gAyjP1JrrFAlEm",
+	"SQSxEvzhE6BOPIcrL+AOzAJtNZSMpPgDmDWzU0xRXG0ABhm4soxvTz/kybWd8he2mLiFCNMIEsNxduYF",
+	"YwlgrZqcalxsvEyyJIkE7jsVkuph8AdbIELRLxcffvXq/q2EaPRJmHHCOJGbka9PEkCOJexSVv1C+B7L",
+	"aA2xXwAVspXMWdJtI3P6uLS0reDPbrfGFf0SGJbsNcy4D5DDP9hivATWVx0UPj33APTn8GcOQg5CXSfj",
+	"iUQJYCERo4AMulGaC4kWgFbkBugMOUHC2v9IF4RCjG6JXKOTX1+H6iS/oilgQ3mBU0BY6P9rjwSVgScl",
+	"ij+9uUSHFZ4xx3sdkzHfzHlO/dpAHfxdmoKIeYKlRULjzKDJpsarGmcx2mNcnQz2z32zLwV8lHMOVJZn",
+	"iaiojcqaCaar9nLvMF3leAUhYlwhLcVIgMKEOrYS+1CgPZitZijKspDnQu57FROzp92c0WTj8cwtwou9",
+	"/0vT2Q9sVQ3Vp7nE4hq5x2jPOovou/0ZesdugSO5xtRabCDQYoO0RYP2vj3a94p34Rx/889+97LfFHHK",
+	"rQ2tQECNvKMMuDL2GY1LyF8NwfVqyGcPA0FoBOM1pZBY5sJDIv27lxXMkIITPp2El+/e+Pkgp5Ik44FR",
+	"5PFg9H5IiWyl+yKWU9kX0kjbp0WTOt9/6z2yepXBH2wxJ55w1dvX7ti3x4Q6kWboVyaRAGmE3E68zdlk",
+	"tlvC5tPMp2tMV3BqtIhyEd7SJRuro9vuyDgnxON0TAFuJO3b02kkO1ewssn6PlysgdB5jDceQXmNNwJp",
+	"Pi8ddWRHzdBl8ROFG+DuASJLxJRb2WFn9Iu4Czak+O4d0JVcl4qh+HsHkYKU0Ldm4KuBo99GDOyCn0dg",
+	"vys6YMIhbaz/AJgrBa/DIuitOu2oOhIXgDhITuAGYoRXmPiDJtIFo8aFGnyhhSB0wHXvTyVaOjmrVCuT",
+	"I8wVn24eZVlt/GIj+93XubyTI0Y09lyBtnu/Nsi5ncbASTIvghhF4LcuvhU1CjcuSziKmy1ob9SoAWYO",
+	"g5x7nIefLy/PLtDH83eIQwTkRkV7zj5cXCJutitmg0hU8xaQj0DjVudal8j8/P7k9ODi55Ovv/seXcNm",
+	"ht7gaO2AR2ss0H8evCMLjvnmdA3RNfCDC7KiWOYcjq+oWOOvv/v+X2u421Mz7ZllwvagS5KCkDjN0Ffo",
+	"KphdBegrtGDxZn9/dkV7JVWb1S3GvTXYGEngFs7d8H5x3fJUecCZ14LhNSQwnfataXT8/ZTF4LXpFgmg",
+	"FEdrQuGAA471D6CGoIjFMEOnCVHwIrFmeRKjBcc0WiOmHCUiriihQgKOnaWSghB4Bf+GKNzqCQRK8UbR",
+	"FscxxPU4KKE3OCHx3LJcEBa/FO5WoExGnMs14+QviCuv4IzMnd5dMr4gcaz/r3A+p0zOOayIkMD1KPXD",
+	"kuU0DgpLfV79LafXlN3SeRkkc784PycIgxTkmsV6nI7y6pl1wm0OdxFArH+QjM1TTDfzDGhM6GoulZ1f",
+	"feD0QxAGEaPLhERm7xI4xclcY1+zJ78hEcxzim8wSRRlvMHiHwkkcZHdmRRyhcRjd6ocmaOn0wZKXpF+",
+	"XzkABXm8ZykHLLyx0IYgmuWL931iqCs8GvLXCFgog3/OcpnlctSxx24UWuF2SC7V0h/cu9ovioHziSfr",
+	"E53FxZ66cNifcSkcnxHBN53OGH3INpHYaySaqUMLTtdWPlToV9+GGj/XnuRDQ5/9plYKKeOtIGiH51e6",
+	"063FSwtqvhUcra0uE6ZDhfZVU0rg3Oh5R0bSF7GsQ1ZsIqyguIs8U13wjkm6WHULRLU32AW7CXEYi7C1",
+	"8pJQXwT5kuegnDebBEQuAq9zg5ShlHFAxsBDS6ZODX9Qa3QQ/rG4byz3ePnD8YNBig+fv2guyD0JfqDx",
+	"40hpe7ua9by7nYo1LifC2IdpEHJEmvAx6NFeyiHFLlBgorbLPgKeYgGvyXLZJuQCCxg6AMp6ITXPua72",
+	"UbNHdmwLDZEO6sTdgqbj83q/KCbLpY7Ic/2rmhPBHRFSqCSZijTrhICNj7VlTmK+ArndFprBNLWfEvo+",
+	"hCpkdiu4MUh1MzlEjj+PW0S9b/u647BSwtDAxMJgws7iAPQh5J1NOkywWEnsZZrOYptugeupa3GDumB+",
+	"QHpNORPjqaURNGQ1mSm9sBo3/KMvdvEhMxDr8MXSClAugP9/gRIzDmWcLUkCM/RRAMIUQZrJDTIIVPVI",
+	"1wAZIhLlVICcmayECzd+fXTkUZLvGSWSqb+2xJ9ypeY6XzKEO5Vc+Q/1onIfigzwvBK6H3mmmHFFHGpq",
+	"lL063AdEWN2Tj4pnZV5pAqKcRIwuYVbng0xGGIhWSMzbPQCfYgkrxgmIbdMxxQSjBaa+9GZQdipLDO9k",
+	"M72kRY2eDH1Bj6ZeHkcf81pYLt+zM1e12VIPOppgnP698x9P0d//cfT3EHVVi+pUX9OljAf5rwxM6ZpR",
+	"BYonSJmnmJaRKRtfCnVUyZy23hiEjqGMR30lhOLBPKFCYptEbdw1wHLdCJXM+q3DMRrHEbq+1rkOk6Bs",
+	"zZWdw6qWkHdN80NzlpPkVuWn8ILl8niRYHodKr2vY3WtIlONxeEotn4aFpxXWKNqzj72e3OH0yyZqhcI",
+	"7bUBwE3q/jc/OuqoSBit7AgNzIDhzWyr7Sywk7WFw6FPYYzwO5qU9PgPBWQ9m/+RTCZj5q3C1wLFIcGS",
+	"3Gh+VGxodQ2KCdf1BzmNwVQhMHqorRexxhxitAYcA/eLgyB/wSj/r4ESW5Wvhw9gYFvaKytrMuE1yj1U",
+	"V7FAlTkbTfPmgNDC07PZB6REhsELA8FyruLePsP1zZ2JkiMOS+BAIyjMV8cmSv9ACtSvisd74/PEXdVp",
+	"+eSeeFMZbes6qCe4I4UqLTFRg6mcyu/zN7HcQ8uHFe1uZeGMKdMdtF3GXv5DEaOCCF2Lz5ZIpW24jhQk",
+	"ICVwEaKYrIgUpWYREeMgGg6Np54iw2oCtep//4YP/jo6+Of881d/8/GcherCMeYuZcet0caNcjRmaaxi",
+	"I+8xv47ZLZ2NSi3UtUO5Qg91LkHIUzx5hyugwLFknkron9wjBT+hSzaTLE1CpEvOOKYxS2dRls3QG+2m",
+	"kiWyGTyvFiB0PvpAKG2L7jyZ4qgaLPOjo65CyPm2R5G1RkoklfuoTDyCKtseVIWqmSrxbuFtzZTCcyZ/",
+	"eQhxqZ4h9cyRg9AslzoEbzKDSB9po4r1RlhEFVgq4HsRb2/TPrQicWJmSImoUnnRtPs/F+WwIQ1dWaEv",
+	"YXduc/DbFQCNCWLU7lS2hKUfpm1TVA/OcZ3DDbv2FrtNm+eCJTcQX3RV7OqnLliurCTsbKRZpQrj3cnl",
+	"m4vL+clpEAYnp94KA28IvJ1G7o7p60KgORuZon+0BOuElP122Rcb9i/zL7Uci4/7KjfUe+saIkzntlC9",
+	"40KRqhaw16dHS3lXOqapk9VQkoAtRXnMsooSgmpdAM+ph4F1jkGlb8rLsMXoELEkBqE0OzdBl0kJEN+m",
+	"jbE9zmHSgBdDwhq5+sk+shRjQg166CqHldGjqsLnehZEBCpuUYwIO1G4k/Mo58JnfZ3q3x0Z1KsoMzdD",
+	"7NLM3JxJsDBP/D54Pfo+kWVHF5JUl+knxgceA6+VlwYHOhXU1KTc+A/qdRNwqF8BcLrUDP6qkVT1KdLu",
+	"MpbR2e7aRaHuOz0PU69FsVz3fXbzQrfXO64EZlq6fXe1L7UNN7dnsVrF/Zbav7fWpKnbG7KovFf71MbA",
+	"Y6Rv+dgwCNwQlgtTdTJaM449Gv6vDsZXd9Goreiui9FoltvZw06iK2GJ778dvOVRuZg2IftUHoZNVah+",
+	"L9IF+sRDeym+Q6/Qe/LDfits8u0/vvv794NAygTm15RF1/WwfMEYnXfZixNYI6cP41t5YDurN6vnp6cB",
+	"VS8sHicuPKd06hjj544f0SRSo/65DkJ9eh+KPuq2B8rBO2cJbCcynCWjXEi1QmsDevAYyLb13z7a+wFT",
+	"GFLMY7iBRP3WoYWVfZKy2MSIhFbAWPUTETP0dkUZN9aaaSrRcR3YVIC4CHxvjUpZZFIJlI3z2MOHkcee",
+	"0FVgwzp+Pnfg/KmudIy6xljsr61rQZoqtsXGUhDJNWf5ao3OPl6iQ/3boZpYHH5R2Lg/VAitGqR61TAo",
+	"2CEIAz3Ka53qGFARUjDhnS3RJPQ08xRn3QMH7JFqbKN9PaZp8pfrdeH4ohYUe4Io3MPDV32htWJku5MZ",
+	"JX/mppqrJw/SzH6sN9kaaCML0jjNv+nNgZwc/JdJgxx05UE+lTe3tr8HWN3qpzXINZj0n73XZS/jgene",
+	"YC1OtlT/4+a+v1/nbdV37GFXDlt1JmNtX6uXR3gztQuGYQWVg826LLCvISEKc1NpJiWkmRxraWyFfBeb",
+	"8pUusWUZjrCwzDopOJVu01rH2eUn7U3HYuoD20fD7ZokgKyVVQvy9M6d4U3CsKfCWnUVMjVfQvcMYVWx",
+	"6rjpZQ6HeeS9XqguyLrws4cgtbiVEmPX4gq5eUdGrkrvbgQVHUO7k8UnNYYrSlRVPL2CsacK0APy7LGZ",
+	"YkolZGPxwZBZZYkRe+nKOFheROVsut+OudZr2+0scHTNlssQLTFJtCkKAt0Ct516UJ5V7Rc7o76TFBVX",
+	"LM1Qrw1Tk9XjL8VEZaRp5kIT9j5T+XfPfA+gnhWgybQbpFkx8WefdSQgyjmRmws1q1XKGSmabjbKJoAL",
+	"tRl0cvbWtqdwPUcWG3Op/VDdwj3Uz4QuGYxVpywOiEiBdGMH2/pw5hq26gMWMNcWqAVwLWVmokgcFljA",
+	"SW6qwMx7PzpZ/+XT5fAs9zqPb7xnG310Ne/I3j1H528uLvWm9rSthpP9Su3McXA0ezU7UvCwDCjOSHAc",
+	"fDM7mn2jZV+uNdasmY1zyaoZmZXvSv25IY+xPfQ45CxyxRK6dFZ1fA1+AunrX9do2fr10VFPw9ZpjVqH",
+	"+vV5Ord++HdTmWtD4/75C4APK41eLe8Fx799aZD6t8/3n5UwpylWRkXgYHEHhEJziiWJXJsm9YD5eyXq",
+	"tSx5HpMy9b5mO6VKT+O2ZyKIggEt8uS6IIDq2xaqC/1Fuk2bEUxMQDP6VOlWFBqvVvs25k2b1bqi1bwK",
+	"+qCMglsiXAMkgbACJlSWT2QanCnRFsCVRKOYY0IRlldUrt1pkrDboiFYiLBEKRMS/c6xhN+RDj1V+m7N",
+	"0Ilp+kfk+ooWjeCWtnWcXGOdVBOSJAmy0Sz1A0buGr/pb1BnqDMmqhxluzqDkD+weLMLRnKRsvv7+2YH",
+	"6funYeXn5mELRq1VnkucLjaOoB71cfiFxPfW5AIJE/jbNHPDCQccb8qmbotN0UpSWULXkHl55FQ3d6wr",
+	"A30GlZ+7+G03XcI/P5lyey5mMLhFuKXUqvQv4gsPP9Zd6+bdnubeBtHPeWRoDBoTH2vsIGZDM5jqhy10",
+	"V2OWCjLbwWOkwJ2YgKhtHmTSoOolwhG77aLPWS6LcGtLvHyYKV85rH0+wMjN46txf87jiTV5R3rjueS3",
+	"IK1lqyXCVYZSbontczp30XkrxS3hbLR12qWEdnWQelQsFkj6CWTR7NUEfu2110wde21cnKmffdh4fJbu",
+	"7SH5xJzd3zLyeRncQ76Cv10vK60mreHdtjJdZeuOCNks5n1i2rXqdp/NuDRwNHVQ+d2KLu3jCn53axn4",
+	"PrLxnIZB1ootFc5+leW7fcrL8isoRNjvIEHs+ntEyvg2s5aNBHV4SjKUYopXtuGqmHldM4evXWk/b1fZ",
+	"p1Z7/uaqz6XvNDQIezijJU4eh6xOQtMksULE/+U+U8fVgGfTdQqaHlLVmzR0Kb5WS4hdKsDu/hO7M73c",
+	"1dMKOjR6im55XZhxzfkmeyPFJxvvw1Hvms/O3YdWIhrfWbNlIiWCW6ll/7giLTZ5pC6lrg1sl1zbeuu9",
+	"AxIjS6IQkThEkqQQIp3h9LWZ36mAttop7oar9NFp2KdyMLaPLwXOTo+uasvqJz62aq0An04DhtVMWVMj",
+	"mmpSTZnyqxoVYS8OrD6Jt97WdIl/Gwc7Z+4n8lY1Bks3p0TeYVlV4w3GXehw/8GFMhx1mlcgITng1Daw",
+	"/l1N9bup/EER5iYNjRoNHq8oFuY7Srolv5kBAY0FwktpS4o0jBxwtNZT6Kpu13LFE8W11H3jqm12RV8J",
+	"d9Jg6cDAXSdwUxvugoIXBl+2uMNE34QJzSiktWhaSc+NSBwV5YqqGq0v3uo0YJlbeZky9ULyIuZCnSGR",
+	"abFuCFW0petSWq7t3S7ttlZrvd3pHtfTGyVEmGqJw7RoTdeHh7KB3S4x4WmTtztclBtHMZbYYKPa2WPA",
+	"qH8KW/6J2KLYdQ0HNk0xAhVbHeu1ezefd4/LJzrenUdUnvANfB5WO14NINb11nrxyG01AdsdgtU56VDY",
+	"jKeVn3vzo35p23UNoF23mHoQysO+hnkKCvXRN6WETarcC6j7rNiK4xj4oa70n62zLHQdwJZYSMJm685v",
+	"otvwT3fI52H+I4sk+C2x8tY8oVhD9BS22Wt2S1XZqgrb5IuERAbTDR5xRXCdDCJGcsjLl8p6b7Yd+usK",
+	"vxWUC7RnmDZ0nelCNJvN9ieRotbQaYAcRYOpF0+Sdius3WrKVm84tGfbYU2jRq0D0gA1im5LL54a7b5Q",
+	"OxaS8oDqwH7xCVPCdWMne5Bx006pD/m249JknNtxY2Oq9nUbVt1pRqDRQmp3guKwq1Hd6NXRhe56Y5PJ",
+	"WC+Hj0V8dcG+kHblivgUUfjVXsZ7lM+fPm7I3H402DOyck1+ZHx/7J1E/3QxxHn2sSNnUAPmsT4o7IPC",
+	"tuJ4hNTDyP4zepxnW63PMmOpNujiiETohEUnQ+jv83ozkD33uEZAsYAl4zAMgPkm7w4A2O7r1D4Qa+1W",
+	"JjBcpaeRO2iK9ii6SxE60xyHOaAlFAXC17ARIJH9tt0VZUskrknmCs+FVC20l+aD4ygGyPRkYoZO6xUI",
+	"eqdqpIkW+3ZmgBti445br8zU0FdL6GsEKL4jLXkO+zN0qt62RetClcYzihLMV0U7mS78l12l+gmwy3Ow",
+	"o4XW7o7DKibLQGHl18FcT73Z2wMOx13HqDu60j0FbisRoiZqH5wJKids5YOuqLcXE6plhfRlFzuSiJrO",
+	"0o6E6q1xRZVm4u6muSIHdl8fKr5KNNyt6YrqCfqyURWcTcpJlRvdMjM1lQ9fdn6qRGMH13lyVb5ay+0y",
+	"Tk8n08+Xc+pNoFuwRhEip+Iwtt8e69Kx1c9qPZAaHfai/aLVQyrGOmYuPpL1UqvRvN8s243EnrI0wxza",
+	"OlPZbbeu05hrBtrBPbJfaC/MO7spl6l3dnviUplGk7OXVSyDkWBJLgtSVW//9Il25WLCg6/q7OqqzNNd",
+	"72jcC6hdoao3/O7DZ6UL0svGamcjqh1jWJRdsy2eNLqrXRy68PvJvbND1Pj6UTxndb/Dy/SS/la/JowE",
+	"WanKft2IxvZeUU0maE3dq9CKLmBhyyvaXLLDMkZ7ReejAuLjoivUFVVPQsT9dw33Z2jwCkLHvW9LrZ0W",
+	"aNo1nvVqQQHDC7magx2V68I78jZBSbVpCtKO27VhXwPyuVFugCkRrttNEilcD6ANStjKQ4XDelOjAZX6",
+	"unz5AURpBdLe4zvVvw/Ror16CZWKrhlBR3uvDl4dHXXHoe0nmaql7BblXx95GlilZtXym0L2rye27fv6",
+	"Uz0TM7lsbZV3jLVveafZhsTMr9t/6Ol1Y7zgMLj/fP8/AwA=",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
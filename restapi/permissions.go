@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+// permission is an action that only some roles may take.
+type permission string
+
+const (
+	// permRejudgeAny allows rejudging submissions of other users.
+	permRejudgeAny permission = "rejudge_any"
+	// permModerateHacks allows judging hacks again.
+	permModerateHacks permission = "moderate_hacks"
+	// permManageProblems allows problem-wide operations such as bulk rejudges.
+	permManageProblems permission = "manage_problems"
+	// permManageUsers allows changing roles of users.
+	permManageUsers permission = "manage_users"
+	// permAllUsersWebhooks allows webhooks receiving the events of every user.
+	permAllUsersWebhooks permission = "all_users_webhooks"
+)
+
+// rolePermissions is the single source of truth of what each role may do.
+var rolePermissions = map[database.UserRole][]permission{
+	database.RoleUser:      {},
+	database.RoleModerator: {permRejudgeAny, permModerateHacks},
+	database.RoleAdmin:     {permRejudgeAny, permModerateHacks, permManageProblems, permManageUsers, permAllUsersWebhooks},
+}
+
+func hasPermission(user *database.User, p permission) bool {
+	if user == nil {
+		return false
+	}
+	return slices.Contains(rolePermissions[user.Role], p)
+}
+
+// requirePermission returns the current user if it has p.
+func (s *server) requirePermission(ctx context.Context, p permission) (*database.User, error) {
+	user, err := s.currentUserFromContext(ctx)
+	if err != nil {
+		return nil, newHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	if user == nil {
+		return nil, newCodedError(http.StatusForbidden, restapi.UserNotRegistered, "user is not registered")
+	}
+	if !hasPermission(user, p) {
+		return nil, newHTTPError(http.StatusForbidden, "permission denied")
+	}
+	return user, nil
+}
+
+func canRejudgeREST(currentUser database.User, submission database.Submission) bool {
+	if currentUser.Name == "" {
+		return false
+	}
+	if submission.UserName.Valid && currentUser.Name == submission.UserName.String {
+		return true
+	}
+	if hasPermission(&currentUser, permRejudgeAny) {
+		return true
+	}
+	return submission.TestCasesVersion != submission.Problem.TestCasesVersion && submission.Status == "AC"
+}
+
+func toRESTUser(user database.User) restapi.User {
+	role := restapi.UserRole(user.Role)
+	return restapi.User{
+		Name:        user.Name,
+		LibraryUrl:  user.LibraryURL,
+		IsDeveloper: user.Role == database.RoleModerator || user.Role == database.RoleAdmin,
+		Role:        &role,
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+	"gorm.io/gorm"
+)
+
+func TestCanRejudgeREST(t *testing.T) {
+	sub := database.Submission{
+		UserName:         sql.NullString{String: "alice", Valid: true},
+		Status:           "WA",
+		TestCasesVersion: "v1",
+		Problem:          database.Problem{TestCasesVersion: "v1"},
+	}
+	for _, tc := range []struct {
+		user     database.User
+		expected bool
+	}{
+		{database.User{}, false},
+		{database.User{Name: "alice", Role: database.RoleUser}, true},
+		{database.User{Name: "bob", Role: database.RoleUser}, false},
+		{database.User{Name: "bob", Role: database.RoleModerator}, true},
+		{database.User{Name: "bob", Role: database.RoleAdmin}, true},
+	} {
+		if actual := canRejudgeREST(tc.user, sub); actual != tc.expected {
+			t.Errorf("canRejudgeREST(%+v) = %v, want %v", tc.user, actual, tc.expected)
+		}
+	}
+
+	// anyone can rejudge outdated AC submissions
+	outdated := sub
+	outdated.Status = "AC"
+	outdated.Problem.TestCasesVersion = "v2"
+	if !canRejudgeREST(database.User{Name: "bob", Role: database.RoleUser}, outdated) {
+		t.Error("outdated AC must be rejudgeable")
+	}
+}
+
+func newRouterAs(db *gorm.DB, uid string) *chi.Mux {
+	r := chi.NewRouter()
+	mountRESTHandler(r, &server{db: db, authClient: fakeAuthClient{uid: uid}})
+	return r
+}
+
+func TestAdminUserRole(t *testing.T) {
+	db := setupTestDB(t)
+	for _, name := range []string{"admin", "alice"} {
+		if err := database.RegisterUser(db, name, "uid-"+name); err != nil {
+			t.Fatalf("register user: %v", err)
+		}
+	}
+	if err := database.UpdateUserRole(db, "admin", database.RoleAdmin); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	asAdmin := newRouterAs(db, "uid-admin")
+	asAlice := newRouterAs(db, "uid-alice")
+
+	req := restapi.UpdateUserRoleRequest{Role: restapi.UserRoleModerator}
+	if rec := doJSON(t, asAlice, http.MethodPut, "/admin/users/alice/role", "token", req); rec.Code != http.StatusForbidden {
+		t.Fatalf("users must not change roles: %d", rec.Code)
+	}
+	if rec := doJSON(t, asAdmin, http.MethodPut, "/admin/users/admin/role", "token", req); rec.Code != http.StatusBadRequest {
+		t.Fatalf("admins must not change their own role: %d", rec.Code)
+	}
+	if rec := doJSON(t, asAdmin, http.MethodPut, "/admin/users/unknown/role", "token", req); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown user: %d", rec.Code)
+	}
+	if rec := doJSON(t, asAdmin, http.MethodPut, "/admin/users/alice/role", "token", req); rec.Code != http.StatusOK {
+		t.Fatalf("change role: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec := doJSON(t, asAdmin, http.MethodGet, "/admin/users", "token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list users: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doJSON(t, asAlice, http.MethodGet, "/admin/users", "token", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("moderators must not list users: %d", rec.Code)
+	}
+
+	rec = doJSON(t, asAlice, http.MethodGet, "/auth/current_user", "token", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("current user: %d", rec.Code)
+	}
+	var current restapi.CurrentUserInfoResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &current); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if current.User == nil || current.User.Role == nil || *current.User.Role != restapi.UserRoleModerator || !current.User.IsDeveloper {
+		t.Fatalf("unexpected current user: %s", rec.Body.String())
+	}
+}
+
+func TestPostHackRejudge(t *testing.T) {
+	db := setupTestDB(t)
+	for _, name := range []string{"moderator", "alice"} {
+		if err := database.RegisterUser(db, name, "uid-"+name); err != nil {
+			t.Fatalf("register user: %v", err)
+		}
+	}
+	if err := database.UpdateUserRole(db, "moderator", database.RoleModerator); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	subID := createTestSubmission(t, db, "aplusb-hack-rejudge")
+	hackID, err := database.SaveHack(db, database.Hack{
+		SubmissionID: subID,
+		TestCaseTxt:  []byte("1 2\n"),
+		Status:       "IE",
+	})
+	if err != nil {
+		t.Fatalf("save hack: %v", err)
+	}
+	path := fmt.Sprintf("/hacks/%d/rejudge", hackID)
+
+	if rec := doJSON(t, newRouterAs(db, "uid-alice"), http.MethodPost, path, "token", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("users must not rejudge hacks: %d", rec.Code)
+	}
+	if rec := doJSON(t, newRouterAs(db, "uid-moderator"), http.MethodPost, path, "token", nil); rec.Code != http.StatusOK {
+		t.Fatalf("rejudge hack: %d %s", rec.Code, rec.Body.String())
+	}
+	h, err := database.FetchHack(db, hackID)
+	if err != nil {
+		t.Fatalf("fetch hack: %v", err)
+	}
+	if h.Status != "WJ" {
+		t.Fatalf("expected WJ, got %s", h.Status)
+	}
+	if id, task, err := database.PopTask(db); err != nil || id == -1 || task.TaskType != database.JudgeHack {
+		t.Fatalf("hack task is not queued: %+v %v", task, err)
+	}
+}
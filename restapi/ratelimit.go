@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	"gorm.io/gorm"
+)
+
+// rateLimit allows Count requests per Window.
+type rateLimit struct {
+	Count  int
+	Window time.Duration
+}
+
+func (l rateLimit) enabled() bool {
+	return l.Count > 0 && l.Window > 0
+}
+
+// rateLimitRule limits a route. Requests with a valid bearer token are counted
+// per user, the others per client IP.
+type rateLimitRule struct {
+	IP   rateLimit
+	User rateLimit
+}
+
+// defaultRateLimitRules are keyed by "METHOD /route/pattern" as registered on chi.
+var defaultRateLimitRules = map[string]rateLimitRule{
+	"POST /submit":                   {IP: rateLimit{10, time.Minute}, User: rateLimit{30, time.Minute}},
+	"POST /submissions/{id}/rejudge": {IP: rateLimit{10, time.Minute}, User: rateLimit{30, time.Minute}},
+	"POST /hacks":                    {IP: rateLimit{5, time.Minute}, User: rateLimit{10, time.Minute}},
+	"POST /auth/register":            {IP: rateLimit{10, time.Hour}, User: rateLimit{5, time.Hour}},
+	"PATCH /auth/current_user":       {IP: rateLimit{30, time.Hour}, User: rateLimit{30, time.Hour}},
+	"POST /auth/tokens":              {IP: rateLimit{30, time.Hour}, User: rateLimit{10, time.Hour}},
+}
+
+// parseRateLimitRules parses RATE_LIMITS, which overrides the rules of the
+// listed routes: "POST /submit=ip:10/1m,user:30/1m;POST /hacks=user:5/1m".
+// A route without a limit of a kind is not limited by it.
+func parseRateLimitRules(spec string, base map[string]rateLimitRule) (map[string]rateLimitRule, error) {
+	rules := map[string]rateLimitRule{}
+	for route, rule := range base {
+		rules[route] = rule
+	}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, limits, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+		rule := rateLimitRule{}
+		for _, limit := range strings.Split(limits, ",") {
+			kind, value, ok := strings.Cut(strings.TrimSpace(limit), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid rate limit %q", limit)
+			}
+			count, window, ok := strings.Cut(value, "/")
+			if !ok {
+				return nil, fmt.Errorf("invalid rate limit %q", limit)
+			}
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid rate limit count %q", count)
+			}
+			d, err := time.ParseDuration(window)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid rate limit window %q", window)
+			}
+			switch kind {
+			case "ip":
+				rule.IP = rateLimit{n, d}
+			case "user":
+				rule.User = rateLimit{n, d}
+			default:
+				return nil, fmt.Errorf("unknown rate limit kind %q", kind)
+			}
+		}
+		rules[strings.Join(strings.Fields(route), " ")] = rule
+	}
+	return rules, nil
+}
+
+// rateLimitStore counts requests in fixed windows.
+type rateLimitStore interface {
+	// increment counts a request of bucket in the window starting at
+	// windowStart and returns the number of requests in the window.
+	increment(ctx context.Context, bucket string, windowStart time.Time, window time.Duration) (int, error)
+}
+
+// memoryRateLimitStore keeps the counters of this instance.
+type memoryRateLimitStore struct {
+	mu          sync.Mutex
+	counters    map[string]memoryRateLimitCounter
+	lastCleanup time.Time
+}
+
+type memoryRateLimitCounter struct {
+	windowStart time.Time
+	count       int
+	expiresAt   time.Time
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{counters: map[string]memoryRateLimitCounter{}}
+}
+
+func (m *memoryRateLimitStore) increment(_ context.Context, bucket string, windowStart time.Time, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if windowStart.Sub(m.lastCleanup) > time.Minute {
+		for key, c := range m.counters {
+			if !c.expiresAt.After(windowStart) {
+				delete(m.counters, key)
+			}
+		}
+		m.lastCleanup = windowStart
+	}
+	c := m.counters[bucket]
+	if !c.windowStart.Equal(windowStart) {
+		c = memoryRateLimitCounter{windowStart: windowStart, expiresAt: windowStart.Add(window)}
+	}
+	c.count++
+	m.counters[bucket] = c
+	return c.count, nil
+}
+
+// dbRateLimitStore keeps the counters in the database, shared by API instances.
+type dbRateLimitStore struct {
+	db *gorm.DB
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+func (s *dbRateLimitStore) increment(ctx context.Context, bucket string, windowStart time.Time, window time.Duration) (int, error) {
+	count, err := database.IncrementRateLimitCounter(s.db.WithContext(ctx), bucket, windowStart, windowStart.Add(window))
+	if err != nil {
+		return 0, err
+	}
+	// windowStart is not after now, so counters expired before it are unused
+	s.mu.Lock()
+	cleanup := windowStart.Sub(s.lastCleanup) > time.Minute
+	if cleanup {
+		s.lastCleanup = windowStart
+	}
+	s.mu.Unlock()
+	if cleanup {
+		if _, err := database.DeleteExpiredRateLimitCounters(s.db.WithContext(ctx), windowStart); err != nil {
+			slog.Warn("delete expired rate limit counters failed", "error", err)
+		}
+	}
+	return int(count), nil
+}
+
+// rateLimiter is a chi middleware applying rateLimitRule to the matched routes.
+type rateLimiter struct {
+	rules map[string]rateLimitRule
+	store rateLimitStore
+	// subject returns the user of the request, or "" to count it per IP
+	subject func(r *http.Request) string
+	// trustedProxies is the number of proxies appending to X-Forwarded-For
+	// in front of the server; 0 uses the address of the connection
+	trustedProxies int
+	now            func() time.Time
+}
+
+// newRateLimiterFromEnv configures the limiter by RATE_LIMIT_BACKEND (memory,
+// postgres or off), RATE_LIMITS and RATE_LIMIT_TRUSTED_PROXIES. It returns
+// nil if rate limiting is off.
+func newRateLimiterFromEnv(s *server) (*rateLimiter, error) {
+	var store rateLimitStore
+	switch backend := getEnv("RATE_LIMIT_BACKEND", "memory"); backend {
+	case "memory":
+		store = newMemoryRateLimitStore()
+	case "postgres":
+		store = &dbRateLimitStore{db: s.db}
+	case "off":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_BACKEND: %s", backend)
+	}
+	rules, err := parseRateLimitRules(getEnv("RATE_LIMITS", ""), defaultRateLimitRules)
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := strconv.Atoi(getEnv("RATE_LIMIT_TRUSTED_PROXIES", "0"))
+	if err != nil || trustedProxies < 0 {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_TRUSTED_PROXIES: %q", getEnv("RATE_LIMIT_TRUSTED_PROXIES", "0"))
+	}
+	return &rateLimiter{
+		rules:          rules,
+		store:          store,
+		subject:        s.rateLimitSubject,
+		trustedProxies: trustedProxies,
+		now:            time.Now,
+	}, nil
+}
+
+// middleware must run after routing, so that the route pattern is known.
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + chi.RouteContext(r.Context()).RoutePattern()
+		rule, ok := l.rules[route]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		limit, bucket := rule.IP, route+" ip:"+l.clientIP(r)
+		if user := l.subject(r); user != "" {
+			limit, bucket = rule.User, route+" user:"+user
+		}
+		if !limit.enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		now := l.now()
+		windowStart := now.Truncate(limit.Window)
+		count, err := l.store.increment(r.Context(), bucket, windowStart, limit.Window)
+		if err != nil {
+			// a broken store must not take the API down
+			slog.Error("rate limit failed", "bucket", bucket, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if count > limit.Count {
+			retryAfter := int(math.Ceil(windowStart.Add(limit.Window).Sub(now).Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, retryAfter)))
+			writeHTTPError(w, r, newHTTPError(http.StatusTooManyRequests, fmt.Sprintf("rate limit exceeded (%d requests per %s)", limit.Count, limit.Window)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *rateLimiter) clientIP(r *http.Request) string {
+	if l.trustedProxies > 0 {
+		// each trusted proxy appends the address it received the request from
+		forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+		if i := len(forwarded) - l.trustedProxies; i >= 0 {
+			if ip := net.ParseIP(strings.TrimSpace(forwarded[i])); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimitSubject returns the user authenticated by the bearer token of r, or
+// "" if there is no valid token. A user is counted once whether it uses an ID
+// token or API tokens, whichever operation they are used for.
+func (s *server) rateLimitSubject(r *http.Request) string {
+	token := parseBearerToken(r)
+	if token == "" {
+		return ""
+	}
+	if strings.HasPrefix(token, apiTokenPrefix) {
+		t, err := database.FetchAPITokenFromHash(s.db, hashAPIToken(token))
+		if err != nil || !t.Active(time.Now()) {
+			return ""
+		}
+		return t.UserName
+	}
+	if s.authClient == nil {
+		return ""
+	}
+	uid := s.authClient.parseUID(r.Context(), token)
+	if uid == "" {
+		return ""
+	}
+	if user, err := database.FetchUserFromUID(s.db, uid); err == nil && user != nil {
+		return user.Name
+	}
+	// not registered yet (POST /auth/register)
+	return "uid:" + uid
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func TestRateLimit(t *testing.T) {
+	db := setupTestDB(t)
+	if err := database.RegisterUser(db, "alice", "uid-alice"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+	createTestSubmission(t, db, "aplusb")
+
+	for _, tc := range []struct {
+		name  string
+		store rateLimitStore
+	}{
+		{"memory", newMemoryRateLimitStore()},
+		{"postgres", &dbRateLimitStore{db: db}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC)
+			s := &server{db: db, authClient: fakeAuthClient{uid: "uid-alice"}}
+			s.limiter = &rateLimiter{
+				rules: map[string]rateLimitRule{
+					"POST /submit": {IP: rateLimit{2, time.Minute}, User: rateLimit{3, time.Minute}},
+				},
+				store:          tc.store,
+				subject:        s.rateLimitSubject,
+				trustedProxies: 1,
+				now:            func() time.Time { return now },
+			}
+			r := chi.NewRouter()
+			mountRESTHandler(r, s)
+			submit := func(token, ip string) *httptest.ResponseRecorder {
+				t.Helper()
+				req := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(`{"problem": "aplusb", "lang": "cpp", "source": "x"}`))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Forwarded-For", "203.0.113.1, "+ip)
+				if token != "" {
+					req.Header.Set("Authorization", "Bearer "+token)
+				}
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+				return rec
+			}
+
+			for i := 0; i < 2; i++ {
+				if rec := submit("", "192.0.2.1"); rec.Code != http.StatusOK {
+					t.Fatalf("anonymous submit %d: %d %s", i, rec.Code, rec.Body.String())
+				}
+			}
+			rec := submit("", "192.0.2.1")
+			if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "50" {
+				t.Fatalf("expected 429 with Retry-After: 50, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+			}
+			if body := decodeProblemDetails(t, rec); body.Code != restapi.TooManyRequests {
+				t.Fatalf("unexpected code: %s", body.Code)
+			}
+			// the client address is taken from the trusted proxy, not the spoofable first entry
+			if rec := submit("", "192.0.2.2"); rec.Code != http.StatusOK {
+				t.Fatalf("other ip: %d", rec.Code)
+			}
+
+			// authenticated users have their own limit
+			for i := 0; i < 3; i++ {
+				if rec := submit("token", "192.0.2.1"); rec.Code != http.StatusOK {
+					t.Fatalf("user submit %d: %d %s", i, rec.Code, rec.Body.String())
+				}
+			}
+			if rec := submit("token", "192.0.2.3"); rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("user limit must not depend on the ip, got %d", rec.Code)
+			}
+
+			// routes without rules are not limited
+			for i := 0; i < 5; i++ {
+				if rec := doJSON(t, r, http.MethodGet, "/langs", "", nil); rec.Code != http.StatusOK {
+					t.Fatalf("langs: %d", rec.Code)
+				}
+			}
+
+			now = now.Add(time.Minute)
+			if rec := submit("", "192.0.2.1"); rec.Code != http.StatusOK {
+				t.Fatalf("next window: %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestParseRateLimitRules(t *testing.T) {
+	rules, err := parseRateLimitRules("POST  /submit=ip:1/1s, user:2/1h; POST /hacks=user:0/1m", defaultRateLimitRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rules["POST /submit"]; got.IP != (rateLimit{1, time.Second}) || got.User != (rateLimit{2, time.Hour}) {
+		t.Fatalf("unexpected rule: %+v", got)
+	}
+	if got := rules["POST /hacks"]; got.IP.enabled() || got.User.enabled() {
+		t.Fatalf("hacks must not be limited: %+v", got)
+	}
+	if rules["POST /auth/register"] != defaultRateLimitRules["POST /auth/register"] {
+		t.Fatal("other rules must be kept")
+	}
+	for _, spec := range []string{"POST /submit", "POST /submit=ip:1", "POST /submit=ip:x/1m", "POST /submit=ip:1/0s", "POST /submit=host:1/1m"} {
+		if _, err := parseRateLimitRules(spec, nil); err == nil {
+			t.Fatalf("%q must be rejected", spec)
+		}
+	}
+}
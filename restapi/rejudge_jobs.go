@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"gorm.io/gorm"
+)
+
+const rejudgeJobInterval = time.Second
+
+// runRejudgeJobs drains the bulk rejudge jobs created by POST /admin/rejudge.
+// The jobs keep their progress in the database, so they survive restarts and
+// several servers may run this at the same time.
+func runRejudgeJobs(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(rejudgeJobInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		n, err := database.QueueRejudgeJobs(db, time.Now())
+		if n > 0 {
+			slog.Debug("bulk rejudge queued", "count", n)
+		}
+		if err != nil {
+			slog.Error("bulk rejudge failed", "error", err)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 type requestContextKey struct{}
 
+type operationContextKey struct{}
+
 func withHTTPRequest(ctx context.Context, r *http.Request) context.Context {
 	return context.WithValue(ctx, requestContextKey{}, r)
 }
@@ -18,3 +20,16 @@ func httpRequestFromContext(ctx context.Context) (*http.Request, bool) {
 	r, _ := ctx.Value(requestContextKey{}).(*http.Request)
 	return r, r != nil
 }
+
+// withOperation stores the OpenAPI operation ID being served.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	operation, _ := ctx.Value(operationContextKey{}).(string)
+	return operation
+}
@@ -2,22 +2,40 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"github.com/go-chi/chi/v5"
 	"github.com/yosupo06/library-checker-judge/database"
 	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+	"github.com/yosupo06/library-checker-judge/storage"
 	"gorm.io/gorm"
 )
 
+// manifestCacheBytes bounds the test case manifests kept in memory.
+const manifestCacheBytes = 32 << 20
+
 // server is REST server implementation for OpenAPI handlers.
 type server struct {
 	db           *gorm.DB
 	authClient   AuthClient
 	updateUserFn func(*gorm.DB, database.User) error
+	// hub wakes up event streams on database notifications; nil means polling only
+	hub *statusHub
+	// files reads statements and public files; nil disables those endpoints
+	files storage.PublicReader
+	// cases reads the test case manifests of the private bucket; nil disables the endpoint
+	cases storage.PrivateReader
+	// webhookAllowPrivate allows webhooks to http and internal addresses, for local runs
+	webhookAllowPrivate bool
+	// limiter throttles the routes it has rules for; nil disables rate limiting
+	limiter *rateLimiter
 }
 
 var _ restapi.StrictServerInterface = (*server)(nil)
@@ -33,6 +51,43 @@ func createFirebaseApp(ctx context.Context, projectID string) (*firebase.App, er
 	return firebase.NewApp(ctx, &firebase.Config{ProjectID: projectID})
 }
 
+// newAuthClient creates the AuthClient selected by AUTH_PROVIDER:
+//
+//   - firebase (default): Firebase Auth of FIREBASE_PROJECT
+//   - oidc: JWTs of OIDC_ISSUER, verified with OIDC_JWKS_URL, OIDC_JWKS_FILE or the discovery document
+//   - static: HS256 JWTs signed with AUTH_STATIC_KEY, for local runs
+func newAuthClient(ctx context.Context) (AuthClient, error) {
+	switch provider := getEnv("AUTH_PROVIDER", "firebase"); provider {
+	case "firebase":
+		firebaseProject := os.Getenv("FIREBASE_PROJECT")
+		if firebaseProject == "" {
+			return nil, errors.New("FIREBASE_PROJECT must be set")
+		}
+		app, err := createFirebaseApp(ctx, firebaseProject)
+		if err != nil {
+			return nil, fmt.Errorf("create firebase app: %w", err)
+		}
+		authCli, err := app.Auth(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("connect firebase auth: %w", err)
+		}
+		return &FirebaseAuthClient{client: authCli}, nil
+	case "oidc":
+		return newOIDCAuthClient(ctx, oidcConfig{
+			Issuer:   os.Getenv("OIDC_ISSUER"),
+			Audience: os.Getenv("OIDC_AUDIENCE"),
+			UIDClaim: os.Getenv("OIDC_UID_CLAIM"),
+			JWKSURL:  os.Getenv("OIDC_JWKS_URL"),
+			JWKSFile: os.Getenv("OIDC_JWKS_FILE"),
+		})
+	case "static":
+		slog.Warn("static key auth is enabled, do not use it in production")
+		return newStaticKeyAuthClient([]byte(os.Getenv("AUTH_STATIC_KEY")), os.Getenv("OIDC_ISSUER"))
+	default:
+		return nil, fmt.Errorf("unknown AUTH_PROVIDER: %s", provider)
+	}
+}
+
 func (s *server) updateUser(db *gorm.DB, user database.User) error {
 	if s != nil && s.updateUserFn != nil {
 		return s.updateUserFn(db, user)
@@ -41,33 +96,22 @@ func (s *server) updateUser(db *gorm.DB, user database.User) error {
 }
 
 func main() {
-	db := database.Connect(database.GetDSNFromEnv(), getEnv("API_DB_LOG", "") != "")
+	dsn := database.GetDSNFromEnv()
+	db := database.Connect(dsn, getEnv("API_DB_LOG", "") != "")
 
-	// connect firebase auth (required)
 	ctx := context.Background()
-	firebaseProject := os.Getenv("FIREBASE_PROJECT")
-	if firebaseProject == "" {
-		slog.Error("FIREBASE_PROJECT must be set")
-		os.Exit(1)
-	}
-	app, err := createFirebaseApp(ctx, firebaseProject)
+	ac, err := newAuthClient(ctx)
 	if err != nil {
-		slog.Error("create firebase app failed", "error", err)
+		slog.Error("create auth client failed", "error", err)
 		os.Exit(1)
 	}
-	authCli, err := app.Auth(ctx)
-	if err != nil {
-		slog.Error("connect firebase auth failed", "error", err)
-		os.Exit(1)
-	}
-	ac := &FirebaseAuthClient{client: authCli}
 
 	r := chi.NewRouter()
 	// CORS (dev)
 	r.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
 			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 			if req.Method == http.MethodOptions {
 				w.WriteHeader(http.StatusNoContent)
@@ -78,8 +122,46 @@ func main() {
 	})
 
 	// Register OpenAPI handlers on chi router
-	s := &server{db: db, authClient: ac}
-	_ = restapi.HandlerFromMux(newRESTHandler(s), r)
+	hub := newStatusHub()
+	go func() {
+		if err := database.ListenUpdates(ctx, dsn, hub.publish, hub.setListening); err != nil {
+			slog.Error("listen database updates failed, event streams fall back to polling", "error", err)
+		}
+	}()
+	// automatic rejudge of outdated ACs (opt-in)
+	if perMinute, _ := strconv.Atoi(getEnv("AUTO_REJUDGE_PER_MINUTE", "0")); perMinute > 0 {
+		maxPending, err := strconv.ParseInt(getEnv("AUTO_REJUDGE_MAX_PENDING", strconv.Itoa(defaultAutoRejudgeMaxPending)), 10, 64)
+		if err != nil {
+			slog.Error("invalid AUTO_REJUDGE_MAX_PENDING", "error", err)
+			os.Exit(1)
+		}
+		a := &autoRejudger{
+			db:         db,
+			batch:      max(1, perMinute*int(autoRejudgeInterval/time.Second)/60),
+			maxPending: maxPending,
+		}
+		go a.run(ctx)
+	}
+	go runRejudgeJobs(ctx, db)
+
+	// webhooks are sent from the API server; the judge only queues deliveries
+	webhookAllowPrivate := getEnv("WEBHOOK_ALLOW_PRIVATE", "") == "true"
+	go newWebhookDispatcher(db, webhookAllowPrivate).run(ctx)
+
+	s := &server{db: db, authClient: ac, hub: hub, webhookAllowPrivate: webhookAllowPrivate}
+	if client, err := storage.Connect(ctx, storage.GetConfigFromEnv()); err != nil {
+		slog.Warn("connect storage failed, problem statement endpoints are disabled", "error", err)
+	} else {
+		cacheMB, _ := strconv.ParseInt(getEnv("STORAGE_CACHE_MB", "256"), 10, 64)
+		s.files = storage.NewCachedPublicReader(client, cacheMB<<20, storage.DefaultListTTL)
+		// manifests are immutable as their keys contain the test case version
+		s.cases = storage.NewCachedPrivateReader(client, manifestCacheBytes)
+	}
+	if s.limiter, err = newRateLimiterFromEnv(s); err != nil {
+		slog.Error("configure rate limit failed", "error", err)
+		os.Exit(1)
+	}
+	mountRESTHandler(r, s)
 	r.Get("/openapi.yaml", func(w http.ResponseWriter, req *http.Request) { http.ServeFile(w, req, "openapi/openapi.yaml") })
 	r.Get("/health", func(w http.ResponseWriter, req *http.Request) { _, _ = w.Write([]byte("SERVING")) })
 
@@ -94,26 +176,44 @@ func main() {
 func newRESTHandler(s *server) restapi.ServerInterface {
 	middlewares := []restapi.StrictMiddlewareFunc{requestContextMiddleware}
 	return restapi.NewStrictHandlerWithOptions(s, middlewares, restapi.StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeHTTPError(w, r, requestError(err))
 		},
 		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 			if err == nil {
 				return
 			}
-			if httpErr, ok := getHTTPError(err); ok {
-				http.Error(w, httpErr.Error(), httpErr.Status)
-				return
-			}
-			slog.Error("handler error", "path", r.URL.Path, "error", err)
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			writeHTTPError(w, r, err)
+		},
+	})
+}
+
+// mountRESTHandler registers the API on r. Errors of parameter binding and
+// unknown routes are also written as problem details.
+func mountRESTHandler(r chi.Router, s *server) {
+	var middlewares []restapi.MiddlewareFunc
+	if s.limiter != nil {
+		middlewares = append(middlewares, s.limiter.middleware)
+	}
+	_ = restapi.HandlerWithOptions(newRESTHandler(s), restapi.ChiServerOptions{
+		BaseRouter:  r,
+		Middlewares: middlewares,
+		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeHTTPError(w, r, requestError(err))
 		},
 	})
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, r, newHTTPError(http.StatusNotFound, "no such endpoint"))
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, r, newHTTPError(http.StatusMethodNotAllowed, "method not allowed"))
+	})
 }
 
-func requestContextMiddleware(next restapi.StrictHandlerFunc, _ string) restapi.StrictHandlerFunc {
+func requestContextMiddleware(next restapi.StrictHandlerFunc, operationID string) restapi.StrictHandlerFunc {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		ctx = withHTTPRequest(ctx, r)
+		ctx = withOperation(ctx, operationID)
 		return next(ctx, w, r, request)
 	}
 }
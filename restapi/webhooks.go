@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookInterval = 5 * time.Second
+	webhookBatch    = 20
+	webhookTimeout  = 10 * time.Second
+	// webhookLease must be longer than webhookTimeout, deliveries are sent in parallel
+	webhookLease          = time.Minute
+	webhookMaxAttempts    = 8
+	webhookInitialBackoff = 30 * time.Second
+	webhookMaxBackoff     = 6 * time.Hour
+	// finished deliveries older than this are removed from the log
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+
+	webhookSecretPrefix = "whsec_"
+)
+
+// webhookDispatcher sends the webhook deliveries queued by the judge. The
+// deliveries are leased in the database, so several servers may run it at
+// the same time.
+type webhookDispatcher struct {
+	db     *gorm.DB
+	client *http.Client
+	now    func() time.Time
+}
+
+func newWebhookDispatcher(db *gorm.DB, allowPrivate bool) *webhookDispatcher {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	if !allowPrivate {
+		// checked after DNS resolution, so that a public name cannot point to an internal address
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("webhook to non-public address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		}
+	}
+	return &webhookDispatcher{
+		db: db,
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: nil},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast()
+}
+
+func (d *webhookDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(webhookInterval)
+	defer ticker.Stop()
+	lastCleanup := time.Time{}
+	for {
+		if _, err := d.step(ctx); err != nil {
+			slog.Error("webhook delivery failed", "error", err)
+		}
+		if d.now().Sub(lastCleanup) > time.Hour {
+			if _, err := database.DeleteWebhookDeliveriesBefore(d.db, d.now().Add(-webhookDeliveryRetention)); err != nil {
+				slog.Error("cleanup webhook deliveries failed", "error", err)
+			}
+			lastCleanup = d.now()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// step sends the due deliveries and returns their number.
+func (d *webhookDispatcher) step(ctx context.Context) (int, error) {
+	deliveries, err := database.ClaimWebhookDeliveries(d.db, webhookBatch, webhookLease)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(deliveries))
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), errors.Join(errs...)
+}
+
+// deliver sends the delivery once and records the result.
+func (d *webhookDispatcher) deliver(ctx context.Context, delivery database.WebhookDelivery) error {
+	now := d.now()
+	code, err := d.post(ctx, delivery, now)
+	attemptErr := ""
+	retryAt := time.Time{}
+	if err != nil {
+		attemptErr = err.Error()
+		if attempts := delivery.Attempts + 1; attempts < webhookMaxAttempts {
+			retryAt = now.Add(webhookBackoff(attempts))
+		}
+		slog.Info("webhook attempt failed", "delivery", delivery.ID, "webhook", delivery.WebhookID, "error", err)
+	}
+	return database.RecordWebhookAttempt(d.db, delivery.ID, now, code, attemptErr, retryAt)
+}
+
+func (d *webhookDispatcher) post(ctx context.Context, delivery database.WebhookDelivery, now time.Time) (int32, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LibraryChecker-Webhook")
+	req.Header.Set("X-LibraryChecker-Event", delivery.Event)
+	req.Header.Set("X-LibraryChecker-Delivery", strconv.Itoa(int(delivery.ID)))
+	req.Header.Set("X-LibraryChecker-Timestamp", timestamp)
+	req.Header.Set("X-LibraryChecker-Signature", signWebhook(delivery.Webhook.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return int32(resp.StatusCode), fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return int32(resp.StatusCode), nil
+}
+
+// signWebhook returns the signature header of the body sent at timestamp.
+// Receivers should reject old timestamps to prevent replays.
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff returns the wait before the retry after the given number of
+// failed attempts: 30s, 1m, 2m, ... up to webhookMaxBackoff.
+func webhookBackoff(attempts int32) time.Duration {
+	backoff := webhookInitialBackoff
+	for i := int32(1); i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
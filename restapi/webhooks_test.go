@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	restapi "github.com/yosupo06/library-checker-judge/restapi/internal/api"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	db := setupTestDB(t)
+	for _, name := range []string{"alice", "bob", "admin"} {
+		if err := database.RegisterUser(db, name, "uid-"+name); err != nil {
+			t.Fatalf("register user: %v", err)
+		}
+	}
+	if err := database.UpdateUserRole(db, "admin", database.RoleAdmin); err != nil {
+		t.Fatalf("update role: %v", err)
+	}
+	alice := newRouterAs(db, "uid-alice")
+
+	for name, url := range map[string]string{
+		"http":     "http://example.com/hook",
+		"loopback": "https://127.0.0.1/hook",
+		"private":  "https://10.0.0.1/hook",
+		"metadata": "https://169.254.169.254/computeMetadata",
+		"local":    "https://localhost/hook",
+		"no host":  "https:///hook",
+	} {
+		rec := doJSON(t, alice, http.MethodPost, "/webhooks", "token", map[string]interface{}{"url": url, "events": []string{"submission.finished"}})
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d %s", name, rec.Code, rec.Body.String())
+		}
+	}
+	rec := doJSON(t, alice, http.MethodPost, "/webhooks", "token", map[string]interface{}{"url": "https://bot.example.com/hook", "events": []string{"submission.finished"}, "all_users": true})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("all_users webhooks require the admin role, got %d", rec.Code)
+	}
+	rec = doJSON(t, newRouterAs(db, "uid-admin"), http.MethodPost, "/webhooks", "token", map[string]interface{}{"url": "https://bot.example.com/all", "events": []string{"submission.finished"}, "all_users": true})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("admin: unexpected status %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = doJSON(t, alice, http.MethodPost, "/webhooks", "token", map[string]interface{}{"url": "https://bot.example.com/hook", "events": []string{"submission.finished", "hack.finished", "submission.finished"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d %s", rec.Code, rec.Body.String())
+	}
+	var created restapi.CreateWebhookResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.HasPrefix(created.Secret, webhookSecretPrefix) || len(created.Webhook.Events) != 2 || created.Webhook.AllUsers {
+		t.Fatalf("unexpected webhook: %+v", created)
+	}
+
+	rec = doJSON(t, alice, http.MethodGet, "/webhooks", "token", nil)
+	var list restapi.WebhookListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || len(list.Webhooks) != 1 {
+		t.Fatalf("unexpected list: %s", rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), created.Secret) {
+		t.Fatal("secret must not be listed")
+	}
+
+	deliveriesPath := fmt.Sprintf("/webhooks/%d/deliveries", created.Webhook.Id)
+	if rec := doJSON(t, alice, http.MethodGet, deliveriesPath, "token", nil); rec.Code != http.StatusOK {
+		t.Fatalf("deliveries: unexpected status %d", rec.Code)
+	}
+	bob := newRouterAs(db, "uid-bob")
+	if rec := doJSON(t, bob, http.MethodGet, deliveriesPath, "token", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("other users must not see the deliveries, got %d", rec.Code)
+	}
+	if rec := doJSON(t, bob, http.MethodDelete, fmt.Sprintf("/webhooks/%d", created.Webhook.Id), "token", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("other users must not delete the webhook, got %d", rec.Code)
+	}
+	if rec := doJSON(t, alice, http.MethodDelete, fmt.Sprintf("/webhooks/%d", created.Webhook.Id), "token", nil); rec.Code != http.StatusOK {
+		t.Fatalf("delete: unexpected status %d", rec.Code)
+	}
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	db := setupTestDB(t)
+	if err := database.RegisterUser(db, "alice", "uid-alice"); err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+
+	var calls atomic.Int32
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hook.Close()
+
+	const secret = "whsec_test"
+	if _, err := database.SaveWebhook(db, database.Webhook{UserName: "alice", URL: hook.URL, Secret: secret, Events: "submission.finished"}); err != nil {
+		t.Fatalf("save webhook: %v", err)
+	}
+	submissionID := createTestSubmission(t, db, "aplusb-webhook")
+	submission, err := database.FetchSubmission(db, submissionID)
+	if err != nil {
+		t.Fatalf("fetch submission: %v", err)
+	}
+	submission.UserName.String, submission.UserName.Valid = "alice", true
+	submission.Status = "AC"
+	if err := database.EnqueueSubmissionWebhooks(db, submission); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	// internal addresses are refused when connecting
+	if n, err := newWebhookDispatcher(db, false).step(context.Background()); n != 1 || err != nil {
+		t.Fatalf("step: %d, %v", n, err)
+	}
+	if calls.Load() != 0 {
+		t.Fatal("webhook to a loopback address must not be sent")
+	}
+	deliveries, err := database.FetchWebhookDeliveries(db, 1, 10)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("fetch deliveries: %v, %v", deliveries, err)
+	}
+	if d := deliveries[0]; d.Status != database.DeliveryPending || d.Attempts != 1 || !strings.Contains(d.LastError, "non-public") {
+		t.Fatalf("unexpected delivery after a refused attempt: %+v", d)
+	}
+
+	dispatcher := newWebhookDispatcher(db, true)
+	retryNow := func() {
+		t.Helper()
+		if err := db.Model(&database.WebhookDelivery{}).Where("status = ?", database.DeliveryPending).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+			t.Fatalf("reschedule: %v", err)
+		}
+	}
+	retryNow()
+	if _, err := dispatcher.step(context.Background()); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	req, body := <-received, <-bodies
+	timestamp := req.Header.Get("X-LibraryChecker-Timestamp")
+	if req.Header.Get("X-LibraryChecker-Signature") != signWebhook(secret, timestamp, body) {
+		t.Fatal("signature mismatch")
+	}
+	if req.Header.Get("X-LibraryChecker-Event") != "submission.finished" {
+		t.Fatalf("unexpected event header: %q", req.Header.Get("X-LibraryChecker-Event"))
+	}
+	var payload database.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Submission == nil || payload.Submission.ID != submissionID || payload.Submission.Status != "AC" {
+		t.Fatalf("unexpected payload: %s", body)
+	}
+
+	// 500 is retried with backoff
+	deliveries, _ = database.FetchWebhookDeliveries(db, 1, 10)
+	if d := deliveries[0]; d.Status != database.DeliveryPending || d.Attempts != 2 || d.ResponseCode != 500 || !d.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("unexpected delivery after 500: %+v", d)
+	}
+	if n, _ := dispatcher.step(context.Background()); n != 0 {
+		t.Fatal("delivery must wait for the backoff")
+	}
+	retryNow()
+	if _, err := dispatcher.step(context.Background()); err != nil {
+		t.Fatalf("step: %v", err)
+	}
+	deliveries, _ = database.FetchWebhookDeliveries(db, 1, 10)
+	if d := deliveries[0]; d.Status != database.DeliverySucceeded || d.Attempts != 3 || d.ResponseCode != 204 {
+		t.Fatalf("unexpected delivery after success: %+v", d)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	if webhookBackoff(1) != 30*time.Second || webhookBackoff(3) != 2*time.Minute {
+		t.Fatalf("unexpected backoff: %v, %v", webhookBackoff(1), webhookBackoff(3))
+	}
+	if webhookBackoff(100) != webhookMaxBackoff {
+		t.Fatalf("backoff must be capped, got %v", webhookBackoff(100))
+	}
+}
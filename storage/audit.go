@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Kinds of AuditIssue.
+const (
+	// AuditMissing is an expected object which does not exist
+	AuditMissing = "missing"
+	// AuditMismatch is an object whose content differs from the local file or
+	// the manifest
+	AuditMismatch = "mismatch"
+	// AuditExtra is an object in the prefix of the current version which is
+	// not expected
+	AuditExtra = "extra"
+	// AuditNoV4 is a v3 object of the current version without its v4
+	// counterpart
+	AuditNoV4 = "no-v4"
+)
+
+// AuditIssue is a problem of the layout found by Auditor.
+type AuditIssue struct {
+	Kind   string
+	Public bool
+	Key    string
+	Detail string
+}
+
+func (i AuditIssue) String() string {
+	bucket := "private"
+	if i.Public {
+		bucket = "public"
+	}
+	if i.Detail == "" {
+		return fmt.Sprintf("%s\t%s\t%s", i.Kind, bucket, i.Key)
+	}
+	return fmt.Sprintf("%s\t%s\t%s\t%s", i.Kind, bucket, i.Key, i.Detail)
+}
+
+// Auditor checks that the buckets have the complete v4 layout of the problems.
+type Auditor struct {
+	client  Client
+	private map[string]ObjectAttrs
+	public  map[string]ObjectAttrs
+}
+
+// NewAuditor lists the buckets. The objects uploaded later are not seen.
+func NewAuditor(ctx context.Context, client Client) (*Auditor, error) {
+	a := &Auditor{client: client}
+	var err error
+	if a.private, err = listAll(ctx, client.bucket); err != nil {
+		return nil, err
+	}
+	if a.public, err = listAll(ctx, client.publicBucket); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func listAll(ctx context.Context, bucket Bucket) (map[string]ObjectAttrs, error) {
+	objects, err := bucket.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]ObjectAttrs{}
+	for _, obj := range objects {
+		m[obj.Key] = obj
+	}
+	return m, nil
+}
+
+// keysWithPrefix returns the keys of objects starting with prefix, sorted.
+func keysWithPrefix(objects map[string]ObjectAttrs, prefix string) []string {
+	keys := []string{}
+	for key := range objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// AuditProblem checks the objects of the current versions of p: the test case
+// manifest and objects (or the tarball without a manifest), the examples, the public files, and the v4
+// counterparts of the v3 objects. If local is not nil, it must be the problem
+// dir of the same OverallVersion, and the public files are compared with it.
+func (a *Auditor) AuditProblem(ctx context.Context, p Problem, local *UploadTarget) ([]AuditIssue, error) {
+	pa := &problemAudit{Auditor: a, issues: []AuditIssue{}}
+
+	// test cases; the tarball is needed only by versions without a manifest
+	manifest, err := p.TestCaseManifest(ctx, a.client)
+	if errors.Is(err, ErrNotFound) {
+		pa.report(AuditMissing, false, p.v4TestCaseManifestKey(), "uploaded before manifests were added")
+		if _, ok := a.private[p.v4TestCasesKey()]; !ok {
+			pa.report(AuditMissing, false, p.v4TestCasesKey(), "")
+		}
+	} else if err != nil {
+		pa.report(AuditMismatch, false, p.v4TestCaseManifestKey(), "%v", err)
+	} else if err := pa.auditTestCases(ctx, p, manifest); err != nil {
+		return nil, err
+	}
+
+	// public files
+	filesPrefix := p.v4PublicFilesKeyPrefix() + "/"
+	expected := map[string]string{}
+	for _, info := range fileInfos("", "") {
+		if !info.required {
+			continue
+		}
+		if strings.HasPrefix(info.path, "common/") {
+			expected[p.v4FilesCommonKey(info.path)] = ""
+		} else {
+			expected[p.v4FilesProblemKey(info.path)] = ""
+		}
+	}
+	if local != nil {
+		files, err := local.publicFilesV4()
+		if err != nil {
+			return nil, err
+		}
+		for key, localPath := range files {
+			expected[key] = localPath
+		}
+	}
+	for _, key := range sortedKeys(expected) {
+		if _, ok := a.public[key]; !ok {
+			pa.report(AuditMissing, true, key, "")
+			continue
+		}
+		if expected[key] == "" {
+			continue
+		}
+		want, err := fileHash(expected[key])
+		if err != nil {
+			return nil, err
+		}
+		if err := pa.auditContent(ctx, key, want); err != nil {
+			return nil, err
+		}
+	}
+	if local != nil {
+		for _, key := range keysWithPrefix(a.public, filesPrefix) {
+			if _, ok := expected[key]; !ok {
+				pa.report(AuditExtra, true, key, "not in the local problem dir")
+			}
+		}
+	}
+
+	pa.auditV3(p)
+	return pa.issues, nil
+}
+
+// problemAudit collects the issues of a problem.
+type problemAudit struct {
+	*Auditor
+	issues []AuditIssue
+}
+
+func (a *problemAudit) report(kind string, public bool, key, format string, args ...any) {
+	a.issues = append(a.issues, AuditIssue{Kind: kind, Public: public, Key: key, Detail: fmt.Sprintf(format, args...)})
+}
+
+func (a *problemAudit) auditTestCases(ctx context.Context, p Problem, m TestCaseManifest) error {
+	if v := m.version(); v != p.TestCaseVersion {
+		a.report(AuditMismatch, false, p.v4TestCaseManifestKey(), "hashes are of version %s", v)
+	}
+	examples := map[string]TestCaseFile{}
+	for _, c := range m.Cases {
+		for _, f := range []TestCaseFile{c.In, c.Out} {
+			key := v4ObjectKey(f.SHA256)
+			if obj, ok := a.private[key]; !ok {
+				a.report(AuditMissing, false, key, "%s of the manifest", c.Name)
+			} else if obj.Size != f.Size {
+				a.report(AuditMismatch, false, key, "%d bytes, want %d", obj.Size, f.Size)
+			}
+		}
+		// as UploadTestcases selects them
+		if strings.Contains(c.Name, "example") {
+			examples[p.v4ExamplesKey(path.Join("in", c.Name+".in"))] = c.In
+			examples[p.v4ExamplesKey(path.Join("out", c.Name+".out"))] = c.Out
+		}
+	}
+	for _, key := range sortedKeys(examples) {
+		if _, ok := a.public[key]; !ok {
+			a.report(AuditMissing, true, key, "")
+			continue
+		}
+		if err := a.auditContent(ctx, key, examples[key].SHA256); err != nil {
+			return err
+		}
+	}
+	for _, key := range keysWithPrefix(a.public, p.v4ExamplesKey("")) {
+		if _, ok := examples[key]; !ok {
+			a.report(AuditExtra, true, key, "not an example of the manifest")
+		}
+	}
+	return nil
+}
+
+// auditContent downloads the public object and compares its sha256.
+func (a *problemAudit) auditContent(ctx context.Context, key, want string) error {
+	reader, err := a.client.publicBucket.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return err
+	}
+	if got := fmt.Sprintf("%x", h.Sum(nil)); got != want {
+		a.report(AuditMismatch, true, key, "sha256 %s, want %s", got, want)
+	}
+	return nil
+}
+
+// auditV3 checks that each v3 object of the current versions has its v4
+// counterpart of the same size.
+func (a *problemAudit) auditV3(p Problem) {
+	check := func(public bool, v3Key, v4Key string) {
+		objects := a.private
+		if public {
+			objects = a.public
+		}
+		v3, ok := objects[v3Key]
+		if !ok {
+			return
+		}
+		v4, ok := objects[v4Key]
+		if !ok {
+			a.report(AuditNoV4, public, v3Key, "%s does not exist", v4Key)
+		} else if v3.Size != v4.Size {
+			a.report(AuditNoV4, public, v3Key, "%s is %d bytes, want %d", v4Key, v4.Size, v3.Size)
+		}
+	}
+
+	check(false, p.testCasesKey(), p.v4TestCasesKey())
+	examplesPrefix := p.publicTestCaseKey("")
+	for _, key := range keysWithPrefix(a.public, examplesPrefix) {
+		check(true, key, p.v4ExamplesKey(strings.TrimPrefix(key, examplesPrefix)))
+	}
+	filesPrefix := p.publicFileKey("")
+	for _, key := range keysWithPrefix(a.public, filesPrefix) {
+		rel := strings.TrimPrefix(key, filesPrefix)
+		if strings.HasPrefix(rel, "common/") {
+			check(true, key, p.v4FilesCommonKey(rel))
+		} else {
+			check(true, key, p.v4FilesProblemKey(rel))
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeProblemsRepo writes a git repository like library-checker-problems
+// with sample/aplusb, and returns its UploadTarget. The test cases and
+// params.h are generated, so they are not tracked.
+func writeProblemsRepo(t *testing.T) UploadTarget {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "sample", "aplusb")
+	tracked := map[string]string{
+		"common/fastio.h":               "// fastio",
+		"common/random.h":               "// random",
+		"common/testlib.h":              "// testlib",
+		"sample/aplusb/task.md":         "# A + B",
+		"sample/aplusb/checker.cpp":     "// checker",
+		"sample/aplusb/verifier.cpp":    "// verifier",
+		"sample/aplusb/sol/correct.cpp": "// correct",
+	}
+	for name, content := range problemFiles(t, manifestCases) {
+		if _, ok := manifestCases[name]; !ok {
+			tracked["sample/aplusb/"+name] = content
+		}
+	}
+	writeFiles(t, root, tracked)
+	writeFiles(t, dir, manifestCases)
+	writeFiles(t, dir, map[string]string{"params.h": "// params"})
+
+	for _, args := range [][]string{{"init", "-q"}, append([]string{"add"}, sortedKeys(tracked)...)} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %s: %v\n%s", args[0], err, out)
+		}
+	}
+	target, err := NewUploadTarget(dir, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target
+}
+
+func auditIssues(t *testing.T, client Client, p Problem, local *UploadTarget) []string {
+	t.Helper()
+	auditor, err := NewAuditor(context.Background(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := auditor.AuditProblem(context.Background(), p, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := []string{}
+	for _, issue := range issues {
+		result = append(result, issue.Kind+" "+issue.Key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestAuditProblem(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public")))
+	target := writeProblemsRepo(t)
+	p := target.Problem
+	if err := target.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.UploadPublicFilesV4(client); err != nil {
+		t.Fatal(err)
+	}
+	// v3 objects which were uploaded in parallel
+	if err := client.PublicBucket().Put(ctx, p.publicFileKey("task.md"), strings.NewReader("# A + B")); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PublicBucket().Put(ctx, p.publicFileKey("common/fastio.h"), strings.NewReader("// fastio")); err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := auditIssues(t, client, p, &target); len(issues) != 0 {
+		t.Fatalf("issues of a complete layout: %v", issues)
+	}
+
+	m, err := p.TestCaseManifest(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missingObject := v4ObjectKey(m.Cases[1].Out.SHA256)
+	if err := client.Bucket().Delete(ctx, missingObject); err != nil {
+		t.Fatal(err)
+	}
+	for key, content := range map[string]string{
+		p.v4ExamplesKey("out/example_00.out"): "4\n",
+		p.v4FilesProblemKey("old.md"):         "old",
+		p.publicFileKey("grader/solve.hpp"):   "// solve",
+	} {
+		if err := client.PublicBucket().Put(ctx, key, strings.NewReader(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.PublicBucket().Delete(ctx, p.v4FilesCommonKey("testlib.h")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"extra " + p.v4FilesProblemKey("old.md"),
+		"mismatch " + p.v4ExamplesKey("out/example_00.out"),
+		"missing " + missingObject,
+		"missing " + p.v4FilesCommonKey("testlib.h"),
+		"no-v4 " + p.publicFileKey("grader/solve.hpp"),
+	}
+	sort.Strings(want)
+	if issues := auditIssues(t, client, p, &target); !reflect.DeepEqual(issues, want) {
+		t.Errorf("issues = %v, want %v", issues, want)
+	}
+
+	// without the local dir, only the required public files are checked
+	want = []string{
+		"mismatch " + p.v4ExamplesKey("out/example_00.out"),
+		"missing " + missingObject,
+		"missing " + p.v4FilesCommonKey("testlib.h"),
+		"no-v4 " + p.publicFileKey("grader/solve.hpp"),
+	}
+	sort.Strings(want)
+	if issues := auditIssues(t, client, p, nil); !reflect.DeepEqual(issues, want) {
+		t.Errorf("issues = %v, want %v", issues, want)
+	}
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Bucket is a flat object store holding the test cases or the public files.
+// Keys are slash separated, like "v4/testcase/aplusb/{hash}.tar.gz".
+type Bucket interface {
+	// Get opens the object. It returns ErrNotFound if the object does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put creates or replaces the object with the content of body.
+	Put(ctx context.Context, key string, body io.ReadSeeker) error
+	// Exists reports whether the object exists.
+	Exists(ctx context.Context, key string) (bool, error)
+	// List returns the objects whose key starts with prefix, in any order.
+	List(ctx context.Context, prefix string) ([]ObjectAttrs, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// Ensure creates the bucket if it does not exist.
+	Ensure(ctx context.Context) error
+}
+
+// ObjectAttrs is an object of a bucket.
+type ObjectAttrs struct {
+	Key  string
+	Size int64
+	// Updated is the last modification time; zero if the backend does not
+	// report it
+	Updated time.Time
+}
+
+// Backends selected by Config.Backend.
+const (
+	BackendGCS   = "gcs"
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// openBucket opens the bucket named name of the backend of config.
+func openBucket(ctx context.Context, config Config, name string) (Bucket, func() error, error) {
+	switch config.Backend {
+	case "", BackendGCS:
+		return openGCSBucket(ctx, name, config.ProjectID)
+	case BackendLocal:
+		if config.LocalDir == "" {
+			return nil, nil, fmt.Errorf("local storage directory is not set")
+		}
+		return NewLocalBucket(filepath.Join(config.LocalDir, name)), func() error { return nil }, nil
+	case BackendS3:
+		b, err := NewS3Bucket(config.S3, name)
+		return b, func() error { return nil }, err
+	default:
+		return nil, nil, fmt.Errorf("unknown storage backend: %s", config.Backend)
+	}
+}
+
+func downloadToFile(ctx context.Context, bucket Bucket, key, destPath string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return err
+	}
+	reader, err := bucket.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	file, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return err
+	}
+	return nil
+}
+
+func uploadFile(ctx context.Context, bucket Bucket, key, srcPath string) error {
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	if err := bucket.Put(ctx, key, file); err != nil {
+		return fmt.Errorf("upload %s failed: %w", key, err)
+	}
+	return nil
+}
+
+// upload uploads the local file, waiting for a free slot if the uploads of c
+// are limited.
+func (c Client) upload(ctx context.Context, bucket Bucket, key, srcPath string) error {
+	return c.withSlot(ctx, func() error {
+		return uploadFile(ctx, bucket, key, srcPath)
+	})
+}
+
+// withSlot runs f, waiting for a free slot if the uploads of c are limited.
+func (c Client) withSlot(ctx context.Context, f func() error) error {
+	if c.uploads != nil {
+		select {
+		case c.uploads <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-c.uploads }()
+	}
+	return f()
+}
+
+// uploadFiles uploads the local files keyed by their keys, as many at once as
+// the uploads of c allow. It stops at the first error and returns it.
+func (c Client) uploadFiles(ctx context.Context, bucket Bucket, files map[string]string) error {
+	return c.forEachKey(ctx, sortedKeys(files), func(ctx context.Context, key string) error {
+		return uploadFile(ctx, bucket, key, files[key])
+	})
+}
+
+// missingKeys returns the keys which do not exist in bucket, checking as many
+// at once as the uploads of c allow.
+func (c Client) missingKeys(ctx context.Context, bucket Bucket, keys []string) (map[string]bool, error) {
+	var mu sync.Mutex
+	missing := map[string]bool{}
+	err := c.forEachKey(ctx, keys, func(ctx context.Context, key string) error {
+		exists, err := bucket.Exists(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			mu.Lock()
+			missing[key] = true
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// forEachKey runs f for the keys, one by one or as many at once as the uploads
+// of c allow. It stops at the first error and returns it.
+func (c Client) forEachKey(ctx context.Context, keys []string, f func(ctx context.Context, key string) error) error {
+	if c.uploads == nil {
+		for _, key := range keys {
+			if err := f(ctx, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// the first error is sent before cancel makes the others fail
+	errs := make(chan error, len(keys))
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.withSlot(ctx, func() error { return f(ctx, key) }); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
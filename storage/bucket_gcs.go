@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// gcsBucket is a Google Cloud Storage bucket. STORAGE_EMULATOR_HOST is
+// honored by the client library, which is how fake-gcs-server is used.
+type gcsBucket struct {
+	client    *storage.Client
+	name      string
+	projectID string
+}
+
+func openGCSBucket(ctx context.Context, name, projectID string) (Bucket, func() error, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcsBucket{client: client, name: name, projectID: projectID}, client.Close, nil
+}
+
+func (b gcsBucket) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := b.client.Bucket(b.name).Object(key).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (b gcsBucket) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	w := b.client.Bucket(b.name).Object(key).NewWriter(ctx)
+	if _, err := io.Copy(w, body); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (b gcsBucket) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := b.client.Bucket(b.name).Object(key).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b gcsBucket) List(ctx context.Context, prefix string) ([]ObjectAttrs, error) {
+	objects := []ObjectAttrs{}
+	it := b.client.Bucket(b.name).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, ObjectAttrs{Key: attrs.Name, Size: attrs.Size, Updated: attrs.Updated})
+	}
+	return objects, nil
+}
+
+func (b gcsBucket) Delete(ctx context.Context, key string) error {
+	if err := b.client.Bucket(b.name).Object(key).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b gcsBucket) Ensure(ctx context.Context) error {
+	if b.name == "" {
+		return nil
+	}
+	if _, err := b.client.Bucket(b.name).Attrs(ctx); err != nil {
+		if !errors.Is(err, storage.ErrBucketNotExist) {
+			return err
+		}
+		if b.projectID == "" {
+			return fmt.Errorf("project ID is required")
+		}
+		if err := b.client.Bucket(b.name).Create(ctx, b.projectID, nil); err != nil {
+			var gErr *googleapi.Error
+			if errors.As(err, &gErr) && gErr.Code == 409 {
+				return nil
+			}
+			return fmt.Errorf("create bucket %s: %w", b.name, err)
+		}
+	}
+	return nil
+}
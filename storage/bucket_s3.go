@@ -0,0 +1,441 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Config configures S3-compatible buckets (AWS S3, MinIO, ...).
+type S3Config struct {
+	// Endpoint is like "http://minio:9000"; empty means AWS S3 of Region
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// VirtualHost uses bucket.host/key URLs instead of host/bucket/key (path
+	// style, which MinIO and most self-hosted servers expect)
+	VirtualHost bool
+}
+
+const (
+	// s3UnsignedPayload is signed instead of the hash of an uploaded body, so
+	// that the body is read only once
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	// s3PartSize is the size of the parts of multipart uploads; smaller
+	// objects are uploaded by a single PUT
+	s3PartSize = 64 << 20
+	// s3MaxParts is the most parts of a multipart upload
+	s3MaxParts = 10000
+)
+
+// S3Bucket is a bucket of an S3-compatible server, accessed by the REST API
+// with Signature Version 4.
+type S3Bucket struct {
+	config   S3Config
+	name     string
+	client   *http.Client
+	now      func() time.Time
+	partSize int64
+}
+
+func NewS3Bucket(config S3Config, name string) (S3Bucket, error) {
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return S3Bucket{}, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return S3Bucket{}, fmt.Errorf("s3 credentials are not set")
+	}
+	return S3Bucket{config: config, name: name, client: http.DefaultClient, now: time.Now, partSize: s3PartSize}, nil
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b S3Bucket) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := b.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if err := checkS3Response(resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Put streams body without hashing it first. Bodies larger than a part are
+// sent by a multipart upload, so objects are not limited to 5 GiB.
+func (b S3Bucket) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if size > b.partSize {
+		return b.putMultipart(ctx, key, body, size)
+	}
+	resp, err := b.send(ctx, http.MethodPut, key, nil, body, size, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return checkS3Response(resp)
+}
+
+func (b S3Bucket) putMultipart(ctx context.Context, key string, body io.Reader, size int64) error {
+	resp, err := b.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return err
+	}
+	initiated := s3InitiateMultipartUploadResult{}
+	err = checkS3Response(resp)
+	if err == nil {
+		err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	}
+	_ = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if err := b.uploadParts(ctx, key, initiated.UploadID, body, size); err != nil {
+		// the parts are kept and billed until the upload is aborted
+		if resp, abortErr := b.do(context.WithoutCancel(ctx), http.MethodDelete, key, url.Values{"uploadId": {initiated.UploadID}}, nil); abortErr == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+	return nil
+}
+
+func (b S3Bucket) uploadParts(ctx context.Context, key, uploadID string, body io.Reader, size int64) error {
+	partSize := max(b.partSize, (size+s3MaxParts-1)/s3MaxParts)
+	complete := s3CompleteMultipartUpload{}
+	for offset, number := int64(0), 1; offset < size; offset, number = offset+partSize, number+1 {
+		n := min(partSize, size-offset)
+		query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+		resp, err := b.send(ctx, http.MethodPut, key, query, io.LimitReader(body, n), n, s3UnsignedPayload)
+		if err != nil {
+			return err
+		}
+		err = checkS3Response(resp)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("upload part %d: %w", number, err)
+		}
+		complete.Parts = append(complete.Parts, s3CompletedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+	}
+
+	data, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkS3Response(resp); err != nil {
+		return err
+	}
+	// the completion may fail after the status is sent
+	e := s3Error{}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e); err == nil && e.Code != "" {
+		return fmt.Errorf("s3: %s: %s", e.Code, e.Message)
+	}
+	return nil
+}
+
+func (b S3Bucket) Exists(ctx context.Context, key string) (bool, error) {
+	resp, err := b.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err := checkS3Response(resp); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (b S3Bucket) List(ctx context.Context, prefix string) ([]ObjectAttrs, error) {
+	objects := []ObjectAttrs{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		result := s3ListResult{}
+		err = checkS3Response(resp)
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range result.Contents {
+			objects = append(objects, ObjectAttrs{Key: c.Key, Size: c.Size, Updated: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (b S3Bucket) Delete(ctx context.Context, key string) error {
+	resp, err := b.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return checkS3Response(resp)
+}
+
+func (b S3Bucket) Ensure(ctx context.Context) error {
+	resp, err := b.do(ctx, http.MethodHead, "", nil, nil)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		return checkS3Response(resp)
+	}
+
+	var body io.ReadSeeker
+	if b.config.Region != "us-east-1" {
+		body = strings.NewReader(fmt.Sprintf(`<CreateBucketConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LocationConstraint>%s</LocationConstraint></CreateBucketConfiguration>`, b.config.Region))
+	}
+	resp, err = b.do(ctx, http.MethodPut, "", nil, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusConflict {
+		// created concurrently
+		return nil
+	}
+	if err := checkS3Response(resp); err != nil {
+		return fmt.Errorf("create bucket %s: %w", b.name, err)
+	}
+	return nil
+}
+
+func checkS3Response(resp *http.Response) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	e := s3Error{}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&e)
+	if e.Code != "" {
+		return fmt.Errorf("s3: %s: %s (status %d)", e.Code, e.Message, resp.StatusCode)
+	}
+	return fmt.Errorf("s3: unexpected status %d", resp.StatusCode)
+}
+
+// do sends a request for the object key, or for the bucket if key is empty.
+// The body is hashed for the signature, so it must be small.
+func (b S3Bucket) do(ctx context.Context, method, key string, query url.Values, body io.ReadSeeker) (*http.Response, error) {
+	payloadHash := sha256.New()
+	size := int64(0)
+	if body != nil {
+		var err error
+		if size, err = io.Copy(payloadHash, body); err != nil {
+			return nil, err
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return b.send(ctx, method, key, query, body, size, hex.EncodeToString(payloadHash.Sum(nil)))
+}
+
+// send sends a request with size bytes of body, signing payloadHash as the
+// hash of the body.
+func (b S3Bucket) send(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	endpoint, err := url.Parse(b.config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var objectPath string
+	if b.config.VirtualHost {
+		endpoint.Host = b.name + "." + endpoint.Host
+		objectPath = "/" + s3Escape(key, true)
+	} else {
+		objectPath = "/" + s3Escape(b.name, false)
+		if key != "" {
+			objectPath += "/" + s3Escape(key, true)
+		}
+	}
+	u, err := url.Parse(endpoint.Scheme + "://" + endpoint.Host + strings.TrimSuffix(endpoint.Path, "/") + objectPath)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Body = io.NopCloser(body)
+		req.ContentLength = size
+	}
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if b.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.config.SessionToken)
+	}
+	signS3Request(req, b.config, b.now())
+	return b.client.Do(req)
+}
+
+// signS3Request adds the Authorization header of Signature Version 4. All
+// headers already set on req are signed; X-Amz-Content-Sha256 must be set.
+func signS3Request(req *http.Request, config S3Config, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		trimmed := make([]string, len(values))
+		for i, v := range values {
+			trimmed[i] = strings.Join(strings.Fields(v), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	scope := date + "/" + config.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + config.SecretAccessKey)
+	for _, part := range []string{date, config.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes every byte except the unreserved characters, as
+// Signature Version 4 requires; slashes are kept if keepSlash.
+func s3Escape(s string, keepSlash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			buf.WriteByte(c)
+		case c == '/' && keepSlash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(key, false)+"="+s3Escape(v, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
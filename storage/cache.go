@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultListTTL is how long CachedPublicReader keeps listings. Objects are
+// kept until evicted because v4 keys contain the version, but a listing may
+// be taken while the uploader is still running.
+const DefaultListTTL = time.Minute
+
+// CachedPublicReader caches the objects and listings of a PublicReader in
+// memory. Objects are evicted in LRU order when their total size exceeds
+// maxBytes. Errors (including ErrNotFound) are not cached.
+type CachedPublicReader struct {
+	reader  PublicReader
+	objects *objectLRU
+	listTTL time.Duration
+	now     func() time.Time
+
+	mu    sync.Mutex
+	lists map[string]cachedList
+}
+
+type cachedList struct {
+	objects   []PublicObject
+	fetchedAt time.Time
+}
+
+var _ PublicReader = (*CachedPublicReader)(nil)
+
+func NewCachedPublicReader(reader PublicReader, maxBytes int64, listTTL time.Duration) *CachedPublicReader {
+	return &CachedPublicReader{
+		reader:  reader,
+		objects: newObjectLRU(maxBytes),
+		listTTL: listTTL,
+		now:     time.Now,
+		lists:   map[string]cachedList{},
+	}
+}
+
+func (c *CachedPublicReader) ReadPublic(ctx context.Context, key string) ([]byte, error) {
+	if data, ok := c.objects.get(key); ok {
+		return data, nil
+	}
+	data, err := c.reader.ReadPublic(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.objects.add(key, data)
+	return data, nil
+}
+
+func (c *CachedPublicReader) ListPublic(ctx context.Context, prefix string) ([]PublicObject, error) {
+	c.mu.Lock()
+	if l, ok := c.lists[prefix]; ok && c.now().Sub(l.fetchedAt) < c.listTTL {
+		c.mu.Unlock()
+		return l.objects, nil
+	}
+	c.mu.Unlock()
+
+	objects, err := c.reader.ListPublic(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for p, l := range c.lists {
+		if c.now().Sub(l.fetchedAt) >= c.listTTL {
+			delete(c.lists, p)
+		}
+	}
+	c.lists[prefix] = cachedList{objects: objects, fetchedAt: c.now()}
+	return objects, nil
+}
+
+// CachedPrivateReader caches the objects of a PrivateReader in memory, like
+// CachedPublicReader. Only objects which never change, like the manifests
+// whose keys contain the test case version, may be read through it.
+type CachedPrivateReader struct {
+	reader  PrivateReader
+	objects *objectLRU
+}
+
+var _ PrivateReader = (*CachedPrivateReader)(nil)
+
+func NewCachedPrivateReader(reader PrivateReader, maxBytes int64) *CachedPrivateReader {
+	return &CachedPrivateReader{reader: reader, objects: newObjectLRU(maxBytes)}
+}
+
+func (c *CachedPrivateReader) ReadPrivate(ctx context.Context, key string) ([]byte, error) {
+	if data, ok := c.objects.get(key); ok {
+		return data, nil
+	}
+	data, err := c.reader.ReadPrivate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.objects.add(key, data)
+	return data, nil
+}
+
+// objectLRU keeps objects until their total size exceeds maxBytes, evicting
+// the least recently used first.
+type objectLRU struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List // of *cachedObject, most recently used first
+	objects map[string]*list.Element
+}
+
+type cachedObject struct {
+	key  string
+	data []byte
+}
+
+func newObjectLRU(maxBytes int64) *objectLRU {
+	return &objectLRU{maxBytes: maxBytes, lru: list.New(), objects: map[string]*list.Element{}}
+}
+
+func (c *objectLRU) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.objects[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*cachedObject).data, true
+}
+
+func (c *objectLRU) add(key string, data []byte) {
+	size := int64(len(data))
+	if size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[key]; ok {
+		return
+	}
+	c.objects[key] = c.lru.PushFront(&cachedObject{key: key, data: data})
+	c.size += size
+	for c.size > c.maxBytes {
+		e := c.lru.Back()
+		obj := e.Value.(*cachedObject)
+		c.lru.Remove(e)
+		delete(c.objects, obj.key)
+		c.size -= int64(len(obj.data))
+	}
+}
@@ -3,112 +3,145 @@ package storage
 import (
 	"context"
 	"errors"
-	"fmt"
-	"io"
 	"os"
-	"path/filepath"
-
-	"cloud.google.com/go/storage"
-	"google.golang.org/api/googleapi"
+	"time"
 )
 
 type Config struct {
+	// Backend is gcs (default), local or s3
+	Backend      string
 	Bucket       string
 	PublicBucket string
+	// ProjectID is the GCP project where EnsureBuckets creates GCS buckets
+	ProjectID string
+	// LocalDir holds a directory per bucket for the local backend
+	LocalDir string
+	S3       S3Config
 }
 
 var DEFAULT_CONFIG = Config{
+	Backend:      BackendGCS,
 	Bucket:       "testcase",
 	PublicBucket: "testcase-public",
 }
 
 type Client struct {
-	client       *storage.Client
-	bucket       string
-	publicBucket string
+	bucket       Bucket
+	publicBucket Bucket
+	closers      []func() error
+	// uploads limits the files uploaded at once by all copies of the client;
+	// nil means one by one
+	uploads chan struct{}
+	// uploadTarball also uploads the test cases as a tarball
+	uploadTarball bool
+}
+
+// UploadOptions configures how a client uploads files.
+type UploadOptions struct {
+	// Concurrency is the number of files uploaded at once, shared by all
+	// goroutines using the client. 0 or 1 uploads them one by one.
+	Concurrency int
+	// Attempts is the number of tries of each request. 0 or 1 does not retry.
+	Attempts int
+	// Backoff is the wait before the first retry, doubled for each next one.
+	Backoff time.Duration
+	// TestCaseTarball also uploads the whole test cases as a tarball, for the
+	// judges which do not read the manifests. Otherwise only the changed
+	// objects are uploaded.
+	TestCaseTarball bool
+}
+
+// WithUploadOptions returns a client of the same buckets which uploads with
+// o. Only one of c and the returned client should be closed.
+func (c Client) WithUploadOptions(o UploadOptions) Client {
+	if o.Attempts > 1 {
+		c.bucket = newRetryBucket(c.bucket, o.Attempts, o.Backoff)
+		c.publicBucket = newRetryBucket(c.publicBucket, o.Attempts, o.Backoff)
+	}
+	c.uploadTarball = o.TestCaseTarball
+	c.uploads = nil
+	if o.Concurrency > 1 {
+		c.uploads = make(chan struct{}, o.Concurrency)
+	}
+	return c
+}
+
+// NewClient returns a client of the given private and public buckets.
+func NewClient(bucket, publicBucket Bucket) Client {
+	return Client{bucket: bucket, publicBucket: publicBucket}
 }
 
 func GetConfigFromEnv() Config {
 	config := DEFAULT_CONFIG
+	if backend := os.Getenv("STORAGE_BACKEND"); backend != "" {
+		config.Backend = backend
+	}
 	if bucket := os.Getenv("STORAGE_PRIVATE_BUCKET"); bucket != "" {
 		config.Bucket = bucket
 	}
 	if publicBucket := os.Getenv("STORAGE_PUBLIC_BUCKET"); publicBucket != "" {
 		config.PublicBucket = publicBucket
 	}
+	config.ProjectID = os.Getenv("STORAGE_PROJECT_ID")
+	config.LocalDir = os.Getenv("STORAGE_LOCAL_DIR")
+	config.S3 = S3Config{
+		Endpoint:        os.Getenv("STORAGE_S3_ENDPOINT"),
+		Region:          os.Getenv("STORAGE_S3_REGION"),
+		AccessKeyID:     os.Getenv("STORAGE_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("STORAGE_S3_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("STORAGE_S3_SESSION_TOKEN"),
+		VirtualHost:     os.Getenv("STORAGE_S3_VIRTUAL_HOST") == "true",
+	}
+	if config.S3.AccessKeyID == "" {
+		config.S3.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		config.S3.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		config.S3.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
 	return config
 }
 
 func Connect(ctx context.Context, config Config) (Client, error) {
-	client, err := storage.NewClient(ctx)
+	bucket, closeBucket, err := openBucket(ctx, config, config.Bucket)
 	if err != nil {
 		return Client{}, err
 	}
-
+	publicBucket, closePublicBucket, err := openBucket(ctx, config, config.PublicBucket)
+	if err != nil {
+		_ = closeBucket()
+		return Client{}, err
+	}
 	return Client{
-		client:       client,
-		bucket:       config.Bucket,
-		publicBucket: config.PublicBucket,
+		bucket:       bucket,
+		publicBucket: publicBucket,
+		closers:      []func() error{closeBucket, closePublicBucket},
 	}, nil
 }
 
 func (c Client) Close() error {
-	return c.client.Close()
-}
-
-func (c Client) downloadToFile(ctx context.Context, bucketName, objectName, destPath string) (err error) {
-	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
-		return err
-	}
-	reader, err := c.client.Bucket(bucketName).Object(objectName).NewReader(ctx)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if closeErr := reader.Close(); closeErr != nil && err == nil {
-			err = closeErr
-		}
-	}()
-
-	file, err := os.Create(destPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil && err == nil {
-			err = closeErr
-		}
-	}()
-
-	if _, err := io.Copy(file, reader); err != nil {
-		return err
+	errs := []error{}
+	for _, closer := range c.closers {
+		errs = append(errs, closer())
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
-func (c Client) uploadFile(ctx context.Context, bucketName, objectName, srcPath string) error {
-	file, err := os.Open(srcPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
+// Bucket returns the private bucket, which holds the test cases.
+func (c Client) Bucket() Bucket {
+	return c.bucket
+}
 
-	w := c.client.Bucket(bucketName).Object(objectName).NewWriter(ctx)
-	if _, err := io.Copy(w, file); err != nil {
-		_ = w.Close()
-		return fmt.Errorf("upload copy failed: %w", err)
-	}
-	return w.Close()
+// PublicBucket returns the public bucket, which holds the examples and public files.
+func (c Client) PublicBucket() Bucket {
+	return c.publicBucket
 }
 
+// EnsureBuckets creates the buckets of config if they do not exist. projectID
+// is used for GCS; it overrides config.ProjectID if not empty.
 func EnsureBuckets(ctx context.Context, config Config, projectID string) error {
-	if projectID == "" {
-		return fmt.Errorf("project ID is required")
+	if projectID != "" {
+		config.ProjectID = projectID
 	}
-
-	client, err := storage.NewClient(ctx)
+	client, err := Connect(ctx, config)
 	if err != nil {
 		return err
 	}
@@ -116,29 +149,14 @@ func EnsureBuckets(ctx context.Context, config Config, projectID string) error {
 		_ = client.Close()
 	}()
 
-	if err := ensureBucketExists(ctx, client, config.Bucket, projectID); err != nil {
-		return err
-	}
-	if err := ensureBucketExists(ctx, client, config.PublicBucket, projectID); err != nil {
-		return err
-	}
-	return nil
-}
-
-func ensureBucketExists(ctx context.Context, client *storage.Client, bucketName, projectID string) error {
-	if bucketName == "" {
-		return nil
-	}
-	if _, err := client.Bucket(bucketName).Attrs(ctx); err != nil {
-		if !errors.Is(err, storage.ErrBucketNotExist) {
+	if config.Bucket != "" {
+		if err := client.bucket.Ensure(ctx); err != nil {
 			return err
 		}
-		if err := client.Bucket(bucketName).Create(ctx, projectID, nil); err != nil {
-			var gErr *googleapi.Error
-			if errors.As(err, &gErr) && gErr.Code == 409 {
-				return nil
-			}
-			return fmt.Errorf("create bucket %s: %w", bucketName, err)
+	}
+	if config.PublicBucket != "" {
+		if err := client.publicBucket.Ensure(ctx); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -37,12 +37,12 @@ func TestClientUploadDownloadWithEmulator(t *testing.T) {
 	}
 
 	const objectName = "nested/object.txt"
-	if err := client.uploadFile(context.Background(), client.bucket, objectName, src); err != nil {
+	if err := uploadFile(context.Background(), client.bucket, objectName, src); err != nil {
 		t.Fatal(err)
 	}
 
 	dest := filepath.Join(t.TempDir(), "downloaded.txt")
-	if err := client.downloadToFile(context.Background(), client.bucket, objectName, dest); err != nil {
+	if err := downloadToFile(context.Background(), client.bucket, objectName, dest); err != nil {
 		t.Fatal(err)
 	}
 
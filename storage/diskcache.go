@@ -0,0 +1,664 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kinds of the entries of diskCache. Each entry is a dir {dir}/{kind}/{version}.
+const (
+	cacheKindTestCase = "testcase"
+	cacheKindPublic   = "public"
+)
+
+const cacheBlobDir = "blobs"
+
+// cacheVerifiedDir holds a marker {version}.json per test case entry whose
+// files were verified, recording their sizes and mtimes.
+const cacheVerifiedDir = "verified"
+
+// diskCache is a directory of extracted problem files, shared by the judges of
+// a host and bounded by maxBytes. The mtime of an entry is its last use, and
+// the least recently used entries are evicted first.
+//
+// The test case files are also kept by content in {dir}/blobs/{sha256}, so
+// that a new version downloads only the files changed from the cached ones.
+// The entries hard link the blobs, and the blobs not linked from any entry
+// are removed on eviction. Files shared by entries are counted for each of
+// them, so maxBytes is conservative.
+//
+// The test case entries are verified against their manifests when the cache
+// is opened. To keep the start fast, an entry whose files have the sizes and
+// mtimes recorded in its marker in {dir}/verified is not hashed again.
+//
+// The processes coordinate with file locks:
+//   - {dir}/lock is held exclusively while evicting or validating entries
+//   - {dir}/locks/{kind}-{version}.lock is held shared while an entry is in
+//     use, and exclusively while it is removed along with the entry
+//   - {dir}/tmp/{id}/.lock is held by the process working in {dir}/tmp/{id};
+//     work dirs whose lock is free were left by a dead process
+type diskCache struct {
+	dir      string
+	maxBytes int64
+	// workDir holds the downloads and extractions in progress; it is in dir so
+	// that finished entries can be renamed into place
+	workDir  string
+	workLock *fileLock
+	now      func() time.Time
+
+	// fills serializes the fills of an entry in this process
+	mu    sync.Mutex
+	fills map[string]*entryFill
+}
+
+// entryFill is the state of the fills of an entry in this process. The other
+// fields are guarded by diskCache.mu.
+type entryFill struct {
+	mu sync.Mutex
+	// refs is the number of the acquires holding or waiting for mu; the fill
+	// is dropped from diskCache.fills at 0
+	refs int
+	// foreground is the number of the foreground acquires waiting for mu
+	foreground int
+	// unthrottled lifts the bandwidth limit of the fill in progress, if any
+	unthrottled *atomic.Bool
+}
+
+type cacheEntry struct {
+	kind    string
+	version string
+	dir     string
+	size    int64
+	usedAt  time.Time
+}
+
+// openDiskCache opens the cache at dir, and removes the entries that are
+// invalid or over maxBytes (0 means unlimited).
+func openDiskCache(dir string, maxBytes int64) (*diskCache, error) {
+	for _, sub := range []string{cacheKindTestCase, cacheKindPublic, cacheBlobDir, cacheVerifiedDir, "locks", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	c := &diskCache{dir: dir, maxBytes: maxBytes, now: time.Now, fills: map[string]*entryFill{}}
+
+	lock, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.unlock() }()
+
+	if err := c.removeStaleWorkDirs(); err != nil {
+		return nil, err
+	}
+	workDir, err := os.MkdirTemp(filepath.Join(dir, "tmp"), "")
+	if err != nil {
+		return nil, err
+	}
+	workLock, err := lockFile(filepath.Join(workDir, ".lock"), true, false)
+	if err != nil {
+		_ = os.RemoveAll(workDir)
+		return nil, err
+	}
+	c.workDir = workDir
+	c.workLock = workLock
+
+	if err := c.validate(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if err := c.removeStaleEntryLocks(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if err := c.evictLocked(); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close removes the work dir of this process. The entries are kept.
+func (c *diskCache) Close() error {
+	err := os.RemoveAll(c.workDir)
+	return errors.Join(err, c.workLock.unlock())
+}
+
+func (c *diskCache) lock() (*fileLock, error) {
+	return lockFile(filepath.Join(c.dir, "lock"), true, true)
+}
+
+func (c *diskCache) entryLockPath(kind, version string) string {
+	return filepath.Join(c.dir, "locks", kind+"-"+version+".lock")
+}
+
+// acquire returns the dir of the entry, filling it by fill if it is missing.
+// fill gets a work dir and the path where it must create the entry; the
+// entry must appear there atomically (e.g. by a rename). The entry is not
+// evicted until release is called.
+//
+// A background acquire is one nobody waits for, e.g. a prefetch. Once a
+// foreground acquire waits for its fill, the downloads with the context passed
+// to fill are no longer throttled.
+func (c *diskCache) acquire(kind, version string, background bool, fill func(ctx context.Context, workDir, dest string) error) (dir string, release func(), err error) {
+	if !validCacheVersion(version) {
+		return "", nil, fmt.Errorf("invalid version: %q", version)
+	}
+	// wait for another goroutine filling the entry, instead of filling it twice
+	f := c.entryFill(kind, version)
+	defer c.putEntryFill(kind, version, f)
+	if !f.mu.TryLock() {
+		if background {
+			f.mu.Lock()
+		} else {
+			c.waitForeground(f)
+		}
+	}
+	defer f.mu.Unlock()
+
+	lock, err := lockFile(c.entryLockPath(kind, version), false, true)
+	if err != nil {
+		return "", nil, err
+	}
+	release = func() { _ = lock.unlock() }
+
+	dir = filepath.Join(c.dir, kind, version)
+	if _, err := os.Stat(dir); err == nil {
+		now := c.now()
+		if err := os.Chtimes(dir, now, now); err != nil {
+			slog.Warn("Failed to update the last use of cache entry", "dir", dir, "err", err)
+		}
+		return dir, release, nil
+	}
+
+	workDir, err := os.MkdirTemp(c.workDir, kind+"-")
+	if err != nil {
+		release()
+		return "", nil, err
+	}
+	defer func() { _ = os.RemoveAll(workDir) }()
+	ctx, done := c.startFill(f)
+	defer done()
+	if err := fill(ctx, workDir, dir); err != nil {
+		release()
+		return "", nil, err
+	}
+	if kind == cacheKindTestCase {
+		// the fill verifies the files
+		if err := writeVerifiedMarker(dir, c.verifiedMarkerPath(version), workDir); err != nil {
+			slog.Warn("Failed to write verified marker", "dir", dir, "err", err)
+		}
+	}
+	if err := c.evict(); err != nil {
+		slog.Warn("Failed to evict cache entries", "err", err)
+	}
+	return dir, release, nil
+}
+
+func (c *diskCache) entryFill(kind, version string) *entryFill {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := kind + "/" + version
+	if _, ok := c.fills[key]; !ok {
+		c.fills[key] = &entryFill{}
+	}
+	c.fills[key].refs++
+	return c.fills[key]
+}
+
+// putEntryFill drops the reference to f got by entryFill, after f.mu is unlocked.
+func (c *diskCache) putEntryFill(kind, version string, f *entryFill) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f.refs--
+	if f.refs == 0 {
+		delete(c.fills, kind+"/"+version)
+	}
+}
+
+// waitForeground locks f.mu, lifting the bandwidth limit of the fill holding it.
+func (c *diskCache) waitForeground(f *entryFill) {
+	c.mu.Lock()
+	f.foreground++
+	if f.unthrottled != nil {
+		f.unthrottled.Store(true)
+	}
+	c.mu.Unlock()
+
+	f.mu.Lock()
+
+	c.mu.Lock()
+	f.foreground--
+	c.mu.Unlock()
+}
+
+// startFill returns the context of a fill of f, which the caller holds; it is
+// unthrottled if a foreground acquire already waits. done must be called when
+// the fill finishes.
+func (c *diskCache) startFill(f *entryFill) (ctx context.Context, done func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	unthrottled := &atomic.Bool{}
+	unthrottled.Store(f.foreground > 0)
+	f.unthrottled = unthrottled
+	return withUnthrottle(context.Background(), unthrottled), func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		f.unthrottled = nil
+	}
+}
+
+// validCacheVersion reports whether version is usable as a file name.
+func validCacheVersion(version string) bool {
+	return version != "" && version != "." && version != ".." && !strings.ContainsAny(version, `/\`)
+}
+
+func (c *diskCache) evict() error {
+	lock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.unlock() }()
+	return c.evictLocked()
+}
+
+// evictLocked removes the least recently used entries not in use until the
+// cache fits maxBytes, and then the blobs no longer linked. The caller holds
+// the cache lock.
+func (c *diskCache) evictLocked() error {
+	if err := c.evictEntries(); err != nil {
+		return err
+	}
+	return c.removeUnlinkedBlobs()
+}
+
+func (c *diskCache) evictEntries() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	total := int64(0)
+	for _, e := range entries {
+		total += e.size
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].usedAt.Before(entries[j].usedAt) })
+	for _, e := range entries {
+		if total <= c.maxBytes {
+			return nil
+		}
+		removed, err := c.removeUnused(e)
+		if err != nil {
+			return err
+		}
+		if removed {
+			slog.Info("Evict cache entry", "kind", e.kind, "version", e.version, "size", e.size)
+			total -= e.size
+		}
+	}
+	if total > c.maxBytes {
+		slog.Warn("Cache exceeds the limit because entries are in use", "size", total, "max", c.maxBytes)
+	}
+	return nil
+}
+
+// blob returns the path of the blob of the file with hash. If it is missing,
+// fetch downloads the file to tmpPath in workDir and verifies its hash.
+func (c *diskCache) blob(workDir, hash string, fetch func(tmpPath string) error) (string, error) {
+	if !validSHA256(hash) {
+		return "", fmt.Errorf("invalid hash: %q", hash)
+	}
+	blobPath := filepath.Join(c.dir, cacheBlobDir, hash)
+	if _, err := os.Stat(blobPath); err == nil {
+		return blobPath, nil
+	}
+	tmpPath := filepath.Join(workDir, "blob-"+hash)
+	if err := fetch(tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, blobPath); err != nil {
+		return "", err
+	}
+	return blobPath, nil
+}
+
+// removeUnlinkedBlobs removes the blobs which are not in the manifest of any
+// test case entry. The caller holds the cache lock. A fill may still be
+// linking a removed blob, so it must download it again if the link fails.
+func (c *diskCache) removeUnlinkedBlobs() error {
+	files, err := os.ReadDir(filepath.Join(c.dir, cacheBlobDir))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	linked := map[string]bool{}
+	entries, err := os.ReadDir(filepath.Join(c.dir, cacheKindTestCase))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		manifest, err := readExtractedManifest(filepath.Join(c.dir, cacheKindTestCase, e.Name()))
+		if err != nil {
+			slog.Warn("Failed to read manifest of cache entry", "version", e.Name(), "err", err)
+			continue
+		}
+		if manifest == nil {
+			continue
+		}
+		for _, tc := range manifest.Cases {
+			linked[tc.In.SHA256] = true
+			linked[tc.Out.SHA256] = true
+		}
+	}
+	for _, f := range files {
+		if linked[f.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, cacheBlobDir, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validSHA256(hash string) bool {
+	if len(hash) != 64 {
+		return false
+	}
+	for _, c := range hash {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// removeUnused removes the entry unless another caller uses it.
+func (c *diskCache) removeUnused(e cacheEntry) (bool, error) {
+	lock, err := lockFile(c.entryLockPath(e.kind, e.version), true, false)
+	if errors.Is(err, errLocked) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = lock.unlock() }()
+	if err := c.removeEntry(e); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeEntry removes the entry, its verified marker and its lock file. The
+// caller holds the lock of the entry exclusively; the others waiting for the
+// lock take it again on a new file.
+func (c *diskCache) removeEntry(e cacheEntry) error {
+	if err := os.RemoveAll(e.dir); err != nil {
+		return err
+	}
+	for _, path := range []string{c.verifiedMarkerPath(e.version), c.entryLockPath(e.kind, e.version)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// entries returns all entries with their sizes. Stray files are removed.
+func (c *diskCache) entries() ([]cacheEntry, error) {
+	entries := []cacheEntry{}
+	for _, kind := range []string{cacheKindTestCase, cacheKindPublic} {
+		files, err := os.ReadDir(filepath.Join(c.dir, kind))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			dir := filepath.Join(c.dir, kind, f.Name())
+			if !f.IsDir() || !validCacheVersion(f.Name()) {
+				slog.Warn("Remove unknown file in cache", "path", dir)
+				if err := os.RemoveAll(dir); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			info, err := f.Info()
+			if err != nil {
+				return nil, err
+			}
+			size, err := dirSize(dir)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, cacheEntry{kind: kind, version: f.Name(), dir: dir, size: size, usedAt: info.ModTime()})
+		}
+	}
+	return entries, nil
+}
+
+// validate removes the test case entries whose files do not match their
+// manifest, e.g. modified by hand. Only the entries whose files differ from
+// their verified markers are hashed. Entries in use are skipped.
+func (c *diskCache) validate() error {
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+	versions := map[string]bool{}
+	for _, e := range entries {
+		if e.kind != cacheKindTestCase {
+			// public files are complete once renamed into place
+			continue
+		}
+		versions[e.version] = true
+		lock, err := lockFile(c.entryLockPath(e.kind, e.version), true, false)
+		if errors.Is(err, errLocked) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = c.validateEntry(e)
+		_ = lock.unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return c.removeStaleVerifiedMarkers(versions)
+}
+
+// validateEntry hashes the files of the entry unless they match its verified
+// marker, and removes the entry if they do not match the manifest.
+func (c *diskCache) validateEntry(e cacheEntry) error {
+	marker := c.verifiedMarkerPath(e.version)
+	if matchVerifiedMarker(e.dir, marker) {
+		return nil
+	}
+	if err := verifyTestCaseDir(e.dir, e.version); err != nil {
+		slog.Warn("Remove invalid cache entry", "dir", e.dir, "err", err)
+		return c.removeEntry(e)
+	}
+	return writeVerifiedMarker(e.dir, marker, c.workDir)
+}
+
+// removeStaleVerifiedMarkers removes the markers of the entries not in
+// versions, e.g. left by a process killed while removing an entry.
+func (c *diskCache) removeStaleVerifiedMarkers(versions map[string]bool) error {
+	files, err := os.ReadDir(filepath.Join(c.dir, cacheVerifiedDir))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if versions[strings.TrimSuffix(f.Name(), ".json")] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, cacheVerifiedDir, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleEntryLocks removes the lock files of the missing entries, e.g.
+// left by a failed fill, unless they are in use. The caller holds the cache
+// lock.
+func (c *diskCache) removeStaleEntryLocks() error {
+	files, err := os.ReadDir(filepath.Join(c.dir, "locks"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		kind, version, ok := strings.Cut(strings.TrimSuffix(f.Name(), ".lock"), "-")
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.dir, kind, version)); err == nil {
+			continue
+		}
+		lock, err := lockFile(filepath.Join(c.dir, "locks", f.Name()), true, false)
+		if errors.Is(err, errLocked) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = os.Remove(filepath.Join(c.dir, "locks", f.Name()))
+		_ = lock.unlock()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *diskCache) verifiedMarkerPath(version string) string {
+	return filepath.Join(c.dir, cacheVerifiedDir, version+".json")
+}
+
+// verifiedFile is a file of a verified entry. A file of the same size and
+// mtime is assumed not to be modified since.
+type verifiedFile struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"mtime"`
+}
+
+func verifiedFiles(dir string) (map[string]verifiedFile, error) {
+	files := map[string]verifiedFile{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = verifiedFile{Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+		return nil
+	})
+	return files, err
+}
+
+// writeVerifiedMarker records the files of dir, which must be verified, in
+// marker. The marker is written in workDir and renamed into place.
+func writeVerifiedMarker(dir, marker, workDir string) error {
+	files, err := verifiedFiles(dir)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(workDir, "verified-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), marker)
+}
+
+// matchVerifiedMarker reports whether the files of dir are the ones recorded
+// in marker.
+func matchVerifiedMarker(dir, marker string) bool {
+	data, err := os.ReadFile(marker)
+	if err != nil {
+		return false
+	}
+	recorded := map[string]verifiedFile{}
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return false
+	}
+	files, err := verifiedFiles(dir)
+	if err != nil {
+		return false
+	}
+	return maps.Equal(files, recorded)
+}
+
+func (c *diskCache) removeStaleWorkDirs() error {
+	files, err := os.ReadDir(filepath.Join(c.dir, "tmp"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		dir := filepath.Join(c.dir, "tmp", f.Name())
+		lock, err := lockFile(filepath.Join(dir, ".lock"), true, false)
+		if errors.Is(err, errLocked) {
+			continue
+		}
+		if err == nil {
+			_ = lock.unlock()
+		}
+		slog.Info("Remove stale work dir", "dir", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dirSize(dir string) (int64, error) {
+	size := int64(0)
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,316 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fillWith returns a fill func of diskCache.acquire creating a file of size bytes.
+func fillWith(size int) func(ctx context.Context, workDir, dest string) error {
+	return func(ctx context.Context, workDir, dest string) error {
+		tmp := filepath.Join(workDir, "entry")
+		if err := os.MkdirAll(tmp, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(tmp, "data"), []byte(strings.Repeat("x", size)), 0o644); err != nil {
+			return err
+		}
+		return os.Rename(tmp, dest)
+	}
+}
+
+func mustAcquire(t *testing.T, c *diskCache, version string, size int) func() {
+	t.Helper()
+	_, release, err := c.acquire(cacheKindPublic, version, false, fillWith(size))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return release
+}
+
+func cachedVersions(t *testing.T, dir, kind string) []string {
+	t.Helper()
+	files, err := os.ReadDir(filepath.Join(dir, kind))
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []string{}
+	for _, f := range files {
+		versions = append(versions, f.Name())
+	}
+	return versions
+}
+
+func TestDiskCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	c, err := openDiskCache(dir, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	mustAcquire(t, c, "a", 10)()
+	mustAcquire(t, c, "b", 10)()
+	// use a again, later than b
+	c.now = func() time.Time { return time.Now().Add(time.Hour) }
+	mustAcquire(t, c, "a", 0)()
+	mustAcquire(t, c, "c", 10)()
+
+	if got := strings.Join(cachedVersions(t, dir, cacheKindPublic), ","); got != "a,c" {
+		t.Errorf("cached = %s, want a,c", got)
+	}
+	// the lock of the evicted entry is removed too
+	if got := strings.Join(cachedVersions(t, dir, "locks"), ","); got != "public-a.lock,public-c.lock" {
+		t.Errorf("locks = %s", got)
+	}
+}
+
+func TestDiskCacheKeepsEntriesInUse(t *testing.T) {
+	dir := t.TempDir()
+	c, err := openDiskCache(dir, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	releaseA := mustAcquire(t, c, "a", 10)
+	releaseB := mustAcquire(t, c, "b", 10)
+	if got := strings.Join(cachedVersions(t, dir, cacheKindPublic), ","); got != "a,b" {
+		t.Errorf("cached = %s, want a,b", got)
+	}
+	releaseA()
+	releaseB()
+	if err := c.evict(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cachedVersions(t, dir, cacheKindPublic); len(got) != 0 {
+		t.Errorf("cached = %v, want none", got)
+	}
+}
+
+func TestDiskCacheFillsOnce(t *testing.T) {
+	c, err := openDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	fills := atomic.Int32{}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, release, err := c.acquire(cacheKindPublic, "a", false, func(ctx context.Context, workDir, dest string) error {
+				fills.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				return fillWith(1)(ctx, workDir, dest)
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			release()
+		}()
+	}
+	wg.Wait()
+	if n := fills.Load(); n != 1 {
+		t.Errorf("filled %d times", n)
+	}
+	if len(c.fills) != 0 {
+		t.Errorf("fills are left: %v", c.fills)
+	}
+}
+
+func TestOpenDiskCacheRemovesStaleLocks(t *testing.T) {
+	dir := t.TempDir()
+	c, err := openDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := mustAcquire(t, c, "a", 1)
+	failed := func(ctx context.Context, workDir, dest string) error { return os.ErrNotExist }
+	if _, _, err := c.acquire(cacheKindPublic, "b", false, failed); err == nil {
+		t.Fatal("acquire succeeded")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = openDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	defer release()
+	if got := strings.Join(cachedVersions(t, dir, "locks"), ","); got != "public-a.lock" {
+		t.Errorf("locks = %s, want public-a.lock", got)
+	}
+}
+
+func TestDiskCacheRejectsInvalidVersion(t *testing.T) {
+	c, err := openDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	for _, version := range []string{"", ".", "..", "../x", "a/b"} {
+		if _, _, err := c.acquire(cacheKindTestCase, version, false, fillWith(1)); err == nil {
+			t.Errorf("acquire(%q) succeeded", version)
+		}
+	}
+}
+
+func TestOpenDiskCacheValidates(t *testing.T) {
+	dir := t.TempDir()
+	entries, version := testCaseEntries(t, map[string]string{"in/a.in": "1\n", "out/a.out": "2\n"})
+	tarGzPath := writeTarGz(t, entries)
+	for _, v := range []string{version, "broken"} {
+		if err := os.MkdirAll(filepath.Join(dir, cacheKindTestCase), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := extractTarGz(tarGzPath, filepath.Join(dir, cacheKindTestCase, v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	live, err := openDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = live.Close() }()
+	if got := strings.Join(cachedVersions(t, dir, cacheKindTestCase), ","); got != version {
+		t.Errorf("cached = %s, want %s", got, version)
+	}
+
+	// a verified entry is not hashed again while its files keep their sizes
+	// and mtimes
+	in := filepath.Join(dir, cacheKindTestCase, version, "in", "a.in")
+	info, err := os.Stat(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(in, []byte("9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(in, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := openDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cachedVersions(t, dir, cacheKindTestCase), ","); got != version {
+		t.Errorf("cached = %s, want %s", got, version)
+	}
+
+	// a modified file and a work dir of a dead process
+	if err := os.WriteFile(filepath.Join(dir, cacheKindTestCase, version, "in", "a.in"), []byte("0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(dir, "tmp", "stale")
+	if err := os.MkdirAll(stale, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := openDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	if got := cachedVersions(t, dir, cacheKindTestCase); len(got) != 0 {
+		t.Errorf("cached = %v, want none", got)
+	}
+	if got := cachedVersions(t, dir, cacheVerifiedDir); len(got) != 0 {
+		t.Errorf("verified markers = %v, want none", got)
+	}
+	if _, err := os.Stat(stale); err == nil {
+		t.Error("stale work dir is not removed")
+	}
+	if _, err := os.Stat(live.workDir); err != nil {
+		t.Error("work dir of a live process is removed:", err)
+	}
+}
+
+func TestTestCaseDownloaderPersistentCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public")))
+
+	entries, version := testCaseEntries(t, map[string]string{"in/a.in": "1\n", "out/a.out": "2\n"})
+	problem := Problem{Name: "aplusb", OverallVersion: "overall", TestCaseVersion: version}
+	tarGz, err := os.Open(writeTarGz(t, entries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = tarGz.Close() }()
+	if err := client.Bucket().Put(ctx, problem.v4TestCasesKey(), tarGz); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PublicBucket().Put(ctx, problem.v4FilesProblemKey("checker.cpp"), strings.NewReader("checker")); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DownloaderConfig{CacheDir: cacheDir, CacheMaxBytes: DefaultCacheMaxBytes}
+	downloader, err := NewTestCaseDownloaderWithConfig(client, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := downloader.Fetch(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+	if err := downloader.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// restart without the buckets; the files come from the cache
+	empty := NewClient(NewLocalBucket(filepath.Join(dir, "none")), NewLocalBucket(filepath.Join(dir, "none")))
+	downloader, err = NewTestCaseDownloaderWithConfig(empty, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+	files, err = downloader.Fetch(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer files.Release()
+	for path, want := range map[string]string{files.InFilePath("a"): "1\n", files.CheckerPath(): "checker"} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestGetDownloaderConfigFromEnv(t *testing.T) {
+	t.Setenv("TESTCASE_CACHE_DIR", "/var/cache/judge")
+	t.Setenv("TESTCASE_CACHE_MAX_BYTES", "1024")
+	config, err := GetDownloaderConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config != (DownloaderConfig{CacheDir: "/var/cache/judge", CacheMaxBytes: 1024}) {
+		t.Errorf("config = %+v", config)
+	}
+
+	t.Setenv("TESTCASE_CACHE_MAX_BYTES", "10G")
+	if _, err := GetDownloaderConfigFromEnv(); err == nil {
+		t.Error("invalid size is accepted")
+	}
+}
@@ -2,88 +2,166 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
-
-	"cloud.google.com/go/storage"
-	"google.golang.org/api/iterator"
 )
 
+// DefaultCacheMaxBytes is the default size limit of the test case cache.
+const DefaultCacheMaxBytes = 20 << 30
+
+// DownloaderConfig configures the local cache of TestCaseDownloader.
+type DownloaderConfig struct {
+	// CacheDir is the cache shared by the judges of the host. If empty, a
+	// temporary dir is used and removed by Close.
+	CacheDir string
+	// CacheMaxBytes is the size limit of the cache; 0 means unlimited
+	CacheMaxBytes int64
+}
+
+func GetDownloaderConfigFromEnv() (DownloaderConfig, error) {
+	config := DownloaderConfig{
+		CacheDir:      os.Getenv("TESTCASE_CACHE_DIR"),
+		CacheMaxBytes: DefaultCacheMaxBytes,
+	}
+	if v := os.Getenv("TESTCASE_CACHE_MAX_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return DownloaderConfig{}, fmt.Errorf("invalid TESTCASE_CACHE_MAX_BYTES: %q", v)
+		}
+		config.CacheMaxBytes = n
+	}
+	return config, nil
+}
+
 type TestCaseDownloader struct {
-	client   Client
-	localDir string
+	client Client
+	cache  *diskCache
+	// tempDir is removed by Close if the cache is not persistent
+	tempDir string
+	// background is set by WithBandwidthLimit
+	background bool
 }
 
+// NewTestCaseDownloader returns a downloader with a temporary cache.
 func NewTestCaseDownloader(client Client) (TestCaseDownloader, error) {
-	dir, err := os.MkdirTemp("", "case")
+	return NewTestCaseDownloaderWithConfig(client, DownloaderConfig{CacheMaxBytes: DefaultCacheMaxBytes})
+}
+
+func NewTestCaseDownloaderWithConfig(client Client, config DownloaderConfig) (TestCaseDownloader, error) {
+	dir := config.CacheDir
+	tempDir := ""
+	if dir == "" {
+		var err error
+		if dir, err = os.MkdirTemp("", "case"); err != nil {
+			slog.Error("Failed to create tempdir", "err", err)
+			return TestCaseDownloader{}, err
+		}
+		tempDir = dir
+	}
+	cache, err := openDiskCache(dir, config.CacheMaxBytes)
 	if err != nil {
-		slog.Error("Failed to create tempdir", "err", err)
+		slog.Error("Failed to open cache", "dir", dir, "err", err)
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
 		return TestCaseDownloader{}, err
 	}
-	slog.Info("TestCaseDownloader created", "dir", dir)
+	slog.Info("TestCaseDownloader created", "dir", dir, "max_bytes", config.CacheMaxBytes)
 	return TestCaseDownloader{
-		client:   client,
-		localDir: dir,
+		client:  client,
+		cache:   cache,
+		tempDir: tempDir,
 	}, nil
 }
+
 func (t TestCaseDownloader) Close() error {
-	if err := os.RemoveAll(t.localDir); err != nil {
-		return err
+	err := t.cache.Close()
+	if t.tempDir != "" {
+		err = errors.Join(err, os.RemoveAll(t.tempDir))
 	}
-	return nil
+	return err
 }
 
 type ProblemFiles struct {
 	TestCases   string
 	PublicFiles string
+
+	release func()
 }
 
+// Release allows the cache to evict the files. They must not be used after.
+func (p ProblemFiles) Release() {
+	if p.release != nil {
+		p.release()
+	}
+}
+
+// Fetch downloads the files of the problem unless they are cached. The
+// returned files are kept in the cache until Release is called.
 func (t TestCaseDownloader) Fetch(problem Problem) (ProblemFiles, error) {
-	testCases, err := t.fetchTestCases(problem)
+	testCases, releaseTestCases, err := t.cache.acquire(cacheKindTestCase, problem.TestCaseVersion, t.background, func(ctx context.Context, workDir, dest string) error {
+		return t.fetchTestCases(ctx, problem, workDir, dest)
+	})
 	if err != nil {
 		return ProblemFiles{}, err
 	}
-	publicFiles, err := t.fetchPublicFiles(problem)
+	publicFiles, releasePublicFiles, err := t.cache.acquire(cacheKindPublic, problem.OverallVersion, t.background, func(ctx context.Context, workDir, dest string) error {
+		return t.fetchPublicFiles(ctx, problem, workDir, dest)
+	})
 	if err != nil {
+		releaseTestCases()
 		return ProblemFiles{}, err
 	}
 
 	return ProblemFiles{
 		TestCases:   testCases,
 		PublicFiles: publicFiles,
+		release: func() {
+			releaseTestCases()
+			releasePublicFiles()
+		},
 	}, nil
 }
 
-func (t TestCaseDownloader) fetchTestCases(problem Problem) (string, error) {
+func (t TestCaseDownloader) fetchTestCases(ctx context.Context, problem Problem, workDir, destDir string) error {
 	slog.Info("Download test cases", "name", problem.Name, "hash", problem.TestCaseVersion)
 
-	tarGzPath := path.Join(t.localDir, problem.TestCaseVersion+".tar.gz")
-	localDir := path.Join(t.localDir, problem.TestCaseVersion)
+	var manifest *TestCaseManifest
+	if m, err := problem.TestCaseManifest(ctx, t.client); err == nil {
+		err := t.fetchTestCaseObjects(ctx, problem, m, workDir, destDir)
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		slog.Warn("Test case objects are not uploaded, download the tarball", "name", problem.Name, "hash", problem.TestCaseVersion)
+		manifest = &m
+	} else if errors.Is(err, ErrNotFound) {
+		slog.Warn("Test cases have no uploaded manifest", "name", problem.Name, "hash", problem.TestCaseVersion)
+	} else {
+		return err
+	}
+
 	// Phase 2: use v4 path for private testcases tarball
 	key := problem.v4TestCasesKey()
+	tarGzPath := filepath.Join(workDir, "testcases.tar.gz")
 
-	if _, err := os.Stat(tarGzPath); err != nil {
-		slog.Info("Download test cases", "remote", key)
-		if err := t.client.downloadToFile(context.Background(), t.client.bucket, key, tarGzPath); err != nil {
-			return "", err
-		}
-		if err := os.MkdirAll(localDir, os.ModePerm); err != nil {
-			return "", err
-		}
-		cmd := exec.Command("tar", "-xf", tarGzPath, "-C", localDir)
-		if err := cmd.Run(); err != nil {
-			slog.Error("failed to expand tar.gz")
-			return "", err
-		}
+	slog.Info("Download test cases", "remote", key)
+	if err := downloadToFile(ctx, t.client.bucket, key, tarGzPath); err != nil {
+		return err
 	}
-
-	return localDir, nil
+	if err := extractTestCases(tarGzPath, workDir, destDir, problem.TestCaseVersion, manifest); err != nil {
+		slog.Error("Failed to extract test cases", "err", err)
+		return err
+	}
+	return nil
 }
 
-func (t TestCaseDownloader) fetchPublicFiles(problem Problem) (string, error) {
+func (t TestCaseDownloader) fetchPublicFiles(ctx context.Context, problem Problem, workDir, destDir string) error {
 	// Phase 2: switch to v4 public files
 	prefix := problem.v4PublicFilesKeyPrefix()
 	// ensure trailing slash to avoid absolute-join surprises
@@ -91,36 +169,41 @@ func (t TestCaseDownloader) fetchPublicFiles(problem Problem) (string, error) {
 		prefix += "/"
 	}
 
-	destDir := path.Join(t.localDir, problem.OverallVersion)
-	if _, err := os.Stat(destDir); err != nil {
-		slog.Info("Download public files", "name", problem.Name, "overall_version", problem.OverallVersion)
-		it := t.client.client.Bucket(t.client.publicBucket).Objects(context.Background(), &storage.Query{Prefix: prefix})
-		for {
-			attrs, err := it.Next()
-			if err == iterator.Done {
-				break
-			}
-			if err != nil {
-				return "", err
-			}
-			rel := strings.TrimPrefix(attrs.Name, prefix)
-			// v4 layout includes either "common/..." or "{problem}/..."; flatten the latter
-			if strings.HasPrefix(rel, problem.Name+"/") {
-				rel = strings.TrimPrefix(rel, problem.Name+"/")
-			}
-			// guard: strip any leading slashes
-			rel = strings.TrimLeft(rel, "/")
-			if rel == "" {
-				continue
-			}
-			destPath := path.Join(destDir, rel)
-			slog.Info("Download public file", "key", attrs.Name, "to", destPath)
-			if err := t.client.downloadToFile(context.Background(), t.client.publicBucket, attrs.Name, destPath); err != nil {
-				return "", err
-			}
+	slog.Info("Download public files", "name", problem.Name, "overall_version", problem.OverallVersion)
+	objects, err := t.client.publicBucket.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	// download into tmpDir and rename it, as extractTestCases does
+	tmpDir := filepath.Join(workDir, "public")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, prefix)
+		// v4 layout includes either "common/..." or "{problem}/..."; flatten the latter
+		if strings.HasPrefix(rel, problem.Name+"/") {
+			rel = strings.TrimPrefix(rel, problem.Name+"/")
+		}
+		// guard: strip any leading slashes
+		rel = strings.TrimLeft(rel, "/")
+		if rel == "" {
+			continue
+		}
+		destPath := path.Join(tmpDir, rel)
+		slog.Info("Download public file", "key", obj.Key, "to", destPath)
+		if err := downloadToFile(ctx, t.client.publicBucket, obj.Key, destPath); err != nil {
+			return err
 		}
 	}
-	return destDir, nil
+	if err := os.Rename(tmpDir, destDir); err != nil {
+		if _, statErr := os.Stat(destDir); statErr == nil {
+			// downloaded concurrently by another process
+			return nil
+		}
+		return err
+	}
+	return nil
 }
 
 func (p ProblemFiles) PublicFilePath(key string) string {
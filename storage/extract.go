@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// testCaseManifestName is hash.json of the problem, stored at the root of the
+// test case tarball. It maps the file names of in/*.in and out/*.out to their
+// sha256, and the TestCaseVersion is the joined hash of its values.
+const testCaseManifestName = "hash.json"
+
+// extractTestCases extracts the tarball into destDir and verifies it against
+// hash.json in it and manifest, if not nil. The files are written to a temporary dir in workDir, which
+// must be on the same filesystem as destDir. It is renamed into place only if
+// everything is valid, so destDir either does not exist or is complete.
+func extractTestCases(tarGzPath, workDir, destDir, version string, manifest *TestCaseManifest) error {
+	tmpDir, err := os.MkdirTemp(workDir, "extract-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	hashes, err := extractTarGz(tarGzPath, tmpDir)
+	if err != nil {
+		return fmt.Errorf("extract %s: %w", filepath.Base(tarGzPath), err)
+	}
+	if err := verifyTestCases(tmpDir, hashes, version); err != nil {
+		return fmt.Errorf("verify %s: %w", filepath.Base(tarGzPath), err)
+	}
+	if manifest != nil {
+		if err := manifest.verify(tmpDir, hashes, version); err != nil {
+			return fmt.Errorf("verify %s: %w", filepath.Base(tarGzPath), err)
+		}
+		// keep a copy to validate the cache later
+		data, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, testCaseManifestFileName), data, 0o644); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpDir, destDir); err != nil {
+		if _, statErr := os.Stat(destDir); statErr == nil {
+			// extracted concurrently by another call
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// extractTarGz extracts the regular files and dirs of the tarball into dir,
+// and returns the sha256 of each file keyed by its slash separated path.
+// Absolute paths, ".." and links or other special entries are rejected.
+func extractTarGz(tarGzPath, dir string) (map[string]string, error) {
+	file, err := os.Open(tarGzPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = gzipReader.Close() }()
+
+	hashes := map[string]string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name, err := tarEntryName(header.Name)
+		if err != nil {
+			return nil, err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if name == "" {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(name)), 0o755); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if name == "" {
+				return nil, fmt.Errorf("invalid entry: %q", header.Name)
+			}
+			if _, ok := hashes[name]; ok {
+				return nil, fmt.Errorf("duplicated entry: %s", name)
+			}
+			h, err := extractFile(tarReader, filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			hashes[name] = h
+		default:
+			return nil, fmt.Errorf("unsupported entry type %q: %s", header.Typeflag, header.Name)
+		}
+	}
+	// Read to the end so that gzip verifies the checksum of the whole stream
+	if _, err := io.Copy(io.Discard, gzipReader); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// tarEntryName returns the cleaned relative path of the entry, or "" for the
+// root dir itself.
+func tarEntryName(name string) (string, error) {
+	if strings.HasPrefix(name, "/") || strings.Contains(name, `\`) {
+		return "", fmt.Errorf("invalid entry: %q", name)
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("invalid entry: %q", name)
+		}
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+func extractFile(r io.Reader, destPath string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, h), r); err != nil {
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// verifyTestCaseDir verifies the test cases already extracted into dir.
+func verifyTestCaseDir(dir, version string) error {
+	hashes := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("not a regular file: %s", p)
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		h, err := fileHash(p)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = h
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	manifest, err := readExtractedManifest(dir)
+	if err != nil {
+		return err
+	}
+	if manifest != nil {
+		// the entries built from objects have no hash.json
+		return manifest.verify(dir, hashes, version)
+	}
+	return verifyTestCases(dir, hashes, version)
+}
+
+// verifyTestCases checks that the extracted files match the manifest and that
+// the manifest is the one of version. Tarballs uploaded before the manifest
+// was added are accepted without the per-file check.
+func verifyTestCases(dir string, hashes map[string]string, version string) error {
+	data, err := os.ReadFile(filepath.Join(dir, testCaseManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("Test cases have no manifest, skip verification", "version", version)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	manifest := map[string]string{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	expected := make([]string, 0, len(manifest))
+	for name, h := range manifest {
+		expected = append(expected, h)
+		ext := path.Ext(name)
+		if ext != ".in" && ext != ".out" {
+			continue
+		}
+		rel := path.Join(ext[1:], name)
+		actual, ok := hashes[rel]
+		if !ok {
+			return fmt.Errorf("missing file: %s", rel)
+		}
+		if actual != h {
+			return fmt.Errorf("hash mismatch: %s", rel)
+		}
+	}
+	for rel := range hashes {
+		if rel == testCaseManifestName || rel == testCaseManifestFileName {
+			continue
+		}
+		if _, ok := manifest[path.Base(rel)]; !ok {
+			return fmt.Errorf("file not in manifest: %s", rel)
+		}
+	}
+	if v := joinHashes(expected); v != version {
+		return fmt.Errorf("manifest is of version %s, want %s", v, version)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type tarEntry struct {
+	header  tar.Header
+	content string
+}
+
+func writeTarGz(t *testing.T, entries []tarEntry) string {
+	t.Helper()
+	tarGzPath := filepath.Join(t.TempDir(), "cases.tar.gz")
+	file, err := os.Create(tarGzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	gw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		header := e.header
+		if header.Typeflag == 0 {
+			header.Typeflag = tar.TypeReg
+		}
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(e.content))
+		}
+		header.Mode = 0o644
+		if err := tw.WriteHeader(&header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return tarGzPath
+}
+
+func sha256Hex(s string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
+}
+
+// testCaseEntries returns the entries of a valid tarball of cases and its version.
+func testCaseEntries(t *testing.T, cases map[string]string) ([]tarEntry, string) {
+	t.Helper()
+	manifest := map[string]string{}
+	hashes := []string{}
+	entries := []tarEntry{}
+	for name, content := range cases {
+		manifest[filepath.Base(name)] = sha256Hex(content)
+		hashes = append(hashes, sha256Hex(content))
+		entries = append(entries, tarEntry{header: tar.Header{Name: name}, content: content})
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = append([]tarEntry{{header: tar.Header{Name: testCaseManifestName}, content: string(data)}}, entries...)
+	return entries, joinHashes(hashes)
+}
+
+func TestExtractTestCases(t *testing.T) {
+	entries, version := testCaseEntries(t, map[string]string{
+		"in/example_00.in":   "1 2\n",
+		"out/example_00.out": "3\n",
+	})
+	tarGzPath := writeTarGz(t, entries)
+	destDir := filepath.Join(t.TempDir(), version)
+	if err := extractTestCases(tarGzPath, filepath.Dir(destDir), destDir, version, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(destDir, "out", "example_00.out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "3\n" {
+		t.Errorf("out = %q", got)
+	}
+
+	// without manifest (uploaded before it was added)
+	legacy := writeTarGz(t, []tarEntry{{header: tar.Header{Name: "in/example_00.in"}, content: "1 2\n"}})
+	if err := extractTestCases(legacy, t.TempDir(), filepath.Join(t.TempDir(), "legacy"), "legacy", nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExtractTestCasesRejects(t *testing.T) {
+	valid, version := testCaseEntries(t, map[string]string{"in/a.in": "1\n", "out/a.out": "2\n"})
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		version string
+		err     string
+	}{
+		{"absolute", []tarEntry{{header: tar.Header{Name: "/etc/passwd"}}}, version, "invalid entry"},
+		{"parent", []tarEntry{{header: tar.Header{Name: "in/../../x.in"}}}, version, "invalid entry"},
+		{"symlink", []tarEntry{{header: tar.Header{Name: "in/a.in", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}}}, version, "unsupported entry type"},
+		{"hardlink", []tarEntry{{header: tar.Header{Name: "in/a.in", Typeflag: tar.TypeLink, Linkname: "out/a.out"}}}, version, "unsupported entry type"},
+		{"modified", append(valid[:1:1], tarEntry{header: tar.Header{Name: "in/a.in"}, content: "0\n"}, tarEntry{header: tar.Header{Name: "out/a.out"}, content: "2\n"}), version, "hash mismatch"},
+		{"missing", valid[:2], version, "missing file"},
+		{"extra", append(append([]tarEntry{}, valid...), tarEntry{header: tar.Header{Name: "in/b.in"}, content: "x"}), version, "not in manifest"},
+		{"other version", valid, "other", "manifest is of version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tarGzPath := writeTarGz(t, tt.entries)
+			parent := t.TempDir()
+			destDir := filepath.Join(parent, "cases")
+			err := extractTestCases(tarGzPath, filepath.Dir(destDir), destDir, tt.version, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("err = %v, want %q", err, tt.err)
+			}
+			if files, _ := os.ReadDir(parent); len(files) != 0 {
+				t.Errorf("files are left: %v", files)
+			}
+		})
+	}
+}
+
+func TestExtractTestCasesTruncated(t *testing.T) {
+	entries, version := testCaseEntries(t, map[string]string{"in/a.in": strings.Repeat("1 2\n", 1000)})
+	tarGzPath := writeTarGz(t, entries)
+	data, err := os.ReadFile(tarGzPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{len(data) / 2, len(data) - 4} {
+		if err := os.WriteFile(tarGzPath, data[:size], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		destDir := filepath.Join(t.TempDir(), "cases")
+		if err := extractTestCases(tarGzPath, filepath.Dir(destDir), destDir, version, nil); err == nil {
+			t.Errorf("truncated to %d bytes: no error", size)
+		}
+		if _, err := os.Stat(destDir); err == nil {
+			t.Errorf("truncated to %d bytes: dest dir is created", size)
+		}
+	}
+}
+
+func TestBuildTestCaseTarGz(t *testing.T) {
+	base := t.TempDir()
+	cases := map[string]string{"in/example_00.in": "1 2\n", "out/example_00.out": "3\n"}
+	manifest := map[string]string{}
+	for name, content := range cases {
+		if err := os.MkdirAll(filepath.Join(base, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(base, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		manifest[filepath.Base(name)] = sha256Hex(content)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "hash.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	version, err := testCaseHash(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tarGzPath, err := UploadTarget{Base: base}.BuildTestCaseTarGz()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(tarGzPath) }()
+	if err := extractTestCases(tarGzPath, t.TempDir(), filepath.Join(t.TempDir(), version), version, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"errors"
+	"os"
+)
+
+// errLocked is returned by a non-blocking lockFile if the lock is held.
+var errLocked = errors.New("file is locked")
+
+// fileLock is an advisory lock of a file, shared between processes.
+type fileLock struct {
+	f *os.File
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package storage
+
+// lockFile is a no-op without flock; the cache is not shared safely between
+// processes on such platforms.
+func lockFile(_ string, _, _ bool) (*fileLock, error) {
+	return &fileLock{}, nil
+}
+
+func (l *fileLock) unlock() error {
+	return nil
+}
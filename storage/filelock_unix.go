@@ -0,0 +1,70 @@
+//go:build unix
+
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock of the file at path, creating it if needed.
+// With block false, it returns errLocked instead of waiting for the lock.
+//
+// The file may be removed by its exclusive holder, so the lock is taken again
+// if the file got by it is no longer at path.
+func lockFile(path string, exclusive, block bool) (*fileLock, error) {
+	for {
+		l, err := lockFileOnce(path, exclusive, block)
+		if err != nil {
+			return nil, err
+		}
+		locked, err := l.f.Stat()
+		if err != nil {
+			_ = l.unlock()
+			return nil, err
+		}
+		current, err := os.Stat(path)
+		if err == nil && os.SameFile(locked, current) {
+			return l, nil
+		}
+		_ = l.unlock()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+}
+
+func lockFileOnce(path string, exclusive, block bool) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	if !block {
+		how |= syscall.LOCK_NB
+	}
+	for {
+		err = syscall.Flock(int(f.Fd()), how)
+		if !errors.Is(err, syscall.EINTR) {
+			break
+		}
+	}
+	if err != nil {
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errLocked
+		}
+		return nil, err
+	}
+	return &fileLock{f: f}, nil
+}
+
+func (l *fileLock) unlock() error {
+	// closing the file releases the lock
+	return l.f.Close()
+}
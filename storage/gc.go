@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Kinds of the versions which the objects of the buckets belong to.
+const (
+	objectKindTestCase = "testcase" // TestCaseVersion
+	objectKindFiles    = "files"    // Version (v3) or OverallVersion (v4)
+)
+
+// objectOwner is the problem version which an object belongs to.
+type objectOwner struct {
+	layout  string // "v3" or "v4"
+	problem string
+	kind    string
+	version string
+}
+
+// parseObjectKey returns the owner of the object of key. Content addressed
+// test case files have no owner and are reported by isObject.
+func parseObjectKey(key string) (owner objectOwner, isObject bool, ok bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 || parts[0] != "v3" && parts[0] != "v4" {
+		return objectOwner{}, false, false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return objectOwner{}, false, false
+		}
+	}
+	switch {
+	// v3/{problem}/testcase/{hash}.tar.gz, v3/{problem}/testcase/{hash}/{in,out}/...
+	case parts[0] == "v3" && len(parts) == 4 && parts[2] == "testcase" && strings.HasSuffix(parts[3], ".tar.gz"):
+		return objectOwner{"v3", parts[1], objectKindTestCase, strings.TrimSuffix(parts[3], ".tar.gz")}, false, true
+	case parts[0] == "v3" && len(parts) >= 5 && parts[2] == "testcase":
+		return objectOwner{"v3", parts[1], objectKindTestCase, parts[3]}, false, true
+	// v3/{problem}/files/{version}/...
+	case parts[0] == "v3" && len(parts) >= 5 && parts[2] == "files":
+		return objectOwner{"v3", parts[1], objectKindFiles, parts[3]}, false, true
+	// v4/testcase/{problem}/{hash}.tar.gz, v4/testcase/{problem}/{hash}.json
+	case parts[0] == "v4" && len(parts) == 4 && parts[1] == "testcase":
+		for _, ext := range []string{".tar.gz", ".json"} {
+			if version, found := strings.CutSuffix(parts[3], ext); found {
+				return objectOwner{"v4", parts[2], objectKindTestCase, version}, false, true
+			}
+		}
+	// v4/examples/{problem}/{hash}/...
+	case parts[0] == "v4" && len(parts) >= 5 && parts[1] == "examples":
+		return objectOwner{"v4", parts[2], objectKindTestCase, parts[3]}, false, true
+	// v4/files/{problem}/{overall_version}/...
+	case parts[0] == "v4" && len(parts) >= 5 && parts[1] == "files":
+		return objectOwner{"v4", parts[2], objectKindFiles, parts[3]}, false, true
+	// v4/objects/{sha256}
+	case parts[0] == "v4" && len(parts) == 3 && parts[1] == "objects" && validSHA256(parts[2]):
+		return objectOwner{}, true, true
+	}
+	return objectOwner{}, false, false
+}
+
+// KeptVersions are the problem versions whose objects the garbage collection
+// keeps.
+type KeptVersions struct {
+	removeV3 bool
+	versions map[objectOwner]bool
+}
+
+// NewKeptVersions returns an empty set. If removeV3, the v3 objects are
+// collected even if they are of a kept version; do it only once the consumers
+// read v4 and the audit reports no issues.
+func NewKeptVersions(removeV3 bool) *KeptVersions {
+	return &KeptVersions{removeV3: removeV3, versions: map[objectOwner]bool{}}
+}
+
+// AddProblem keeps the versions of p, e.g. the current ones.
+func (k *KeptVersions) AddProblem(p Problem) {
+	k.AddTestCases(p.Name, p.TestCaseVersion)
+	k.versions[objectOwner{"v3", p.Name, objectKindFiles, p.Version}] = true
+	k.versions[objectOwner{"v4", p.Name, objectKindFiles, p.OverallVersion}] = true
+}
+
+// AddTestCases keeps the test cases of version.
+func (k *KeptVersions) AddTestCases(problem, version string) {
+	k.versions[objectOwner{"v3", problem, objectKindTestCase, version}] = true
+	k.versions[objectOwner{"v4", problem, objectKindTestCase, version}] = true
+}
+
+func (k *KeptVersions) keeps(owner objectOwner) bool {
+	if owner.layout == "v3" && k.removeV3 {
+		return false
+	}
+	return k.versions[owner]
+}
+
+// StaleObject is an object which the garbage collection deletes.
+type StaleObject struct {
+	Public bool
+	ObjectAttrs
+}
+
+// GCReport is the result of FindStaleObjects.
+type GCReport struct {
+	Stale []StaleObject
+	// Kept is the number of the objects of kept versions
+	Kept int
+	// Recent is the number of the objects of other versions which are kept
+	// because they are updated in the grace period
+	Recent int
+	// Unknown is the number of the objects outside of the layout, which are
+	// never deleted
+	Unknown int
+}
+
+// StaleSize returns the total size of the stale objects.
+func (r GCReport) StaleSize() int64 {
+	size := int64(0)
+	for _, obj := range r.Stale {
+		size += obj.Size
+	}
+	return size
+}
+
+// FindStaleObjects lists the buckets and returns the objects which are not of
+// the kept versions and are updated before deadline. Objects whose update time
+// is unknown are kept. A content addressed test case file is kept while any
+// manifest in the bucket lists it, whether or not its version is kept, so the
+// files reused by an upload in progress survive until its manifest is
+// uploaded; the files of a stale manifest are collected by the next run.
+func (c Client) FindStaleObjects(ctx context.Context, kept *KeptVersions, deadline time.Time) (GCReport, error) {
+	listed := map[bool][]ObjectAttrs{}
+	for _, public := range []bool{false, true} {
+		bucket := c.bucket
+		if public {
+			bucket = c.publicBucket
+		}
+		objects, err := bucket.List(ctx, "")
+		if err != nil {
+			return GCReport{}, err
+		}
+		listed[public] = objects
+	}
+
+	keptObjects := map[string]bool{}
+	for _, obj := range listed[false] {
+		owner, _, ok := parseObjectKey(obj.Key)
+		if !ok || owner.layout != "v4" || owner.kind != objectKindTestCase || !strings.HasSuffix(obj.Key, ".json") {
+			continue
+		}
+		p := Problem{Name: owner.problem, TestCaseVersion: owner.version}
+		m, err := p.TestCaseManifest(ctx, c)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			// the objects listed in it would be deleted without it
+			return GCReport{}, fmt.Errorf("read %s: %w", obj.Key, err)
+		}
+		for _, tc := range m.Cases {
+			keptObjects[v4ObjectKey(tc.In.SHA256)] = true
+			keptObjects[v4ObjectKey(tc.Out.SHA256)] = true
+		}
+	}
+
+	report := GCReport{Stale: []StaleObject{}}
+	for _, public := range []bool{false, true} {
+		for _, obj := range listed[public] {
+			owner, isObject, ok := parseObjectKey(obj.Key)
+			switch {
+			case !ok:
+				slog.Debug("Unknown object", "key", obj.Key)
+				report.Unknown++
+			case isObject && keptObjects[obj.Key] || !isObject && kept.keeps(owner):
+				report.Kept++
+			case obj.Updated.IsZero() || !obj.Updated.Before(deadline):
+				report.Recent++
+			default:
+				report.Stale = append(report.Stale, StaleObject{Public: public, ObjectAttrs: obj})
+			}
+		}
+	}
+	return report, nil
+}
+
+// DeleteObjects deletes the objects found by FindStaleObjects.
+func (c Client) DeleteObjects(ctx context.Context, objects []StaleObject) error {
+	for _, obj := range objects {
+		bucket := c.bucket
+		if obj.Public {
+			bucket = c.publicBucket
+		}
+		if err := bucket.Delete(ctx, obj.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseObjectKey(t *testing.T) {
+	hash := sha256Hex("x")
+	for key, want := range map[string]objectOwner{
+		"v3/aplusb/testcase/h.tar.gz":           {"v3", "aplusb", objectKindTestCase, "h"},
+		"v3/aplusb/testcase/h/in/example_00.in": {"v3", "aplusb", objectKindTestCase, "h"},
+		"v3/aplusb/files/v/task.md":             {"v3", "aplusb", objectKindFiles, "v"},
+		"v4/testcase/aplusb/h.tar.gz":           {"v4", "aplusb", objectKindTestCase, "h"},
+		"v4/testcase/aplusb/h.json":             {"v4", "aplusb", objectKindTestCase, "h"},
+		"v4/examples/aplusb/h/in/example_00.in": {"v4", "aplusb", objectKindTestCase, "h"},
+		"v4/files/aplusb/ov/common/fastio.h":    {"v4", "aplusb", objectKindFiles, "ov"},
+	} {
+		owner, isObject, ok := parseObjectKey(key)
+		if !ok || isObject || owner != want {
+			t.Errorf("parseObjectKey(%q) = %v, %v, %v, want %v", key, owner, isObject, ok, want)
+		}
+	}
+	if _, isObject, ok := parseObjectKey("v4/objects/" + hash); !ok || !isObject {
+		t.Errorf("object is not parsed: %v, %v", isObject, ok)
+	}
+	for _, key := range []string{"categories.json", "v4/objects/x", "v4/testcase/aplusb/h.zip", "v4/files/aplusb/ov", "v5/testcase/aplusb/h.tar.gz", "v4/files//ov/a"} {
+		if _, _, ok := parseObjectKey(key); ok {
+			t.Errorf("parseObjectKey(%q) is ok", key)
+		}
+	}
+}
+
+func TestFindStaleObjects(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public")))
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+
+	current := writeProblemDir(t, manifestCases)
+	current.Problem.Version = "v2"
+	current.Problem.OverallVersion = "ov2"
+	if err := current.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	m, err := current.Problem.TestCaseManifest(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := v4ObjectKey(m.Cases[0].In.SHA256)
+
+	touch := func(bucketDir, key string, updated time.Time) {
+		t.Helper()
+		if err := os.Chtimes(filepath.Join(dir, bucketDir, filepath.FromSlash(key)), updated, updated); err != nil {
+			t.Fatal(err)
+		}
+	}
+	put := func(bucket Bucket, bucketDir, key string, updated time.Time) {
+		t.Helper()
+		if err := bucket.Put(ctx, key, strings.NewReader(key)); err != nil {
+			t.Fatal(err)
+		}
+		touch(bucketDir, key, updated)
+	}
+	// uploaded long ago, and reused by the current version
+	touch("private", shared, old)
+	for _, key := range []string{
+		"v4/testcase/aplusb/old.tar.gz",
+		"v4/testcase/aplusb/judged.tar.gz",
+		"v4/objects/" + sha256Hex("old"),
+		"v3/aplusb/testcase/old.tar.gz",
+	} {
+		put(client.Bucket(), "private", key, old)
+	}
+	put(client.Bucket(), "private", "v4/testcase/aplusb/uploading.tar.gz", now)
+	// a stale manifest keeps its files until it is deleted
+	prevObject := v4ObjectKey(sha256Hex("prev"))
+	put(client.Bucket(), "private", prevObject, old)
+	prev, err := json.Marshal(TestCaseManifest{Problem: "aplusb", TestCaseVersion: "prev", Cases: []TestCaseInfo{
+		{Name: "example_00", In: TestCaseFile{SHA256: sha256Hex("prev")}, Out: TestCaseFile{SHA256: sha256Hex("prev")}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Bucket().Put(ctx, "v4/testcase/aplusb/prev.json", bytes.NewReader(prev)); err != nil {
+		t.Fatal(err)
+	}
+	touch("private", "v4/testcase/aplusb/prev.json", old)
+	put(client.Bucket(), "private", "README", old)
+	for _, key := range []string{
+		"v4/examples/aplusb/old/in/example_00.in",
+		"v4/files/aplusb/ov1/aplusb/task.md",
+		"v4/files/aplusb/ov2/aplusb/task.md",
+		"v3/aplusb/files/v2/task.md",
+		"v4/files/removed/ov/removed/task.md",
+	} {
+		put(client.PublicBucket(), "public", key, old)
+	}
+
+	kept := NewKeptVersions(false)
+	kept.AddProblem(current.Problem)
+	kept.AddTestCases("aplusb", "judged")
+	report, err := client.FindStaleObjects(ctx, kept, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := []string{}
+	for _, obj := range report.Stale {
+		stale = append(stale, obj.Key)
+	}
+	sort.Strings(stale)
+	want := []string{
+		"v3/aplusb/testcase/old.tar.gz",
+		"v4/examples/aplusb/old/in/example_00.in",
+		"v4/files/aplusb/ov1/aplusb/task.md",
+		"v4/files/removed/ov/removed/task.md",
+		"v4/objects/" + sha256Hex("old"),
+		"v4/testcase/aplusb/old.tar.gz",
+		"v4/testcase/aplusb/prev.json",
+	}
+	if !reflect.DeepEqual(stale, want) {
+		t.Errorf("stale = %v, want %v", stale, want)
+	}
+	if report.Recent != 1 || report.Unknown != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.StaleSize() == 0 {
+		t.Error("StaleSize is 0")
+	}
+
+	// the v3 objects of the current versions are deleted only on request
+	removeV3 := NewKeptVersions(true)
+	removeV3.AddProblem(current.Problem)
+	removeV3.AddTestCases("aplusb", "judged")
+	v3Report, err := client.FindStaleObjects(ctx, removeV3, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v3Report.Stale) != len(report.Stale)+1 {
+		t.Errorf("stale with removeV3 = %v", v3Report.Stale)
+	}
+
+	if err := client.DeleteObjects(ctx, report.Stale); err != nil {
+		t.Fatal(err)
+	}
+	report, err = client.FindStaleObjects(ctx, kept, now.Add(-7*24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Stale) != 1 || report.Stale[0].Key != prevObject {
+		t.Errorf("stale after delete = %v", report.Stale)
+	}
+	// the current version is intact
+	downloader, err := NewTestCaseDownloader(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+	files, err := downloader.Fetch(current.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// testCaseManifestFileName is the copy of the manifest kept in the extracted dir.
+const testCaseManifestFileName = "manifest.json"
+
+// TestCaseManifest describes the test cases of a version. It is uploaded next
+// to the tarball as v4/testcase/{problem}/{testcase_hash}.json.
+type TestCaseManifest struct {
+	Problem         string         `json:"problem"`
+	TestCaseVersion string         `json:"testcase_version"`
+	Title           string         `json:"title"`
+	TimeLimit       float64        `json:"time_limit"`
+	Cases           []TestCaseInfo `json:"cases"`
+}
+
+// TestCaseInfo is a test case of TestCaseManifest.
+type TestCaseInfo struct {
+	Name string `json:"name"`
+	// Generator is the name in info.toml, e.g. "random.cpp"
+	Generator string       `json:"generator,omitempty"`
+	In        TestCaseFile `json:"in"`
+	Out       TestCaseFile `json:"out"`
+}
+
+type TestCaseFile struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// PrivateReader reads objects of the private bucket.
+type PrivateReader interface {
+	ReadPrivate(ctx context.Context, key string) ([]byte, error)
+}
+
+var _ PrivateReader = Client{}
+
+func (c Client) ReadPrivate(ctx context.Context, key string) ([]byte, error) {
+	reader, err := c.bucket.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+// TestCaseManifest returns the manifest of the test cases of the problem. It
+// returns ErrNotFound for versions uploaded before manifests were added.
+func (p Problem) TestCaseManifest(ctx context.Context, r PrivateReader) (TestCaseManifest, error) {
+	data, err := r.ReadPrivate(ctx, p.v4TestCaseManifestKey())
+	if err != nil {
+		return TestCaseManifest{}, err
+	}
+	m := TestCaseManifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return TestCaseManifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.TestCaseVersion != p.TestCaseVersion {
+		return TestCaseManifest{}, fmt.Errorf("manifest is of version %s, want %s", m.TestCaseVersion, p.TestCaseVersion)
+	}
+	return m, nil
+}
+
+func (p Problem) UploadTestCaseManifest(ctx context.Context, c Client, m TestCaseManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	remoteURL := p.v4TestCaseManifestKey()
+	slog.Info("Upload test case manifest", "remote", remoteURL)
+	return c.bucket.Put(ctx, remoteURL, bytes.NewReader(data))
+}
+
+// BuildTestCaseManifest builds the manifest of the files in the tarball of
+// BuildTestCaseTarGz. It fails if they do not match TestCaseVersion, i.e.
+// hash.json is outdated.
+func (p UploadTarget) BuildTestCaseManifest() (TestCaseManifest, error) {
+	info, err := ParseInfo(path.Join(p.Base, "info.toml"))
+	if err != nil {
+		return TestCaseManifest{}, err
+	}
+	generators := map[string]string{}
+	for _, test := range info.Tests {
+		for i := 0; i < test.Number; i++ {
+			generators[fmt.Sprintf("%v_%02d", strings.Split(test.Name, ".")[0], i)] = test.Name
+		}
+	}
+
+	files := map[string]TestCaseFile{}
+	for _, ext := range []string{"in", "out"} {
+		if err := filepath.WalkDir(path.Join(p.Base, ext), func(fpath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || path.Ext(fpath) != "."+ext {
+				return nil
+			}
+			f, err := testCaseFile(fpath)
+			if err != nil {
+				return err
+			}
+			files[ext+"/"+filepath.Base(fpath)] = f
+			return nil
+		}); err != nil {
+			return TestCaseManifest{}, err
+		}
+	}
+
+	m := TestCaseManifest{
+		Problem:         p.Problem.Name,
+		TestCaseVersion: p.Problem.TestCaseVersion,
+		Title:           info.Title,
+		TimeLimit:       info.TimeLimit,
+		Cases:           []TestCaseInfo{},
+	}
+	for key, in := range files {
+		name, ok := strings.CutPrefix(key, "in/")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSuffix(name, ".in")
+		out, ok := files["out/"+name+".out"]
+		if !ok {
+			return TestCaseManifest{}, fmt.Errorf("%s.out not found", name)
+		}
+		m.Cases = append(m.Cases, TestCaseInfo{Name: name, Generator: generators[name], In: in, Out: out})
+	}
+	if len(m.Cases)*2 != len(files) {
+		return TestCaseManifest{}, fmt.Errorf("some .out files have no .in file")
+	}
+	sort.Slice(m.Cases, func(i, j int) bool { return m.Cases[i].Name < m.Cases[j].Name })
+	if v := m.version(); v != p.Problem.TestCaseVersion {
+		return TestCaseManifest{}, fmt.Errorf("test cases do not match hash.json: %s, want %s", v, p.Problem.TestCaseVersion)
+	}
+	return m, nil
+}
+
+func testCaseFile(fpath string) (TestCaseFile, error) {
+	stat, err := os.Stat(fpath)
+	if err != nil {
+		return TestCaseFile{}, err
+	}
+	h, err := fileHash(fpath)
+	if err != nil {
+		return TestCaseFile{}, err
+	}
+	return TestCaseFile{Size: stat.Size(), SHA256: h}, nil
+}
+
+// version returns the TestCaseVersion computed from the hashes of the files,
+// as testCaseHash does from hash.json.
+func (m TestCaseManifest) version() string {
+	hashes := []string{}
+	for _, c := range m.Cases {
+		hashes = append(hashes, c.In.SHA256, c.Out.SHA256)
+	}
+	return joinHashes(hashes)
+}
+
+// TotalSize returns the total size of the input and output files.
+func (m TestCaseManifest) TotalSize() int64 {
+	size := int64(0)
+	for _, c := range m.Cases {
+		size += c.In.Size + c.Out.Size
+	}
+	return size
+}
+
+// verify checks that the files extracted into dir, whose hashes are given,
+// are exactly the cases of the manifest.
+func (m TestCaseManifest) verify(dir string, hashes map[string]string, version string) error {
+	if m.TestCaseVersion != version {
+		return fmt.Errorf("manifest is of version %s, want %s", m.TestCaseVersion, version)
+	}
+	if v := m.version(); v != version {
+		return fmt.Errorf("manifest hashes are of version %s, want %s", v, version)
+	}
+	expected := map[string]TestCaseFile{}
+	for _, c := range m.Cases {
+		expected["in/"+c.Name+".in"] = c.In
+		expected["out/"+c.Name+".out"] = c.Out
+	}
+	for rel, want := range expected {
+		h, ok := hashes[rel]
+		if !ok {
+			return fmt.Errorf("missing file: %s", rel)
+		}
+		stat, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		if h != want.SHA256 || stat.Size() != want.Size {
+			return fmt.Errorf("file does not match manifest: %s", rel)
+		}
+	}
+	for rel := range hashes {
+		if _, ok := expected[rel]; !ok && rel != testCaseManifestName && rel != testCaseManifestFileName {
+			return fmt.Errorf("file not in manifest: %s", rel)
+		}
+	}
+	return nil
+}
+
+// readExtractedManifest reads the copy of the manifest in dir, if any.
+func readExtractedManifest(dir string) (*TestCaseManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, testCaseManifestFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := TestCaseManifest{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeProblemDir writes a problem dir with info.toml, hash.json and cases.
+func writeProblemDir(t *testing.T, cases map[string]string) UploadTarget {
+	t.Helper()
+	base := filepath.Join(t.TempDir(), "aplusb")
+	writeFiles(t, base, problemFiles(t, cases))
+	version, err := testCaseHash(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return UploadTarget{Base: base, Problem: Problem{Name: "aplusb", OverallVersion: "ov", TestCaseVersion: version}}
+}
+
+// problemFiles returns the files of a problem dir: info.toml, hash.json and cases.
+func problemFiles(t *testing.T, cases map[string]string) map[string]string {
+	t.Helper()
+	files := map[string]string{
+		"info.toml": "title = 'A + B'\ntimelimit = 2.0\n[[tests]]\n    name = \"example.in\"\n    number = 1\n[[tests]]\n    name = \"random.cpp\"\n    number = 1\n",
+	}
+	manifest := map[string]string{}
+	for name, content := range cases {
+		files[name] = content
+		manifest[filepath.Base(name)] = sha256Hex(content)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["hash.json"] = string(data)
+	return files
+}
+
+// writeFiles writes files, keyed by slash separated paths, into dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+var manifestCases = map[string]string{
+	"in/example_00.in":   "1 2\n",
+	"out/example_00.out": "3\n",
+	"in/random_00.in":    "10 20\n",
+	"out/random_00.out":  "30\n",
+}
+
+func TestBuildTestCaseManifest(t *testing.T) {
+	target := writeProblemDir(t, manifestCases)
+	m, err := target.BuildTestCaseManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TestCaseManifest{
+		Problem:         "aplusb",
+		TestCaseVersion: target.Problem.TestCaseVersion,
+		Title:           "A + B",
+		TimeLimit:       2.0,
+		Cases: []TestCaseInfo{
+			{Name: "example_00", Generator: "example.in", In: TestCaseFile{Size: 4, SHA256: sha256Hex("1 2\n")}, Out: TestCaseFile{Size: 2, SHA256: sha256Hex("3\n")}},
+			{Name: "random_00", Generator: "random.cpp", In: TestCaseFile{Size: 6, SHA256: sha256Hex("10 20\n")}, Out: TestCaseFile{Size: 3, SHA256: sha256Hex("30\n")}},
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("manifest = %+v, want %+v", m, want)
+	}
+	if m.TotalSize() != 15 {
+		t.Errorf("TotalSize = %d", m.TotalSize())
+	}
+
+	// hash.json is outdated
+	if err := os.WriteFile(filepath.Join(target.Base, "out", "random_00.out"), []byte("31\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := target.BuildTestCaseManifest(); err == nil || !strings.Contains(err.Error(), "hash.json") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestUploadAndFetchWithManifest(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public")))
+	target := writeProblemDir(t, manifestCases)
+	if err := target.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := target.Problem.TestCaseManifest(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Cases) != 2 {
+		t.Fatalf("manifest = %+v", m)
+	}
+
+	downloader, err := NewTestCaseDownloaderWithConfig(client, DownloaderConfig{CacheDir: filepath.Join(dir, "cache")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := downloader.Fetch(target.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+	if err := downloader.Close(); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := readExtractedManifest(files.TestCases)
+	if err != nil || cached == nil || !reflect.DeepEqual(*cached, m) {
+		t.Fatalf("cached manifest = %v, %v", cached, err)
+	}
+
+	// the cache is validated with the manifest on startup
+	if err := os.Truncate(files.InFilePath("random_00"), 0); err != nil {
+		t.Fatal(err)
+	}
+	downloader, err = NewTestCaseDownloaderWithConfig(client, DownloaderConfig{CacheDir: filepath.Join(dir, "cache")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+	if _, err := os.Stat(files.TestCases); err == nil {
+		t.Error("invalid cache entry is kept")
+	}
+
+	// an uploaded manifest not matching the tarball is rejected
+	m.Cases[0].In.Size++
+	if err := target.Problem.UploadTestCaseManifest(ctx, client, m); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := downloader.Fetch(target.Problem); err == nil || !strings.Contains(err.Error(), "does not match manifest") {
+		t.Errorf("err = %v", err)
+	}
+
+	// versions uploaded before manifests were added
+	other := target.Problem
+	other.TestCaseVersion = "other"
+	if _, err := other.TestCaseManifest(ctx, client); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// v4ObjectKeyPrefix is the prefix of the test case files stored by content.
+// The objects are shared by all problems and versions, and the manifest of a
+// version lists the objects of its files.
+const v4ObjectKeyPrefix = "v4/objects/"
+
+func v4ObjectKey(hash string) string {
+	return v4ObjectKeyPrefix + hash
+}
+
+// UploadTestCaseObjects uploads the files of the manifest as objects, skipping
+// the ones already uploaded, e.g. by a previous version. Only the objects of
+// the manifest are checked, so the cost does not grow with the bucket.
+func (p UploadTarget) UploadTestCaseObjects(client Client, m TestCaseManifest) error {
+	ctx := context.Background()
+	locals := map[string]string{}
+	sizes := map[string]int64{}
+	for _, c := range m.Cases {
+		for _, ext := range []string{"in", "out"} {
+			file := c.In
+			if ext == "out" {
+				file = c.Out
+			}
+			key := v4ObjectKey(file.SHA256)
+			if _, ok := locals[key]; !ok {
+				locals[key] = path.Join(p.Base, ext, c.Name+"."+ext)
+				sizes[key] = file.Size
+			}
+		}
+	}
+	missing, err := client.missingKeys(ctx, client.bucket, sortedKeys(locals))
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{}
+	size := int64(0)
+	for key := range missing {
+		local := locals[key]
+		// an object must never have other content than its key says
+		if h, err := fileHash(local); err != nil {
+			return err
+		} else if v4ObjectKey(h) != key {
+			return fmt.Errorf("%s is modified after the manifest is built", local)
+		}
+		files[key] = local
+		size += sizes[key]
+	}
+	if err := client.uploadFiles(ctx, client.bucket, files); err != nil {
+		return err
+	}
+	slog.Info("Upload test case objects", "name", p.Problem.Name, "uploaded", len(files), "size", size, "skipped", 2*len(m.Cases)-len(files))
+	return nil
+}
+
+// fetchTestCaseObjects builds the test cases of the manifest from the objects
+// in destDir, downloading only the files missing in the blob cache. It returns
+// ErrNotFound if some object is not uploaded.
+func (t TestCaseDownloader) fetchTestCaseObjects(ctx context.Context, problem Problem, m TestCaseManifest, workDir, destDir string) error {
+	if v := m.version(); v != problem.TestCaseVersion {
+		return fmt.Errorf("manifest hashes are of version %s, want %s", v, problem.TestCaseVersion)
+	}
+	tmpDir, err := os.MkdirTemp(workDir, "objects-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	for _, ext := range []string{"in", "out"} {
+		if err := os.Mkdir(filepath.Join(tmpDir, ext), 0o755); err != nil {
+			return err
+		}
+	}
+
+	count, size, cached := 0, int64(0), 0
+	for _, c := range m.Cases {
+		if !validCacheVersion(c.Name) {
+			return fmt.Errorf("invalid test case name: %q", c.Name)
+		}
+		for _, ext := range []string{"in", "out"} {
+			file := c.In
+			if ext == "out" {
+				file = c.Out
+			}
+			downloaded, err := t.linkObject(ctx, workDir, file, filepath.Join(tmpDir, ext, c.Name+"."+ext))
+			if err != nil {
+				return err
+			}
+			if downloaded {
+				count++
+				size += file.Size
+			} else {
+				cached++
+			}
+		}
+	}
+	slog.Info("Download test case objects", "name", problem.Name, "downloaded", count, "size", size, "cached", cached)
+
+	// keep the manifest to validate the cache and to find the linked blobs
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, testCaseManifestFileName), data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, destDir); err != nil {
+		if _, statErr := os.Stat(destDir); statErr == nil {
+			// built concurrently by another process
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// linkObject links the blob of file to dest, downloading it unless cached.
+func (t TestCaseDownloader) linkObject(ctx context.Context, workDir string, file TestCaseFile, dest string) (bool, error) {
+	for retry := 0; ; retry++ {
+		downloaded := false
+		blob, err := t.cache.blob(workDir, file.SHA256, func(tmpPath string) error {
+			downloaded = true
+			return downloadObject(ctx, t.client.bucket, file, tmpPath)
+		})
+		if err != nil {
+			return false, err
+		}
+		err = linkFile(blob, dest)
+		if errors.Is(err, fs.ErrNotExist) && retry == 0 {
+			// removed by the eviction of another process in between
+			continue
+		}
+		return downloaded, err
+	}
+}
+
+// downloadObject downloads the object of file to destPath and verifies it.
+func downloadObject(ctx context.Context, bucket Bucket, file TestCaseFile, destPath string) (err error) {
+	reader, err := bucket.Get(ctx, v4ObjectKey(file.SHA256))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dest.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dest, h), reader)
+	if err != nil {
+		return err
+	}
+	if n != file.Size {
+		return fmt.Errorf("object %s does not match manifest: %d bytes, want %d", file.SHA256, n, file.Size)
+	}
+	if fmt.Sprintf("%x", h.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("object %s does not match its hash", file.SHA256)
+	}
+	return nil
+}
+
+// linkFile hard links src to dest, or copies it if links are not supported.
+func linkFile(src, dest string) error {
+	err := os.Link(src, dest)
+	if err == nil || errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	slog.Debug("Failed to link, copy instead", "src", src, "err", err)
+	return copyFile(src, dest)
+}
+
+func copyFile(src, dest string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// countingBucket counts the objects got and put, and the lists.
+type countingBucket struct {
+	Bucket
+	mu    sync.Mutex
+	gets  map[string]int
+	puts  map[string]int
+	lists int
+}
+
+func newCountingBucket(b Bucket) *countingBucket {
+	return &countingBucket{Bucket: b, gets: map[string]int{}, puts: map[string]int{}}
+}
+
+func (b *countingBucket) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	b.mu.Lock()
+	b.gets[key]++
+	b.mu.Unlock()
+	return b.Bucket.Get(ctx, key)
+}
+
+func (b *countingBucket) Put(ctx context.Context, key string, r io.ReadSeeker) error {
+	b.mu.Lock()
+	b.puts[key]++
+	b.mu.Unlock()
+	return b.Bucket.Put(ctx, key, r)
+}
+
+func (b *countingBucket) List(ctx context.Context, prefix string) ([]ObjectAttrs, error) {
+	b.mu.Lock()
+	b.lists++
+	b.mu.Unlock()
+	return b.Bucket.List(ctx, prefix)
+}
+
+// objectCounts returns the number of the objects in counts and resets it.
+func (b *countingBucket) objectCounts(counts map[string]int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for key := range counts {
+		if strings.HasPrefix(key, v4ObjectKeyPrefix) {
+			n++
+		}
+		delete(counts, key)
+	}
+	return n
+}
+
+func TestTestCaseObjectsTransferOnlyChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	private := newCountingBucket(NewLocalBucket(filepath.Join(dir, "private")))
+	client := NewClient(private, NewLocalBucket(filepath.Join(dir, "public")))
+	downloader, err := NewTestCaseDownloaderWithConfig(client, DownloaderConfig{CacheDir: filepath.Join(dir, "cache")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+
+	v1 := writeProblemDir(t, manifestCases)
+	if err := v1.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	if n := private.objectCounts(private.puts); n != 4 {
+		t.Errorf("uploaded %d objects, want 4", n)
+	}
+	files, err := downloader.Fetch(v1.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+	if n := private.objectCounts(private.gets); n != 4 {
+		t.Errorf("downloaded %d objects, want 4", n)
+	}
+	if _, ok := private.gets[v1.Problem.v4TestCasesKey()]; ok {
+		t.Error("tarball is downloaded")
+	}
+
+	// change one case
+	cases := map[string]string{}
+	for k, v := range manifestCases {
+		cases[k] = v
+	}
+	cases["out/random_00.out"] = "31\n"
+	v2 := writeProblemDir(t, cases)
+	if err := v2.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := private.puts[v2.Problem.v4TestCasesKey()]; ok {
+		t.Error("tarball is uploaded")
+	}
+	if private.lists != 0 {
+		t.Errorf("the bucket is listed %d times", private.lists)
+	}
+	if n := private.objectCounts(private.puts); n != 1 {
+		t.Errorf("uploaded %d objects, want 1", n)
+	}
+	files, err = downloader.Fetch(v2.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer files.Release()
+	if n := private.objectCounts(private.gets); n != 1 {
+		t.Errorf("downloaded %d objects, want 1", n)
+	}
+	for path, want := range map[string]string{files.InFilePath("random_00"): "10 20\n", files.OutFilePath("random_00"): "31\n"} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+	if err := verifyTestCaseDir(files.TestCases, v2.Problem.TestCaseVersion); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTestCaseObjectsFallBackToTarball(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public"))).
+		WithUploadOptions(UploadOptions{TestCaseTarball: true})
+	target := writeProblemDir(t, manifestCases)
+	if err := target.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	// versions uploaded before the objects were added
+	m, err := target.Problem.TestCaseManifest(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Bucket().Delete(ctx, v4ObjectKey(m.Cases[1].Out.SHA256)); err != nil {
+		t.Fatal(err)
+	}
+
+	downloader, err := NewTestCaseDownloader(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = downloader.Close() }()
+	files, err := downloader.Fetch(target.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer files.Release()
+	if got, err := os.ReadFile(files.OutFilePath("random_00")); err != nil || string(got) != "30\n" {
+		t.Errorf("random_00.out = %q, %v", got, err)
+	}
+}
+
+func TestDiskCacheRemovesUnlinkedBlobs(t *testing.T) {
+	dir := t.TempDir()
+	client := NewClient(NewLocalBucket(filepath.Join(dir, "private")), NewLocalBucket(filepath.Join(dir, "public")))
+	target := writeProblemDir(t, manifestCases)
+	if err := target.UploadTestcases(client); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(dir, "cache")
+	downloader, err := NewTestCaseDownloaderWithConfig(client, DownloaderConfig{CacheDir: cacheDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := downloader.Fetch(target.Problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files.Release()
+	if err := downloader.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(cachedVersions(t, cacheDir, cacheBlobDir)); n != 4 {
+		t.Fatalf("%d blobs, want 4", n)
+	}
+
+	// a stray blob is removed, and the linked ones are kept
+	if err := os.WriteFile(filepath.Join(cacheDir, cacheBlobDir, sha256Hex("stray")), []byte("stray"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := openDiskCache(cacheDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(cachedVersions(t, cacheDir, cacheBlobDir)); n != 4 {
+		t.Errorf("%d blobs, want 4", n)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the blobs go with the last entry linking them
+	if err := os.RemoveAll(files.TestCases); err != nil {
+		t.Fatal(err)
+	}
+	c, err = openDiskCache(cacheDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+	if n := len(cachedVersions(t, cacheDir, cacheBlobDir)); n != 0 {
+		t.Errorf("%d blobs, want 0", n)
+	}
+}
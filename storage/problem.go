@@ -16,19 +16,21 @@ type Problem struct {
 	TestCaseVersion string
 }
 
+// Deprecated: nothing reads the v3 layout any more; use UploadTestCasesV4.
 func (p Problem) UploadTestCases(ctx context.Context, c Client, tarGzPath string) error {
 	remoteURL := p.testCasesKey()
 	slog.Info("Upload test cases", "remote", remoteURL)
-	return c.uploadFile(ctx, c.bucket, remoteURL, tarGzPath)
+	return c.upload(ctx, c.bucket, remoteURL, tarGzPath)
 }
 
 // UploadTestCasesV4 uploads testcases tarball also to v4 private path for Phase 1 dual-write.
 func (p Problem) UploadTestCasesV4(ctx context.Context, c Client, tarGzPath string) error {
 	remoteURL := p.v4TestCasesKey()
 	slog.Info("Upload test cases (v4)", "remote", remoteURL)
-	return c.uploadFile(ctx, c.bucket, remoteURL, tarGzPath)
+	return c.upload(ctx, c.bucket, remoteURL, tarGzPath)
 }
 
+// Deprecated: nothing reads the v3 layout any more; use UploadPublicFileTo.
 func (p Problem) UploadPublicFile(ctx context.Context, c Client, localPath, key string) error {
 	return p.uploadAsPublic(ctx, c, localPath, p.publicFileKey(key))
 }
@@ -39,6 +41,7 @@ func (p Problem) UploadPublicFileTo(ctx context.Context, c Client, localPath, re
 	return p.uploadAsPublic(ctx, c, localPath, remoteURL)
 }
 
+// Deprecated: nothing reads the v3 layout any more; use UploadPublicTestCaseV4.
 func (p Problem) UploadPublicTestCase(ctx context.Context, c Client, localPath, key string) error {
 	return p.uploadAsPublic(ctx, c, localPath, p.publicTestCaseKey(key))
 }
@@ -50,7 +53,7 @@ func (p Problem) UploadPublicTestCaseV4(ctx context.Context, c Client, localPath
 
 func (p Problem) uploadAsPublic(ctx context.Context, c Client, localPath, remoteURL string) error {
 	slog.Info("Upload public file", "local", localPath, "remote", remoteURL)
-	return c.uploadFile(ctx, c.publicBucket, remoteURL, localPath)
+	return c.upload(ctx, c.publicBucket, remoteURL, localPath)
 }
 
 func (p Problem) testCasesKey() string {
@@ -78,6 +81,10 @@ func (p Problem) v4TestCasesKey() string {
 	return fmt.Sprintf("v4/testcase/%s/%s.tar.gz", p.Name, p.TestCaseVersion)
 }
 
+func (p Problem) v4TestCaseManifestKey() string {
+	return fmt.Sprintf("v4/testcase/%s/%s.json", p.Name, p.TestCaseVersion)
+}
+
 func (p Problem) v4ExamplesKey(key string) string {
 	return fmt.Sprintf("v4/examples/%s/%s/%s", p.Name, p.TestCaseVersion, key)
 }
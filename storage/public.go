@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned when the object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// PublicObject is an object of the public bucket.
+type PublicObject struct {
+	Key  string
+	Size int64
+}
+
+// PublicReader reads objects of the public bucket.
+type PublicReader interface {
+	ReadPublic(ctx context.Context, key string) ([]byte, error)
+	ListPublic(ctx context.Context, prefix string) ([]PublicObject, error)
+}
+
+var _ PublicReader = Client{}
+
+func (c Client) ReadPublic(ctx context.Context, key string) ([]byte, error) {
+	reader, err := c.publicBucket.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+// ListPublic returns the objects under prefix, sorted by key.
+func (c Client) ListPublic(ctx context.Context, prefix string) ([]PublicObject, error) {
+	attrs, err := c.publicBucket.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	objects := []PublicObject{}
+	for _, a := range attrs {
+		objects = append(objects, PublicObject{Key: a.Key, Size: a.Size})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Example is an example test case shown in the statement.
+type Example struct {
+	Name string
+	In   []byte
+	Out  []byte
+}
+
+// PublicFile is a file of the v4 public files. Path is relative to the
+// problem dir, or starts with "common/".
+type PublicFile struct {
+	Path string
+	Size int64
+}
+
+// Statement returns task.md of the problem.
+func (p Problem) Statement(ctx context.Context, r PublicReader) ([]byte, error) {
+	return r.ReadPublic(ctx, p.v4FilesProblemKey("task.md"))
+}
+
+// Examples returns the examples of the problem, sorted by name.
+func (p Problem) Examples(ctx context.Context, r PublicReader) ([]Example, error) {
+	inPrefix := p.v4ExamplesKey("in/")
+	objects, err := r.ListPublic(ctx, inPrefix)
+	if err != nil {
+		return nil, err
+	}
+	examples := []Example{}
+	for _, obj := range objects {
+		name := strings.TrimSuffix(strings.TrimPrefix(obj.Key, inPrefix), ".in")
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		in, err := r.ReadPublic(ctx, obj.Key)
+		if err != nil {
+			return nil, err
+		}
+		out, err := r.ReadPublic(ctx, p.v4ExamplesKey(path.Join("out", name+".out")))
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, Example{Name: name, In: in, Out: out})
+	}
+	return examples, nil
+}
+
+// PublicFiles returns the public files of the problem, with the same layout
+// as ProblemFiles.PublicFiles of the downloader.
+func (p Problem) PublicFiles(ctx context.Context, r PublicReader) ([]PublicFile, error) {
+	prefix := p.v4PublicFilesKeyPrefix() + "/"
+	objects, err := r.ListPublic(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	files := []PublicFile{}
+	for _, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, prefix)
+		rel = strings.TrimPrefix(rel, p.Name+"/")
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			continue
+		}
+		files = append(files, PublicFile{Path: rel, Size: obj.Size})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// PublicFile returns the content of the public file at rel (see PublicFiles).
+func (p Problem) PublicFile(ctx context.Context, r PublicReader, rel string) ([]byte, error) {
+	rel = path.Clean("/" + rel)[1:]
+	if rel == "" {
+		return nil, ErrNotFound
+	}
+	if strings.HasPrefix(rel, "common/") {
+		return r.ReadPublic(ctx, p.v4FilesCommonKey(rel))
+	}
+	return r.ReadPublic(ctx, p.v4FilesProblemKey(rel))
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryReader is a PublicReader and PrivateReader backed by a map, counting
+// the calls.
+type memoryReader struct {
+	objects map[string]string
+	reads   int
+	lists   int
+}
+
+func (m *memoryReader) ReadPublic(_ context.Context, key string) ([]byte, error) {
+	m.reads++
+	v, ok := m.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return []byte(v), nil
+}
+
+func (m *memoryReader) ReadPrivate(ctx context.Context, key string) ([]byte, error) {
+	return m.ReadPublic(ctx, key)
+}
+
+func (m *memoryReader) ListPublic(_ context.Context, prefix string) ([]PublicObject, error) {
+	m.lists++
+	objects := []PublicObject{}
+	for k, v := range m.objects {
+		if strings.HasPrefix(k, prefix) {
+			objects = append(objects, PublicObject{Key: k, Size: int64(len(v))})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func TestProblemPublicContents(t *testing.T) {
+	p := Problem{Name: "aplusb", OverallVersion: "ov", TestCaseVersion: "tv"}
+	r := &memoryReader{objects: map[string]string{
+		"v4/files/aplusb/ov/aplusb/task.md":           "# A + B",
+		"v4/files/aplusb/ov/aplusb/grader/solve.hpp":  "int solve();",
+		"v4/files/aplusb/ov/common/fastio.h":          "// fastio",
+		"v4/files/aplusb/ov2/aplusb/task.md":          "# other version",
+		"v4/examples/aplusb/tv/in/example_01.in":      "3 4",
+		"v4/examples/aplusb/tv/out/example_01.out":    "7",
+		"v4/examples/aplusb/tv/in/example_00.in":      "1 2",
+		"v4/examples/aplusb/tv/out/example_00.out":    "3",
+		"v4/examples/aplusb/other/in/example_00.in":   "0 0",
+		"v4/examples/aplusb/other/out/example_00.out": "0",
+		"v4/examples/aplusbc/tv/in/example_00.in":     "1 2 3",
+		"v4/examples/aplusbc/tv/out/example_00.out":   "6",
+	}}
+	ctx := context.Background()
+
+	statement, err := p.Statement(ctx, r)
+	if err != nil || string(statement) != "# A + B" {
+		t.Fatalf("unexpected statement: %q, %v", statement, err)
+	}
+
+	examples, err := p.Examples(ctx, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Example{
+		{Name: "example_00", In: []byte("1 2"), Out: []byte("3")},
+		{Name: "example_01", In: []byte("3 4"), Out: []byte("7")},
+	}
+	if !reflect.DeepEqual(examples, expected) {
+		t.Fatalf("unexpected examples: %+v", examples)
+	}
+
+	files, err := p.PublicFiles(ctx, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	if !reflect.DeepEqual(paths, []string{"common/fastio.h", "grader/solve.hpp", "task.md"}) {
+		t.Fatalf("unexpected files: %v", paths)
+	}
+
+	if data, err := p.PublicFile(ctx, r, "common/fastio.h"); err != nil || string(data) != "// fastio" {
+		t.Fatalf("unexpected common file: %q, %v", data, err)
+	}
+	if data, err := p.PublicFile(ctx, r, "grader/solve.hpp"); err != nil || string(data) != "int solve();" {
+		t.Fatalf("unexpected problem file: %q, %v", data, err)
+	}
+	// paths are cleaned, so they cannot escape the problem version
+	if _, err := p.PublicFile(ctx, r, "../../ov2/aplusb/task.md"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCachedPublicReader(t *testing.T) {
+	r := &memoryReader{objects: map[string]string{
+		"a": "1234",
+		"b": "5678",
+		"c": "90",
+	}}
+	c := NewCachedPublicReader(r, 8, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	read := func(key string) {
+		t.Helper()
+		if _, err := c.ReadPublic(ctx, key); err != nil {
+			t.Fatalf("read %s: %v", key, err)
+		}
+	}
+	read("a")
+	read("a")
+	if r.reads != 1 {
+		t.Fatalf("expected a cached read, got %d reads", r.reads)
+	}
+	read("b")
+	read("a")
+	read("c") // evicts b, the least recently used
+	read("a")
+	if r.reads != 3 {
+		t.Fatalf("a must stay cached, got %d reads", r.reads)
+	}
+	read("b")
+	if r.reads != 4 {
+		t.Fatalf("b must be evicted, got %d reads", r.reads)
+	}
+
+	if _, err := c.ReadPublic(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	r.objects["missing"] = "x"
+	if _, err := c.ReadPublic(ctx, "missing"); err != nil {
+		t.Fatalf("not found must not be cached: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ListPublic(ctx, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if r.lists != 1 {
+		t.Fatalf("expected a cached listing, got %d lists", r.lists)
+	}
+	now = now.Add(2 * time.Minute)
+	if _, err := c.ListPublic(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if r.lists != 2 {
+		t.Fatalf("listing must expire, got %d lists", r.lists)
+	}
+}
+
+func TestCachedPrivateReader(t *testing.T) {
+	ctx := context.Background()
+	p := Problem{Name: "aplusb", TestCaseVersion: "tv"}
+	r := &memoryReader{objects: map[string]string{}}
+	c := NewCachedPrivateReader(r, 1<<20)
+
+	if _, err := p.TestCaseManifest(ctx, c); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	r.objects[p.v4TestCaseManifestKey()] = `{"testcase_version":"tv","cases":[{"name":"example_00"}]}`
+	for i := 0; i < 2; i++ {
+		m, err := p.TestCaseManifest(ctx, c)
+		if err != nil || len(m.Cases) != 1 {
+			t.Fatalf("unexpected manifest: %+v, %v", m, err)
+		}
+	}
+	if r.reads != 2 {
+		t.Fatalf("the manifest must be cached after it is uploaded, got %d reads", r.reads)
+	}
+}
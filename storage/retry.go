@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// maxRetryBackoff caps the wait between the tries of retryBucket.
+const maxRetryBackoff = time.Minute
+
+// retryBucket retries the failed Put, Exists and List, waiting backoff before the
+// first retry and twice as long before each next one.
+type retryBucket struct {
+	Bucket
+	attempts int
+	backoff  time.Duration
+}
+
+func newRetryBucket(bucket Bucket, attempts int, backoff time.Duration) retryBucket {
+	return retryBucket{Bucket: bucket, attempts: attempts, backoff: backoff}
+}
+
+func (b retryBucket) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	return b.retry(ctx, "put", key, func() error {
+		// rewind what the failed try read
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return b.Bucket.Put(ctx, key, body)
+	})
+}
+
+func (b retryBucket) Exists(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := b.retry(ctx, "exists", key, func() error {
+		var err error
+		exists, err = b.Bucket.Exists(ctx, key)
+		return err
+	})
+	return exists, err
+}
+
+func (b retryBucket) List(ctx context.Context, prefix string) ([]ObjectAttrs, error) {
+	var objects []ObjectAttrs
+	err := b.retry(ctx, "list", prefix, func() error {
+		var err error
+		objects, err = b.Bucket.List(ctx, prefix)
+		return err
+	})
+	return objects, err
+}
+
+func (b retryBucket) retry(ctx context.Context, op, key string, f func() error) error {
+	backoff := b.backoff
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= b.attempts || ctx.Err() != nil {
+			return err
+		}
+		slog.Warn("Retry storage request", "op", op, "key", key, "attempt", attempt, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
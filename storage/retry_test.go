@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyBucket fails the first failures Puts of each key after reading a part
+// of the body, and records the most Puts running at once.
+type flakyBucket struct {
+	Bucket
+	failures int
+	mu       sync.Mutex
+	tries    map[string]int
+	running  int
+	peak     int
+}
+
+func (b *flakyBucket) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	b.mu.Lock()
+	b.tries[key]++
+	fail := b.tries[key] <= b.failures
+	b.running++
+	b.peak = max(b.peak, b.running)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.running--
+		b.mu.Unlock()
+	}()
+
+	time.Sleep(time.Millisecond)
+	if fail {
+		_, _ = body.Read(make([]byte, 1))
+		return errors.New("connection reset")
+	}
+	return b.Bucket.Put(ctx, key, body)
+}
+
+func TestUploadFilesWithRetry(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	flaky := &flakyBucket{Bucket: NewLocalBucket(filepath.Join(dir, "bucket")), failures: 2, tries: map[string]int{}}
+	client := NewClient(flaky, flaky).WithUploadOptions(UploadOptions{Concurrency: 3, Attempts: 3, Backoff: time.Millisecond})
+
+	files := map[string]string{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		local := filepath.Join(dir, name)
+		if err := os.WriteFile(local, []byte("content of "+name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		files["files/"+name] = local
+	}
+	if err := client.uploadFiles(ctx, client.bucket, files); err != nil {
+		t.Fatal(err)
+	}
+	for key, local := range files {
+		reader, err := flaky.Bucket.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "content of " + filepath.Base(local); string(data) != want {
+			t.Errorf("%s = %q, want %q", key, data, want)
+		}
+	}
+	if flaky.peak < 2 || flaky.peak > 3 {
+		t.Errorf("%d uploads ran at once, want 2 or 3", flaky.peak)
+	}
+
+	// gives up after the attempts
+	flaky.failures = 3
+	flaky.tries = map[string]int{}
+	err := client.uploadFiles(ctx, client.bucket, files)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("err = %v, want connection reset", err)
+	}
+	for key, tries := range flaky.tries {
+		if tries > 3 {
+			t.Errorf("%s is tried %d times", key, tries)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+
+	"golang.org/x/time/rate"
+)
+
+// throttledBucket limits the total read bandwidth of the objects of Get.
+type throttledBucket struct {
+	Bucket
+	limiter *rate.Limiter
+}
+
+func newThrottledBucket(bucket Bucket, limiter *rate.Limiter) throttledBucket {
+	return throttledBucket{Bucket: bucket, limiter: limiter}
+}
+
+func (b throttledBucket) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := b.Bucket.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &throttledReader{ReadCloser: reader, ctx: ctx, limiter: b.limiter}, nil
+}
+
+type unthrottleKey struct{}
+
+// withUnthrottle returns a context whose downloads are not throttled once
+// unthrottled is set.
+func withUnthrottle(ctx context.Context, unthrottled *atomic.Bool) context.Context {
+	return context.WithValue(ctx, unthrottleKey{}, unthrottled)
+}
+
+func isUnthrottled(ctx context.Context) bool {
+	unthrottled, ok := ctx.Value(unthrottleKey{}).(*atomic.Bool)
+	return ok && unthrottled.Load()
+}
+
+type throttledReader struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if isUnthrottled(r.ctx) {
+		return r.ReadCloser.Read(p)
+	}
+	// WaitN fails for more than the burst
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// WithBandwidthLimit returns a background downloader sharing the cache of t,
+// whose downloads are limited to bytesPerSec in total; 0 means unlimited. A
+// download is no longer limited once a Fetch of t waits for it. Only t should
+// be closed.
+func (t TestCaseDownloader) WithBandwidthLimit(bytesPerSec int) TestCaseDownloader {
+	t.background = true
+	if bytesPerSec <= 0 {
+		return t
+	}
+	burst := min(bytesPerSec, 256<<10)
+	limiter := rate.NewLimiter(rate.Limit(bytesPerSec), burst)
+	t.client = Client{
+		bucket:       newThrottledBucket(t.client.bucket, limiter),
+		publicBucket: newThrottledBucket(t.client.publicBucket, limiter),
+	}
+	return t
+}
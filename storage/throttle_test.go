@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestThrottledBucket(t *testing.T) {
+	ctx := context.Background()
+	local := NewLocalBucket(t.TempDir())
+	data := bytes.Repeat([]byte("x"), 2<<20+256<<10)
+	if err := local.Put(ctx, "a", bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the burst (256 KiB) is free, then 2 MiB at 4 MiB/s
+	b := newThrottledBucket(local, rate.NewLimiter(4<<20, 256<<10))
+	start := time.Now()
+	r, err := b.Get(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("content is changed")
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("read in %v, want about 500ms", elapsed)
+	}
+}
+
+func TestForegroundAcquireLiftsThrottle(t *testing.T) {
+	ctx := context.Background()
+	local := NewLocalBucket(t.TempDir())
+	data := bytes.Repeat([]byte("x"), 4<<20)
+	if err := local.Put(ctx, "a", bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	c, err := openDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = c.Close() }()
+
+	// 16 s at 256 KiB/s unless the throttle is lifted
+	b := newThrottledBucket(local, rate.NewLimiter(256<<10, 256<<10))
+	started := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, release, err := c.acquire(cacheKindPublic, "a", true, func(ctx context.Context, workDir, dest string) error {
+			close(started)
+			if err := downloadToFile(ctx, b, "a", filepath.Join(workDir, "entry", "a")); err != nil {
+				return err
+			}
+			return os.Rename(filepath.Join(workDir, "entry"), dest)
+		})
+		if err == nil {
+			release()
+		}
+		done <- err
+	}()
+
+	<-started
+	start := time.Now()
+	_, release, err := c.acquire(cacheKindPublic, "a", false, func(ctx context.Context, workDir, dest string) error {
+		t.Error("filled twice")
+		return fillWith(1)(ctx, workDir, dest)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("waited %v for the background fill", elapsed)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path"
@@ -205,33 +206,42 @@ func overallVersion(base, root string) (string, error) {
 }
 
 func (p UploadTarget) UploadTestcases(client Client) error {
-	tarGz, err := p.BuildTestCaseTarGz()
+	manifest, err := p.BuildTestCaseManifest()
 	if err != nil {
 		return err
 	}
-	defer func() { _ = os.Remove(tarGz) }()
-
-	// v4 upload (private) only
-	if err := p.Problem.UploadTestCasesV4(context.Background(), client, tarGz); err != nil {
+	if err := p.UploadTestCaseObjects(client, manifest); err != nil {
+		return err
+	}
+	if client.uploadTarball {
+		tarGz, err := p.BuildTestCaseTarGz()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(tarGz) }()
+		if err := p.Problem.UploadTestCasesV4(context.Background(), client, tarGz); err != nil {
+			return err
+		}
+	}
+	// last, so that a manifest always has its objects and tarball
+	if err := p.Problem.UploadTestCaseManifest(context.Background(), client, manifest); err != nil {
 		return err
 	}
 
-	// upload examples to the public bucket
+	// upload examples to the public bucket (v4 only)
+	examples := map[string]string{}
 	for _, ext := range []string{"in", "out"} {
 		if err := filepath.Walk(path.Join(p.Base, ext), func(fpath string, info fs.FileInfo, err error) error {
 			if strings.Contains(fpath, "example") {
-				// v4 only
-				if err := p.Problem.UploadPublicTestCaseV4(context.Background(), client, fpath, path.Join(ext, path.Base(fpath))); err != nil {
-					return err
-				}
+				examples[p.Problem.v4ExamplesKey(path.Join(ext, path.Base(fpath)))] = fpath
 			}
 			return nil
 		}); err != nil {
 			return err
 		}
 	}
-
-	return nil
+	slog.Info("Upload examples", "name", p.Problem.Name, "files", len(examples))
+	return client.uploadFiles(context.Background(), client.publicBucket, examples)
 }
 
 func (p UploadTarget) BuildTestCaseTarGz() (string, error) {
@@ -244,6 +254,22 @@ func (p UploadTarget) BuildTestCaseTarGz() (string, error) {
 	gzipWriter := gzip.NewWriter(tempFile)
 	tarWriter := tar.NewWriter(gzipWriter)
 
+	// hash.json is the manifest which the downloader verifies the files with
+	manifest, err := os.ReadFile(path.Join(p.Base, testCaseManifestName))
+	if err != nil {
+		return "", err
+	}
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name: testCaseManifestName,
+		Size: int64(len(manifest)),
+		Mode: 0600,
+	}); err != nil {
+		return "", err
+	}
+	if _, err := tarWriter.Write(manifest); err != nil {
+		return "", err
+	}
+
 	for _, ext := range []string{"in", "out"} {
 		if err := filepath.Walk(path.Join(p.Base, ext), func(fpath string, info fs.FileInfo, err error) error {
 			if path.Ext(fpath) == fmt.Sprintf(".%s", ext) {
@@ -290,6 +316,8 @@ func (p UploadTarget) BuildTestCaseTarGz() (string, error) {
 	return tempFile.Name(), nil
 }
 
+// Deprecated: nothing reads the v3 layout any more. Check that the v4 layout
+// is complete with `bucket audit` before removing it.
 func (p UploadTarget) UploadPublicFilesV3(client Client) error {
 	for _, info := range fileInfos(p.Base, p.Root) {
 		src := path.Join(info.base, info.path)
@@ -308,43 +336,44 @@ func (p UploadTarget) UploadPublicFilesV3(client Client) error {
 }
 
 func (p UploadTarget) UploadPublicFilesV4(client Client) error {
-	commonFiles, problemFiles, err := gitTrackedFiles(p.Base, p.Root)
+	files, err := p.publicFilesV4()
 	if err != nil {
 		return err
 	}
+	slog.Info("Upload public files", "name", p.Problem.Name, "files", len(files))
+	return client.uploadFiles(context.Background(), client.publicBucket, files)
+}
+
+// publicFilesV4 returns the local paths of the public files keyed by their v4
+// keys: the common/ files and the problem dir tracked by git, and params.h.
+func (p UploadTarget) publicFilesV4() (map[string]string, error) {
+	commonFiles, problemFiles, err := gitTrackedFiles(p.Base, p.Root)
+	if err != nil {
+		return nil, err
+	}
 
+	files := map[string]string{}
 	for _, relCommon := range commonFiles {
-		local := path.Join(p.Root, "common", relCommon)
-		remote := p.Problem.v4FilesCommonKey(relCommon)
-		if err := p.Problem.UploadPublicFileTo(context.Background(), client, local, remote); err != nil {
-			return err
-		}
+		files[p.Problem.v4FilesCommonKey(relCommon)] = path.Join(p.Root, "common", relCommon)
 	}
 
 	relProblemDir, err := filepath.Rel(p.Root, p.Base)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	relProblemDir = filepath.ToSlash(relProblemDir)
 	prefix := relProblemDir + "/"
 
 	for _, rel := range problemFiles {
-		local := path.Join(p.Root, rel)
 		sub := strings.TrimPrefix(filepath.ToSlash(rel), prefix)
-		remote := p.Problem.v4FilesProblemKey(sub)
-		if err := p.Problem.UploadPublicFileTo(context.Background(), client, local, remote); err != nil {
-			return err
-		}
+		files[p.Problem.v4FilesProblemKey(sub)] = path.Join(p.Root, rel)
 	}
 	// Also upload params.h (generated, not always tracked by git)
 	paramsLocal := path.Join(p.Base, "params.h")
 	if _, err := os.Stat(paramsLocal); err == nil {
-		paramsRemote := p.Problem.v4FilesProblemKey("params.h")
-		if err := p.Problem.UploadPublicFileTo(context.Background(), client, paramsLocal, paramsRemote); err != nil {
-			return err
-		}
+		files[p.Problem.v4FilesProblemKey("params.h")] = paramsLocal
 	}
-	return nil
+	return files, nil
 }
 
 func gitTrackedFiles(base, root string) ([]string, []string, error) {
@@ -380,11 +409,16 @@ func gitTrackedFiles(base, root string) ([]string, []string, error) {
 }
 
 func fileHash(path string) (string, error) {
-	checker, err := os.ReadFile(path)
+	file, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%x", sha256.Sum256(checker)), nil
+	defer func() { _ = file.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
 }
 
 func joinHashes(hashes []string) string {
@@ -38,6 +38,19 @@ resource "google_cloud_run_v2_service" "api_rest" {
         name  = "FIREBASE_PROJECT"
         value = data.google_project.main.project_id
       }
+      env {
+        name  = "STORAGE_PUBLIC_BUCKET"
+        value = google_storage_bucket.public.name
+      }
+      # instances share the counters; Google Front End appends the client address to X-Forwarded-For
+      env {
+        name  = "RATE_LIMIT_BACKEND"
+        value = "postgres"
+      }
+      env {
+        name  = "RATE_LIMIT_TRUSTED_PROXIES"
+        value = "1"
+      }
       volume_mounts {
         name       = "cloudsql"
         mount_path = "/cloudsql"
@@ -33,7 +33,7 @@ else
 fi
 
 echo "Testing database..."
-(cd database && go test ./... -v)
+(cd database && PGHOST=localhost go test ./... -v)
 
 echo "Testing REST API..."
 (cd restapi && go test ./... -v)
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/yosupo06/library-checker-judge/database"
+	"github.com/yosupo06/library-checker-judge/storage"
+	"gorm.io/gorm"
+)
+
+var (
+	app = kingpin.New("bucket", "Maintain the storage buckets")
+
+	gcCmd   = app.Command("gc", "Report the objects of stale problem versions, and delete them with --apply")
+	gcApply = gcCmd.Flag("apply", "Delete the stale objects").Bool()
+	gcGrace = gcCmd.Flag("grace", "Keep the objects updated, and the test case versions judged, within this period").Default("720h").Duration()
+	gcV3    = gcCmd.Flag("remove-current-v3", "Delete the v3 objects of the current versions too").Bool()
+	gcList  = gcCmd.Flag("list", "Print the key of every stale object").Bool()
+
+	auditCmd      = app.Command("audit", "Check that the buckets have the complete v4 layout of the current problem versions")
+	auditDir      = auditCmd.Flag("dir", "Directory of library-checker-problems to compare the public files with").String()
+	auditProblems = auditCmd.Arg("problem", "Problem names. If omitted, all problems are checked").Strings()
+)
+
+func main() {
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	case gcCmd.FullCommand():
+		if *gcGrace < 24*time.Hour {
+			app.Fatalf("--grace must be at least 24h")
+		}
+		runGC()
+	case auditCmd.FullCommand():
+		runAudit()
+	}
+}
+
+func runGC() {
+	ctx := context.Background()
+	db := database.Connect(database.GetDSNFromEnv(), false)
+	client, err := storage.Connect(ctx, storage.GetConfigFromEnv())
+	if err != nil {
+		log.Fatal("connect to storage failed:", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	deadline := time.Now().Add(-*gcGrace)
+	kept, err := keptVersions(db, deadline, *gcV3)
+	if err != nil {
+		log.Fatal("fetch versions failed:", err)
+	}
+	report, err := client.FindStaleObjects(ctx, kept, deadline)
+	if err != nil {
+		log.Fatal("list objects failed:", err)
+	}
+
+	if *gcList {
+		for _, obj := range report.Stale {
+			bucket := "private"
+			if obj.Public {
+				bucket = "public"
+			}
+			fmt.Printf("%s\t%s\t%d\t%s\n", bucket, obj.Key, obj.Size, obj.Updated.Format(time.RFC3339))
+		}
+	}
+	log.Printf("stale: %d objects (%s), kept: %d, in grace period: %d, unknown: %d",
+		len(report.Stale), formatBytes(report.StaleSize()), report.Kept, report.Recent, report.Unknown)
+
+	if !*gcApply {
+		log.Print("dry run; rerun with --apply to delete the stale objects")
+		return
+	}
+	if err := client.DeleteObjects(ctx, report.Stale); err != nil {
+		log.Fatal("delete failed:", err)
+	}
+	log.Printf("deleted %d objects", len(report.Stale))
+}
+
+func runAudit() {
+	ctx := context.Background()
+	db := database.Connect(database.GetDSNFromEnv(), false)
+	client, err := storage.Connect(ctx, storage.GetConfigFromEnv())
+	if err != nil {
+		log.Fatal("connect to storage failed:", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	names := *auditProblems
+	if len(names) == 0 {
+		problems, err := database.FetchProblemList(db)
+		if err != nil {
+			log.Fatal("fetch problems failed:", err)
+		}
+		for _, p := range problems {
+			names = append(names, p.Name)
+		}
+	}
+	auditor, err := storage.NewAuditor(ctx, client)
+	if err != nil {
+		log.Fatal("list objects failed:", err)
+	}
+
+	counts := map[string]int{}
+	for _, name := range names {
+		p, err := database.FetchProblem(db, name)
+		if err != nil {
+			log.Fatalf("fetch problem %s failed: %v", name, err)
+		}
+		problem := toStorageProblem(p)
+		var local *storage.UploadTarget
+		if *auditDir != "" {
+			local = localTarget(*auditDir, problem)
+		}
+		issues, err := auditor.AuditProblem(ctx, problem, local)
+		if err != nil {
+			log.Fatalf("audit %s failed: %v", name, err)
+		}
+		for _, issue := range issues {
+			fmt.Printf("%s\t%s\n", name, issue)
+			counts[issue.Kind]++
+		}
+	}
+
+	total := 0
+	for _, kind := range []string{storage.AuditMissing, storage.AuditMismatch, storage.AuditExtra, storage.AuditNoV4} {
+		total += counts[kind]
+	}
+	log.Printf("%d problems, %d issues (missing: %d, mismatch: %d, extra: %d, no-v4: %d)", len(names), total,
+		counts[storage.AuditMissing], counts[storage.AuditMismatch], counts[storage.AuditExtra], counts[storage.AuditNoV4])
+	if total > 0 {
+		os.Exit(1)
+	}
+}
+
+// localTarget returns the problem dir in problemsDir if it is of the same
+// version as p, or nil.
+func localTarget(problemsDir string, p storage.Problem) *storage.UploadTarget {
+	tomls, err := filepath.Glob(filepath.Join(problemsDir, "*", p.Name, "info.toml"))
+	if err != nil || len(tomls) != 1 {
+		log.Printf("%s: problem dir is not found in %s", p.Name, problemsDir)
+		return nil
+	}
+	target, err := storage.NewUploadTarget(filepath.Dir(tomls[0]), problemsDir)
+	if err != nil {
+		log.Printf("%s: failed to read problem dir: %v", p.Name, err)
+		return nil
+	}
+	if target.Problem.OverallVersion != p.OverallVersion {
+		log.Printf("%s: problem dir is of another version, skip comparing the files", p.Name)
+		return nil
+	}
+	return &target
+}
+
+func toStorageProblem(p database.Problem) storage.Problem {
+	return storage.Problem{
+		Name:            p.Name,
+		Version:         p.Version,
+		OverallVersion:  p.OverallVersion,
+		TestCaseVersion: p.TestCasesVersion,
+	}
+}
+
+// keptVersions returns the current versions of the problems and the test case
+// versions of the submissions judged after since.
+func keptVersions(db *gorm.DB, since time.Time, removeV3 bool) (*storage.KeptVersions, error) {
+	kept := storage.NewKeptVersions(removeV3)
+	problems, err := database.FetchProblemList(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range problems {
+		// the list has no versions
+		p, err := database.FetchProblem(db, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		kept.AddProblem(toStorageProblem(p))
+	}
+	judged, err := database.FetchJudgedTestCasesVersions(db, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range judged {
+		kept.AddTestCases(v.ProblemName, v.TestCasesVersion)
+	}
+	log.Printf("keep %d problems and %d judged test case versions", len(problems), len(judged))
+	return kept, nil
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
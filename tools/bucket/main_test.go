@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yosupo06/library-checker-judge/database"
+	"github.com/yosupo06/library-checker-judge/storage"
+)
+
+func TestKeptVersions(t *testing.T) {
+	ctx := context.Background()
+	db := database.CreateTestDB(t)
+	if err := database.SaveProblem(db, database.Problem{Name: "aplusb", Title: "A + B", Version: "v", OverallVersion: "ov", TestCasesVersion: "tv"}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if _, err := database.SaveSubmission(db, database.Submission{ProblemName: "aplusb", Source: "source", TestCasesVersion: "judged", JudgedTime: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	private := storage.NewLocalBucket(filepath.Join(dir, "private"))
+	client := storage.NewClient(private, storage.NewLocalBucket(filepath.Join(dir, "public")))
+	old := now.Add(-60 * 24 * time.Hour)
+	for _, key := range []string{"v4/testcase/aplusb/tv.tar.gz", "v4/testcase/aplusb/judged.tar.gz", "v4/testcase/aplusb/old.tar.gz"} {
+		if err := private.Put(ctx, key, strings.NewReader(key)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filepath.Join(dir, "private", filepath.FromSlash(key)), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := now.Add(-30 * 24 * time.Hour)
+	kept, err := keptVersions(db, deadline, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := client.FindStaleObjects(ctx, kept, deadline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Stale) != 1 || report.Stale[0].Key != "v4/testcase/aplusb/old.tar.gz" || report.Kept != 2 {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 3 << 30: "3.0 GiB"} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"log"
 	"os"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/yosupo06/library-checker-judge/database"
@@ -10,19 +12,89 @@ import (
 
 var (
 	app                  = kingpin.New("rejudge", "Queue submissions for rejudge")
-	rejudgeSubmissionIDs = app.Arg("id", "Submission ID").Required().Int32List()
+	rejudgeSubmissionIDs = app.Arg("id", "Submission ID. If omitted, submissions are selected by the filters").Int32List()
+
+	problem      = app.Flag("problem", "Filter by problem name").String()
+	statuses     = app.Flag("status", "Filter by status (e.g. AC); repeat to match any of them").Strings()
+	langs        = app.Flag("lang", "Filter by language; repeat to match any of them").Strings()
+	user         = app.Flag("user", "Filter by user name").String()
+	outdatedOnly = app.Flag("outdated", "Only submissions judged with an old test case version").Bool()
+	since        = app.Flag("since", "Only submissions at or after this time (RFC3339 or 2006-01-02)").String()
+	until        = app.Flag("until", "Only submissions before this time (RFC3339 or 2006-01-02)").String()
+	dryRun       = app.Flag("dry-run", "Only print the number of matched submissions").Bool()
+	priority     = app.Flag("priority", "Task priority of filtered rejudges, lower than rejudges by users (40)").Default("5").Int32()
+	rate         = app.Flag("rate", "Tasks enqueued per second for filtered rejudges").Default("10").Int32()
 )
 
+// rejudgeJobCreator is the CreatedBy of the jobs created by this CLI.
+const rejudgeJobCreator = "rejudge-cli"
+
 func main() {
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 	db := database.Connect(database.GetDSNFromEnv(), true)
 
-	for _, id := range *rejudgeSubmissionIDs {
-		log.Print("rejudge:", id)
-		if err := database.PushSubmissionTask(db, database.SubmissionData{
-			ID: id,
-		}, 45); err != nil {
-			log.Print("rejudge failed:", err)
+	if len(*rejudgeSubmissionIDs) > 0 {
+		for _, id := range *rejudgeSubmissionIDs {
+			log.Print("rejudge:", id)
+			if err := database.PushSystemSubmissionTask(db, database.SubmissionData{
+				ID: id,
+			}, 45); err != nil {
+				log.Print("rejudge failed:", err)
+			}
+		}
+		return
+	}
+
+	filter := database.SubmissionFilter{
+		Problem:  *problem,
+		Statuses: *statuses,
+		Langs:    *langs,
+		User:     *user,
+		Since:    parseTime(*since),
+		Until:    parseTime(*until),
+	}
+	if *outdatedOnly {
+		isLatest := false
+		filter.IsLatest = &isLatest
+	}
+	if filter.Empty() {
+		app.Fatalf("specify submission IDs or at least one filter")
+	}
+	if *priority < 0 || *priority >= 40 {
+		app.Fatalf("--priority must be in [0, 40)")
+	}
+	if *rate < 1 || *rate > 100 {
+		app.Fatalf("--rate must be in [1, 100]")
+	}
+
+	if *dryRun {
+		count, err := database.CountRejudgeTargets(db, filter)
+		if err != nil {
+			log.Fatal("count failed:", err)
+		}
+		log.Printf("%d submissions match (dry run)", count)
+		return
+	}
+
+	// queued by the API servers, as the bulk rejudges of the admin API
+	job, err := database.CreateRejudgeJob(db, filter, *priority, *rate, rejudgeJobCreator)
+	if errors.Is(err, database.ErrAlreadyExists) {
+		log.Fatal("the same bulk rejudge is running")
+	} else if err != nil {
+		log.Fatal("create bulk rejudge failed:", err)
+	}
+	log.Printf("bulk rejudge job %d created: %d submissions (priority: %d, rate: %d/s)", job.ID, job.Total, *priority, *rate)
+}
+
+func parseTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
 		}
 	}
+	app.Fatalf("invalid time: %s", s)
+	return time.Time{}
 }
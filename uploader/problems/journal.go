@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// Steps of the upload of a problem recorded in the journal.
+const (
+	// stepTestCases is the upload of the test cases of TestCaseVersion
+	stepTestCases = "testcases"
+	// stepPublicFiles is the upload of the public files of OverallVersion
+	stepPublicFiles = "public-files"
+	// stepSaved is the save of the problem of OverallVersion to the database
+	stepSaved = "saved"
+)
+
+type journalEntry struct {
+	Problem string `json:"problem"`
+	Step    string `json:"step"`
+	Version string `json:"version"`
+}
+
+// journal records the finished steps in a JSON lines file, so that a rerun
+// of an interrupted upload skips them. Without a file it records nothing.
+type journal struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[journalEntry]bool
+}
+
+func openJournal(path string) (*journal, error) {
+	j := &journal{done: map[journalEntry]bool{}}
+	if path == "" {
+		return j, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var e journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// the last line may be cut by the interruption
+			slog.Warn("Ignore broken journal line", "line", scanner.Text())
+			continue
+		}
+		j.done[e] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if j.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644); err != nil {
+		return nil, err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		if _, err := j.file.WriteString("\n"); err != nil {
+			_ = j.file.Close()
+			return nil, err
+		}
+	}
+	slog.Info("Open journal", "path", path, "finished_steps", len(j.done))
+	return j, nil
+}
+
+func (j *journal) has(problem, step, version string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.done[journalEntry{Problem: problem, Step: step, Version: version}]
+}
+
+// record writes the entry to the disk before it returns.
+func (j *journal) record(problem, step, version string) error {
+	e := journalEntry{Problem: problem, Step: step, Version: version}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.done[e] = true
+	if j.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+// remove deletes the file once every problem is uploaded, so that the next run
+// starts over.
+func (j *journal) remove() error {
+	if j.file == nil {
+		return nil
+	}
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(j.file.Name())
+}
+
+func (j *journal) close() error {
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := openJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.record("aplusb", stepTestCases, "h"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.close(); err != nil {
+		t.Fatal(err)
+	}
+	// interrupted while writing the next line
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(`{"problem":"aplusb","st`); err != nil {
+		t.Fatal(err)
+	}
+	_ = file.Close()
+
+	j, err = openJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !j.has("aplusb", stepTestCases, "h") {
+		t.Error("finished step is not recorded")
+	}
+	if j.has("aplusb", stepTestCases, "h2") || j.has("aplusb", stepPublicFiles, "h") {
+		t.Error("unfinished step is recorded")
+	}
+	if err := j.record("aplusb", stepPublicFiles, "ov"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err = openJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !j.has("aplusb", stepTestCases, "h") || !j.has("aplusb", stepPublicFiles, "ov") {
+		t.Error("steps are lost after the broken line")
+	}
+	if err := j.remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("journal is not removed: %v", err)
+	}
+}
+
+func TestJournalWithoutFile(t *testing.T) {
+	j, err := openJournal("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.record("aplusb", stepSaved, "ov"); err != nil {
+		t.Fatal(err)
+	}
+	if !j.has("aplusb", stepSaved, "ov") {
+		t.Error("step is not recorded")
+	}
+	if err := j.remove(); err != nil {
+		t.Fatal(err)
+	}
+}
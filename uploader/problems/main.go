@@ -8,11 +8,15 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/webhook"
 	"github.com/yosupo06/library-checker-judge/database"
 	"github.com/yosupo06/library-checker-judge/storage"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -22,6 +26,13 @@ func main() {
 
 	forceUpload := flag.Bool("force", false, "force upload even if the version is the same")
 
+	jobs := flag.Int("jobs", 1, "number of problems generated and uploaded at once")
+	uploadJobs := flag.Int("upload-jobs", 8, "number of files uploaded at once, shared by all problems")
+	attempts := flag.Int("attempts", 5, "number of tries of each storage request")
+	retryBackoff := flag.Duration("retry-backoff", time.Second, "wait before the first retry of a storage request, doubled for each next one")
+	uploadTarball := flag.Bool("upload-tarball", false, "also upload the whole test cases as a tarball, for judges which do not read the manifests")
+	journalPath := flag.String("journal", "", "file recording the finished steps; a rerun after an interruption skips them, and the file is removed when all problems are uploaded")
+
 	flag.Parse()
 
 	tomls := flag.Args()
@@ -46,128 +57,205 @@ func main() {
 		slog.Error("Failed to connect to storage", "err", err)
 		os.Exit(1)
 	}
+	storageClient = storageClient.WithUploadOptions(storage.UploadOptions{
+		Concurrency:     *uploadJobs,
+		Attempts:        *attempts,
+		Backoff:         *retryBackoff,
+		TestCaseTarball: *uploadTarball,
+	})
 
-	for _, t := range tomls {
-		slog.Info("Upload problem", "toml", t)
+	j, err := openJournal(*journalPath)
+	if err != nil {
+		slog.Error("Failed to open journal", "err", err)
+		os.Exit(1)
+	}
 
-		// clean testcase & generate params.h
-		if err := clean(*problemsDir, t); err != nil {
-			slog.Error("Failed to clean", "err", err)
-			os.Exit(1)
-		}
+	u := uploader{
+		problemsDir: *problemsDir,
+		force:       *forceUpload,
+		db:          db,
+		storage:     storageClient,
+		discord:     dc,
+		journal:     j,
+	}
+	failed := uploadAll(tomls, *jobs, u.uploadProblem)
+	if len(failed) != 0 {
+		_ = j.close()
+		slog.Error("Failed to upload problems; rerun to retry them", "failed", len(failed), "tomls", failed)
+		os.Exit(1)
+	}
+	if err := j.remove(); err != nil {
+		slog.Error("Failed to remove journal", "err", err)
+		os.Exit(1)
+	}
 
-		// generate problem info
-		target, err := storage.NewUploadTarget(path.Dir(t), *problemsDir)
-		if err != nil {
-			slog.Error("Failed to build UploadTarget", "err", err)
-			os.Exit(1)
-		}
-		name := target.Problem.Name
-		v := target.Problem.Version
-		ov := target.Problem.OverallVersion
-		h := target.Problem.TestCaseVersion
-		slog.Info("Problem info", "name", name, "version", v, "overall_version", ov, "hash", h)
-
-		// fetch problem info from database
-		dbP, err := database.FetchProblem(db, name)
-		newProblem := (err == database.ErrNotExist)
-		if newProblem {
-			slog.Info("New problem")
-			dbP = database.Problem{
-				Name: name,
+	// Note: Category upload is handled by separate CLI: ./categories
+}
+
+// uploadAll uploads the problems of tomls with jobs workers, and returns the
+// tomls failed.
+func uploadAll(tomls []string, jobs int, upload func(toml string) error) []string {
+	queue := make(chan string)
+	var mu sync.Mutex
+	failed := []string{}
+	var wg sync.WaitGroup
+	for range max(jobs, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				if err := upload(t); err != nil {
+					slog.Error("Failed to upload problem", "toml", t, "err", err)
+					mu.Lock()
+					failed = append(failed, t)
+					mu.Unlock()
+				}
 			}
-		} else if err != nil {
-			slog.Error("Failed to fetch problem", "err", err)
-			os.Exit(1)
-		}
+		}()
+	}
+	for _, t := range tomls {
+		queue <- t
+	}
+	close(queue)
+	wg.Wait()
+	sort.Strings(failed)
+	return failed
+}
 
-		// parse info.toml
-		info, err := storage.ParseInfo(t)
-		if err != nil {
-			slog.Error("Failed to parse info.toml", "err", err)
-			os.Exit(1)
-		}
+type uploader struct {
+	problemsDir string
+	force       bool
+	db          *gorm.DB
+	storage     storage.Client
+	discord     webhook.Client
+	journal     *journal
+}
 
-		versionUpdated := (v != dbP.Version)
-		overallVersionUpdated := (ov != dbP.OverallVersion)
-		testcaseUpdated := (h != dbP.TestCasesVersion)
-
-		// update problem fields
-		dbP.Title = info.Title
-		dbP.Timelimit = int32(info.TimeLimit * 1000)
-		dbP.SourceUrl = toSourceURL(t)
-		dbP.Version = v
-		dbP.OverallVersion = ov
-		dbP.TestCasesVersion = h
-
-		// upload test cases (v4 only)
-		if testcaseUpdated || *forceUpload {
-			if err := generate(*problemsDir, t); err != nil {
-				slog.Error("Failed to generate", "err", err)
-				os.Exit(1)
-			}
-			if err := target.UploadTestcases(storageClient); err != nil {
-				slog.Error("Failed to upload test cases", "err", err)
-				os.Exit(1)
-			}
-		} else {
-			slog.Info("Skip to upload test cases")
+func (u uploader) uploadProblem(t string) error {
+	slog.Info("Upload problem", "toml", t)
+
+	// clean testcase & generate params.h
+	if err := clean(u.problemsDir, t); err != nil {
+		return fmt.Errorf("clean: %w", err)
+	}
+
+	// generate problem info
+	target, err := storage.NewUploadTarget(path.Dir(t), u.problemsDir)
+	if err != nil {
+		return fmt.Errorf("build UploadTarget: %w", err)
+	}
+	name := target.Problem.Name
+	v := target.Problem.Version
+	ov := target.Problem.OverallVersion
+	h := target.Problem.TestCaseVersion
+	slog.Info("Problem info", "name", name, "version", v, "overall_version", ov, "hash", h)
+
+	if u.journal.has(name, stepSaved, ov) {
+		slog.Info("Skip problem uploaded by the previous run", "name", name)
+		return nil
+	}
+
+	// fetch problem info from database
+	dbP, err := database.FetchProblem(u.db, name)
+	newProblem := (err == database.ErrNotExist)
+	if newProblem {
+		slog.Info("New problem", "name", name)
+		dbP = database.Problem{
+			Name: name,
 		}
+	} else if err != nil {
+		return fmt.Errorf("fetch problem: %w", err)
+	}
 
-		// upload public files (v4 only)
-		// Trigger v4 upload when either Version or OverallVersion changed, or forced
-		if versionUpdated || overallVersionUpdated || *forceUpload {
-			if err := target.UploadPublicFilesV4(storageClient); err != nil {
-				slog.Error("Failed to upload public files (v4)", "err", err)
-				os.Exit(1)
-			}
+	// parse info.toml
+	info, err := storage.ParseInfo(t)
+	if err != nil {
+		return fmt.Errorf("parse info.toml: %w", err)
+	}
+
+	versionUpdated := (v != dbP.Version)
+	overallVersionUpdated := (ov != dbP.OverallVersion)
+	testcaseUpdated := (h != dbP.TestCasesVersion)
+
+	// update problem fields
+	dbP.Title = info.Title
+	dbP.Timelimit = int32(info.TimeLimit * 1000)
+	dbP.SourceUrl = toSourceURL(t)
+	dbP.Version = v
+	dbP.OverallVersion = ov
+	dbP.TestCasesVersion = h
+	dbP.TestCaseCount = int32(len(info.TestCaseNames()))
+
+	// upload test cases (v4 only)
+	if (testcaseUpdated || u.force) && !u.journal.has(name, stepTestCases, h) {
+		if err := generate(u.problemsDir, t); err != nil {
+			return fmt.Errorf("generate: %w", err)
 		}
-		if !versionUpdated && !overallVersionUpdated && !*forceUpload {
-			slog.Info("Skip to upload public files")
+		if err := target.UploadTestcases(u.storage); err != nil {
+			return fmt.Errorf("upload test cases: %w", err)
 		}
-
-		if err := clean(*problemsDir, t); err != nil {
-			slog.Error("Failed to clean", "err", err)
-			os.Exit(1)
+		if err := u.journal.record(name, stepTestCases, h); err != nil {
+			return fmt.Errorf("record journal: %w", err)
 		}
+	} else {
+		slog.Info("Skip to upload test cases", "name", name)
+	}
 
-		if err := database.SaveProblem(db, dbP); err != nil {
-			slog.Error("Failed to upload problem info", "err", err)
-			os.Exit(1)
+	// upload public files (v4 only)
+	// Trigger v4 upload when either Version or OverallVersion changed, or forced
+	if (versionUpdated || overallVersionUpdated || u.force) && !u.journal.has(name, stepPublicFiles, ov) {
+		if err := target.UploadPublicFilesV4(u.storage); err != nil {
+			return fmt.Errorf("upload public files (v4): %w", err)
+		}
+		if err := u.journal.record(name, stepPublicFiles, ov); err != nil {
+			return fmt.Errorf("record journal: %w", err)
 		}
+	} else {
+		slog.Info("Skip to upload public files", "name", name)
+	}
 
-		if dc != nil && testcaseUpdated {
-			if newProblem {
-				if _, err := dc.CreateMessage(discord.NewWebhookMessageCreateBuilder().
-					AddEmbeds(discord.NewEmbedBuilder().
-						SetTitlef("New problem added: %s", info.Title).
-						SetColor(0x00ff00).
-						SetURLf("https://judge.yosupo.jp/problem/%s", name).
-						AddField("Github", fmt.Sprintf("[link](%s)", dbP.SourceUrl), false).
-						AddField("Test case hash", v[0:16], false).
-						Build()).
-					Build(),
-				); err != nil {
-					slog.Error("Failed to send message", "err", err)
-				}
-			} else {
-				if _, err := dc.CreateMessage(discord.NewWebhookMessageCreateBuilder().
-					AddEmbeds(discord.NewEmbedBuilder().
-						SetTitlef("Testcase updated: %s", info.Title).
-						SetColor(0x0000ff).
-						SetURLf("https://judge.yosupo.jp/problem/%s", name).
-						AddField("Github", fmt.Sprintf("[link](%s)", dbP.SourceUrl), false).
-						AddField("New test case hash", v[0:16], false).
-						Build()).
-					Build(),
-				); err != nil {
-					slog.Error("Failed to send message", "err", err)
-				}
+	if err := clean(u.problemsDir, t); err != nil {
+		return fmt.Errorf("clean: %w", err)
+	}
+
+	if err := database.SaveProblem(u.db, dbP); err != nil {
+		return fmt.Errorf("upload problem info: %w", err)
+	}
+
+	if u.discord != nil && testcaseUpdated {
+		if newProblem {
+			if _, err := u.discord.CreateMessage(discord.NewWebhookMessageCreateBuilder().
+				AddEmbeds(discord.NewEmbedBuilder().
+					SetTitlef("New problem added: %s", info.Title).
+					SetColor(0x00ff00).
+					SetURLf("https://judge.yosupo.jp/problem/%s", name).
+					AddField("Github", fmt.Sprintf("[link](%s)", dbP.SourceUrl), false).
+					AddField("Test case hash", v[0:16], false).
+					Build()).
+				Build(),
+			); err != nil {
+				slog.Error("Failed to send message", "err", err)
+			}
+		} else {
+			if _, err := u.discord.CreateMessage(discord.NewWebhookMessageCreateBuilder().
+				AddEmbeds(discord.NewEmbedBuilder().
+					SetTitlef("Testcase updated: %s", info.Title).
+					SetColor(0x0000ff).
+					SetURLf("https://judge.yosupo.jp/problem/%s", name).
+					AddField("Github", fmt.Sprintf("[link](%s)", dbP.SourceUrl), false).
+					AddField("New test case hash", v[0:16], false).
+					Build()).
+				Build(),
+			); err != nil {
+				slog.Error("Failed to send message", "err", err)
 			}
 		}
 	}
 
-	// Note: Category upload is handled by separate CLI: ./categories
+	if err := u.journal.record(name, stepSaved, ov); err != nil {
+		return fmt.Errorf("record journal: %w", err)
+	}
+	return nil
 }
 
 func generate(problemsDir, tomlPath string) error {
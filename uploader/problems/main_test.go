@@ -1,6 +1,10 @@
 package main
 
 import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -10,3 +14,36 @@ func TestToSourceURL(t *testing.T) {
 		t.Fatal("URL is differ", url)
 	}
 }
+
+func TestUploadAll(t *testing.T) {
+	var running, peak atomic.Int32
+	// the uploads wait until 3 of them run at once
+	block := make(chan struct{})
+	var unblock sync.Once
+	upload := func(toml string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if n == 3 {
+			unblock.Do(func() { close(block) })
+		}
+		<-block
+		if toml == "b/info.toml" || toml == "d/info.toml" {
+			return errors.New("upload failed")
+		}
+		return nil
+	}
+	tomls := []string{"d/info.toml", "a/info.toml", "b/info.toml", "c/info.toml", "e/info.toml"}
+	failed := uploadAll(tomls, 3, upload)
+	if want := []string{"b/info.toml", "d/info.toml"}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("failed = %v, want %v", failed, want)
+	}
+	if peak.Load() != 3 {
+		t.Errorf("%d problems ran at once, want 3", peak.Load())
+	}
+}
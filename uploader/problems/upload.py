@@ -20,12 +20,15 @@ if __name__ == "__main__":
 
     DISCORD_WEBHOOK = environ["DISCORD_WEBHOOK"]
     FORCE_UPLOAD = environ["FORCE_UPLOAD"]
+    # optional; a rerun with the same journal skips the problems already uploaded
+    UPLOAD_JOURNAL = environ.get("UPLOAD_JOURNAL", "")
 
     subprocess.run(
         ["./uploader"] +
         ["-discordwebhook", DISCORD_WEBHOOK] +
         ["-dir", "../library-checker-problems"] +
         (["-force"] if FORCE_UPLOAD == "true" else []) +
+        (["-journal", UPLOAD_JOURNAL] if UPLOAD_JOURNAL else []) +
         [str(toml.absolute()) for toml in tomls],
         check=True
     )